@@ -52,6 +52,8 @@ type Addenda98 struct {
 	//
 	// Use TraceNumberField for a properly formatted string representation.
 	TraceNumber string `json:"traceNumber,omitempty"`
+	// ChangeDetails describes the ChangeCode and CorrectedData, it is only set by File.Enrich
+	ChangeDetails *ChangeDetails `json:"changeDetails,omitempty"`
 
 	// validator is composed for data validation
 	validator
@@ -285,11 +287,11 @@ func IsRefusedChangeCode(code string) bool {
 //
 // All fields are optional and a valid code may not have populated data in this struct.
 type CorrectedData struct {
-	AccountNumber   string
-	RoutingNumber   string
-	Name            string
-	TransactionCode int
-	Identification  string
+	AccountNumber   string `json:"accountNumber,omitempty"`
+	RoutingNumber   string `json:"routingNumber,omitempty"`
+	Name            string `json:"name,omitempty"`
+	TransactionCode int    `json:"transactionCode,omitempty"`
+	Identification  string `json:"identification,omitempty"`
 }
 
 type correctedDataOptions struct {
@@ -65,6 +65,8 @@ type Addenda99 struct {
 	TraceNumber string `json:"traceNumber,omitempty"`
 	// Line number at which the record appears in the file
 	LineNumber int `json:"lineNumber,omitempty"`
+	// ReturnDetails describes the ReturnCode, it is only set by File.Enrich
+	ReturnDetails *ReturnDetails `json:"returnDetails,omitempty"`
 
 	// validator is composed for data validation
 	validator
@@ -0,0 +1,131 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"cmp"
+	"fmt"
+	"time"
+)
+
+// advMaxEntries is the most ADVEntryDetail records a batch can hold, their SequenceNumber is four digits.
+const advMaxEntries = 9999
+
+// ADVActivity is a settlement accounting entry to advise a financial institution of.
+type ADVActivity struct {
+	// TransactionCode is an ADV accounting code such as CreditForDebitsOriginated (81) or DebitSummary (88).
+	TransactionCode int
+
+	// RoutingNumber is the nine digit routing number of the financial institution being advised.
+	RoutingNumber string
+
+	// AccountNumber is the settlement account at RoutingNumber.
+	AccountNumber string
+
+	// Amount in cents
+	Amount int
+
+	// AdviceRoutingNumber is the routing number of the institution the activity is for.
+	AdviceRoutingNumber string
+
+	// FileIdentification identifies the file the activity came from.
+	FileIdentification string
+
+	// ACHOperatorData is optional information from the ACH operator.
+	ACHOperatorData string
+
+	// IndividualName is the name of the financial institution or account.
+	IndividualName string
+
+	// DiscretionaryData is optional.
+	DiscretionaryData string
+}
+
+// ADVFileBuilder creates ADV files advising financial institutions of their settlement activity.
+type ADVFileBuilder struct {
+	// Header is the FileHeader of built files.
+	Header FileHeader
+
+	// BatchHeader is copied into each batch. Its StandardEntryClassCode, ServiceClassCode and
+	// OriginatorStatusCode are set for ADV and batches are numbered from its BatchNumber.
+	BatchHeader BatchHeader
+
+	// ACHOperatorRoutingNumber is the first eight digits of the ACH operator's routing number.
+	ACHOperatorRoutingNumber string
+
+	// JulianDay is the day of the year the activity was processed. When zero the day of
+	// BatchHeader.EffectiveEntryDate is used.
+	JulianDay int
+}
+
+// Build returns a created ADV file with an entry for each activity record. Batches are split so no more than
+// 9,999 entries (the limit of ADV sequence numbers) are in a batch, and the file has ADV batch and file controls.
+func (b ADVFileBuilder) Build(activity []ADVActivity) (*File, error) {
+	if len(activity) == 0 {
+		return nil, ErrBatchNoEntries
+	}
+
+	julianDay := b.JulianDay
+	if julianDay == 0 && b.BatchHeader.EffectiveEntryDate != "" {
+		effective, err := time.Parse("060102", b.BatchHeader.EffectiveEntryDate)
+		if err != nil {
+			return nil, fieldError("EffectiveEntryDate", err, b.BatchHeader.EffectiveEntryDate)
+		}
+		julianDay = effective.YearDay()
+	}
+
+	file := NewFile()
+	file.SetHeader(b.Header)
+
+	for start := 0; start < len(activity); start += advMaxEntries {
+		bh := b.BatchHeader
+		bh.StandardEntryClassCode = ADV
+		bh.ServiceClassCode = AutomatedAccountingAdvices
+		bh.OriginatorStatusCode = 0
+		bh.BatchNumber = cmp.Or(b.BatchHeader.BatchNumber, 1) + start/advMaxEntries
+
+		batch := NewBatchADV(&bh)
+		for i, a := range activity[start:min(start+advMaxEntries, len(activity))] {
+			if err := CheckRoutingNumber(a.RoutingNumber); err != nil {
+				return nil, fmt.Errorf("activity %d: %w", start+i, err)
+			}
+			entry := NewADVEntryDetail()
+			entry.TransactionCode = a.TransactionCode
+			entry.SetRDFI(a.RoutingNumber)
+			entry.DFIAccountNumber = a.AccountNumber
+			entry.Amount = a.Amount
+			entry.AdviceRoutingNumber = a.AdviceRoutingNumber
+			entry.FileIdentification = a.FileIdentification
+			entry.ACHOperatorData = a.ACHOperatorData
+			entry.IndividualName = a.IndividualName
+			entry.DiscretionaryData = a.DiscretionaryData
+			entry.ACHOperatorRoutingNumber = b.ACHOperatorRoutingNumber
+			entry.JulianDay = julianDay
+			batch.AddADVEntry(entry)
+		}
+		if err := batch.Create(); err != nil {
+			return nil, fmt.Errorf("batch %d: %w", bh.BatchNumber, err)
+		}
+		file.AddBatch(batch)
+	}
+
+	if err := file.Create(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
@@ -0,0 +1,143 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mockADVFileBuilder() ADVFileBuilder {
+	bh := NewBatchHeader()
+	bh.CompanyName = "Your Company, inc"
+	bh.CompanyIdentification = "121042882"
+	bh.CompanyEntryDescription = "Accounting"
+	bh.ODFIIdentification = "12104288"
+	bh.EffectiveEntryDate = "260219"
+
+	return ADVFileBuilder{
+		Header:                   mockFileHeader(),
+		BatchHeader:              *bh,
+		ACHOperatorRoutingNumber: "01100001",
+	}
+}
+
+func mockADVActivity() ADVActivity {
+	return ADVActivity{
+		TransactionCode:     CreditForDebitsOriginated,
+		RoutingNumber:       "231380104",
+		AccountNumber:       "744-5678-99",
+		Amount:              50000,
+		AdviceRoutingNumber: "121042882",
+		FileIdentification:  "11131",
+		IndividualName:      "Name",
+	}
+}
+
+func TestADVFileBuilder(t *testing.T) {
+	debit := mockADVActivity()
+	debit.TransactionCode = DebitForCreditsOriginated
+	debit.Amount = 12500
+
+	file, err := mockADVFileBuilder().Build([]ADVActivity{mockADVActivity(), debit, mockADVActivity()})
+	require.NoError(t, err)
+	require.True(t, file.IsADV())
+	require.Len(t, file.Batches, 1)
+
+	batch := file.Batches[0]
+	require.Equal(t, ADV, batch.GetHeader().StandardEntryClassCode)
+	require.Equal(t, AutomatedAccountingAdvices, batch.GetHeader().ServiceClassCode)
+
+	entries := batch.GetADVEntries()
+	require.Len(t, entries, 3)
+	for i, entry := range entries {
+		require.Equal(t, i+1, entry.SequenceNumber)
+		require.Equal(t, 50, entry.JulianDay)
+		require.Equal(t, "01100001", entry.ACHOperatorRoutingNumber)
+		require.Equal(t, "23138010", entry.RDFIIdentification)
+	}
+
+	require.Equal(t, 100000, batch.GetADVControl().TotalCreditEntryDollarAmount)
+	require.Equal(t, 12500, batch.GetADVControl().TotalDebitEntryDollarAmount)
+	require.Equal(t, 1, file.ADVControl.BatchCount)
+	require.Equal(t, 3, file.ADVControl.EntryAddendaCount)
+	require.Equal(t, 100000, file.ADVControl.TotalCreditEntryDollarAmountInFile)
+	require.Equal(t, 12500, file.ADVControl.TotalDebitEntryDollarAmountInFile)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+	read, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.Equal(t, file.ADVControl.EntryHash, read.ADVControl.EntryHash)
+}
+
+func TestADVFileBuilder__Split(t *testing.T) {
+	activity := make([]ADVActivity, advMaxEntries+1)
+	for i := range activity {
+		activity[i] = mockADVActivity()
+	}
+
+	// A full batch doesn't need to be split
+	file, err := mockADVFileBuilder().Build(activity[:advMaxEntries])
+	require.NoError(t, err)
+	require.Len(t, file.Batches, 1)
+	require.Equal(t, advMaxEntries, file.Batches[0].GetADVEntries()[advMaxEntries-1].SequenceNumber)
+
+	file, err = mockADVFileBuilder().Build(activity)
+	require.NoError(t, err)
+	require.Len(t, file.Batches, 2)
+	require.Len(t, file.Batches[0].GetADVEntries(), advMaxEntries)
+	require.Len(t, file.Batches[1].GetADVEntries(), 1)
+	require.Equal(t, 1, file.Batches[0].GetHeader().BatchNumber)
+	require.Equal(t, 2, file.Batches[1].GetHeader().BatchNumber)
+	require.Equal(t, 1, file.Batches[1].GetADVEntries()[0].SequenceNumber)
+
+	require.Equal(t, 2, file.ADVControl.BatchCount)
+	require.Equal(t, advMaxEntries+1, file.ADVControl.EntryAddendaCount)
+	require.Equal(t, (advMaxEntries+1)*50000, file.ADVControl.TotalCreditEntryDollarAmountInFile)
+	require.NoError(t, file.Validate())
+}
+
+func TestADVFileBuilder__Errors(t *testing.T) {
+	builder := mockADVFileBuilder()
+
+	_, err := builder.Build(nil)
+	require.ErrorIs(t, err, ErrBatchNoEntries)
+
+	invalid := mockADVActivity()
+	invalid.RoutingNumber = "231380105"
+	_, err = builder.Build([]ADVActivity{mockADVActivity(), invalid})
+	require.ErrorContains(t, err, "activity 1: routing number checksum mismatch")
+
+	invalid = mockADVActivity()
+	invalid.TransactionCode = CheckingCredit
+	_, err = builder.Build([]ADVActivity{invalid})
+	require.ErrorIs(t, err, ErrBatchTransactionCode)
+
+	builder.BatchHeader.EffectiveEntryDate = "261399"
+	_, err = builder.Build([]ADVActivity{mockADVActivity()})
+	require.ErrorContains(t, err, "EffectiveEntryDate")
+
+	builder.JulianDay = 100
+	file, err := builder.Build([]ADVActivity{mockADVActivity()})
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(strings.TrimSpace(file.Batches[0].GetADVEntries()[0].String()), "1000001"))
+}
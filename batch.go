@@ -480,14 +480,14 @@ func (batch *Batch) build() error {
 			if entry.Addenda99 != nil {
 				entryCount++
 			}
+			if seq > advMaxEntries {
+				return batch.Error("SequenceNumber", ErrBatchADVCount)
+			}
+
 			// Set Sequence Number
 			batch.ADVEntries[i].SequenceNumber = seq
 
 			seq++
-
-			if seq > 9999 {
-				return batch.Error("SequenceNumber", ErrBatchADVCount)
-			}
 		}
 		// build a BatchADVControl record
 		bcADV := NewADVBatchControl()
@@ -1221,6 +1221,10 @@ func (b *Batch) WithOffset(off *Offset) {
 	b.offset = off
 }
 
+func (b *Batch) getOffset() *Offset {
+	return b.offset
+}
+
 const offsetIndividualName = "OFFSET"
 
 func (b *Batch) upsertOffsets() error {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // BatchDNE is a batch file that handles SEC code Death Notification Entry (DNE)
@@ -96,6 +97,21 @@ func (batch *BatchDNE) InvalidEntries() []InvalidEntry {
 				Error: batch.Error("AddendaCount", NewErrBatchAddendaCount(len(entry.Addenda05), 1)),
 			})
 		}
+		// Verify the Addenda05 contains the date of death, SSN and amount
+		if batch.validateOpts != nil && batch.validateOpts.RequireDNEPaymentInformation {
+			for _, addenda05 := range entry.Addenda05 {
+				info, err := ParseDNEPaymentInformation(addenda05)
+				if err == nil {
+					err = info.Validate()
+				}
+				if err != nil {
+					out = append(out, InvalidEntry{
+						Entry: entry,
+						Error: batch.Error("PaymentRelatedInformation", err, addenda05.PaymentRelatedInformation),
+					})
+				}
+			}
+		}
 		// Verify the Amount is valid for SEC code and TransactionCode
 		if err := batch.ValidAmountForCodes(entry); err != nil {
 			out = append(out, InvalidEntry{
@@ -135,29 +151,51 @@ func (batch *BatchDNE) Create() error {
 	return batch.Validate()
 }
 
+// DNEPaymentInformation is the structured content of a DNE Addenda05's PaymentRelatedInformation.
+//
+// Example: DATE OF DEATH*MMDDYY*CUSTOMERSSN*#########*AMOUNT*$$$$.cc\
 type DNEPaymentInformation struct {
-	DateOfDeath time.Time
-	CustomerSSN string
+	DateOfDeath time.Time `json:"dateOfDeath"`
+
+	// CustomerSSN is the deceased's nine digit Social Security Number, or all zeros if there is none.
+	CustomerSSN string `json:"customerSSN"`
 
 	// Amount is a two-decimal float value formatted as a string
 	// Example: 123.45
-	Amount string
+	Amount string `json:"amount"`
 }
 
+const (
+	dneDateOfDeathLabel = "DATE OF DEATH"
+	dneCustomerSSNLabel = "CUSTOMERSSN"
+	dneAmountLabel      = "AMOUNT"
+)
+
 // ParseDNEPaymentInformation returns an DNEPaymentInformation for a given Addenda05 record. The information is parsed from the addenda's
 // PaymentRelatedInformation field.
 //
-// The returned information is not validated for correctness.
+// The returned information is not validated for correctness, call Validate to check it.
 func ParseDNEPaymentInformation(addenda05 *Addenda05) (*DNEPaymentInformation, error) {
 	if addenda05 == nil {
 		return nil, nil
 	}
 
-	fields := strings.Split(strings.TrimSuffix(addenda05.PaymentRelatedInformation, `\`), "*")
+	fields := strings.Split(strings.TrimSuffix(strings.TrimSpace(addenda05.PaymentRelatedInformation), `\`), "*")
 	if len(fields) != 6 {
 		return nil, fmt.Errorf("unexpected %d fields", len(fields))
 	}
 
+	// Older files have used "CUSTOMER SSN" as the label
+	if fields[0] != dneDateOfDeathLabel {
+		return nil, fmt.Errorf("unexpected label %q, expected %q", fields[0], dneDateOfDeathLabel)
+	}
+	if label := strings.ReplaceAll(fields[2], " ", ""); label != dneCustomerSSNLabel {
+		return nil, fmt.Errorf("unexpected label %q, expected %q", fields[2], dneCustomerSSNLabel)
+	}
+	if fields[4] != dneAmountLabel {
+		return nil, fmt.Errorf("unexpected label %q, expected %q", fields[4], dneAmountLabel)
+	}
+
 	dateOfDeath, err := time.Parse("010206", fields[1])
 	if err != nil {
 		return nil, fmt.Errorf("parsing DateOfDeath: %w", err)
@@ -170,7 +208,40 @@ func ParseDNEPaymentInformation(addenda05 *Addenda05) (*DNEPaymentInformation, e
 	}, nil
 }
 
+// Validate checks the DateOfDeath is set, CustomerSSN is nine digits and Amount is
+// formatted in dollars and cents (e.g. 123.45). Amount may be left blank.
+func (info DNEPaymentInformation) Validate() error {
+	if info.DateOfDeath.IsZero() {
+		return fieldError("DateOfDeath", ErrFieldRequired)
+	}
+	if err := validateDigits(info.CustomerSSN, 9); err != nil {
+		return fieldError("CustomerSSN", err, info.CustomerSSN)
+	}
+	if info.Amount != "" {
+		dollars, cents, found := strings.Cut(info.Amount, ".")
+		if !found || dollars == "" || validateDigits(dollars, len(dollars)) != nil || validateDigits(cents, 2) != nil {
+			return fieldError("Amount", ErrNonNumeric, info.Amount)
+		}
+	}
+	return nil
+}
+
 func (info DNEPaymentInformation) String() string {
-	return fmt.Sprintf(`DATE OF DEATH*%s*CUSTOMER SSN*%s*AMOUNT*%s\`,
-		info.DateOfDeath.Format("010206"), info.CustomerSSN, info.Amount)
+	return fmt.Sprintf(`DATE OF DEATH*%s*%s*%s*AMOUNT*%s\`,
+		info.DateOfDeath.Format("010206"), dneCustomerSSNLabel, info.CustomerSSN, info.Amount)
+}
+
+// Addenda05 validates info and returns an Addenda05 record containing it. The record is the
+// entry's first addenda, its EntryDetailSequenceNumber is set when added to a batch.
+func (info DNEPaymentInformation) Addenda05() (*Addenda05, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	addenda05 := NewAddenda05()
+	addenda05.PaymentRelatedInformation = info.String()
+	addenda05.SequenceNumber = 1
+	if utf8.RuneCountInString(addenda05.PaymentRelatedInformation) > 80 {
+		return nil, fieldError("PaymentRelatedInformation", ErrExceedsFieldLength, addenda05.PaymentRelatedInformation)
+	}
+	return addenda05, nil
 }
@@ -18,6 +18,7 @@
 package ach
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -298,6 +299,82 @@ func TestDNRPaymentInformation(t *testing.T) {
 		CustomerSSN: "333224444",
 		Amount:      "123.45",
 	}
-	expected := `DATE OF DEATH*082824*CUSTOMER SSN*333224444*AMOUNT*123.45\`
+	expected := `DATE OF DEATH*082824*CUSTOMERSSN*333224444*AMOUNT*123.45\`
 	require.Equal(t, expected, info.String())
 }
+
+func TestDNEPaymentInformation__Validate(t *testing.T) {
+	info := DNEPaymentInformation{
+		DateOfDeath: time.Date(2024, time.August, 28, 0, 0, 0, 0, time.UTC),
+		CustomerSSN: "000000000",
+		Amount:      "123.45",
+	}
+	require.NoError(t, info.Validate())
+
+	addenda05, err := info.Addenda05()
+	require.NoError(t, err)
+	require.NoError(t, addenda05.Validate())
+
+	parsed, err := ParseDNEPaymentInformation(addenda05)
+	require.NoError(t, err)
+	require.Equal(t, info, *parsed)
+
+	info.Amount = ""
+	require.NoError(t, info.Validate())
+
+	for _, amount := range []string{"$$$$.cc", "123", "123.4", ".45", "1,234.56"} {
+		info.Amount = amount
+		require.ErrorIs(t, info.Validate(), ErrNonNumeric, amount)
+	}
+	info.Amount = "123.45"
+
+	info.CustomerSSN = "#########"
+	require.ErrorIs(t, info.Validate(), ErrNonNumeric)
+	info.CustomerSSN = "1234"
+	require.ErrorContains(t, info.Validate(), "is not length 9")
+
+	_, err = DNEPaymentInformation{CustomerSSN: "333224444"}.Addenda05()
+	require.ErrorIs(t, err, ErrFieldRequired)
+
+	// The formatted information must fit in one Addenda05
+	info.CustomerSSN = "333224444"
+	info.Amount = strings.Repeat("9", 30) + ".00"
+	require.NoError(t, info.Validate())
+	_, err = info.Addenda05()
+	require.ErrorIs(t, err, ErrExceedsFieldLength)
+}
+
+func TestBatchDNE__ParseDNEPaymentInformationLabels(t *testing.T) {
+	addenda05 := NewAddenda05()
+
+	// Older files label the SSN with a space
+	addenda05.PaymentRelatedInformation = `DATE OF DEATH*082824*CUSTOMER SSN*333224444*AMOUNT*123.45\`
+	info, err := ParseDNEPaymentInformation(addenda05)
+	require.NoError(t, err)
+	require.Equal(t, "333224444", info.CustomerSSN)
+
+	addenda05.PaymentRelatedInformation = `DATE OF BIRTH*082824*CUSTOMERSSN*333224444*AMOUNT*123.45\`
+	_, err = ParseDNEPaymentInformation(addenda05)
+	require.ErrorContains(t, err, `unexpected label "DATE OF BIRTH"`)
+
+	addenda05.PaymentRelatedInformation = `DATE OF DEATH*082824*CUSTOMERSSN*333224444*TOTAL*123.45\`
+	_, err = ParseDNEPaymentInformation(addenda05)
+	require.ErrorContains(t, err, `unexpected label "TOTAL"`)
+}
+
+func TestBatchDNE__InvalidPaymentInformation(t *testing.T) {
+	// The placeholder text from the Nacha rules is only rejected when required
+	batch := mockBatchDNE(t)
+	require.NoError(t, batch.Validate())
+
+	batch.SetValidation(&ValidateOpts{RequireDNEPaymentInformation: true})
+	err := batch.Validate()
+	require.ErrorIs(t, err, ErrNonNumeric)
+	require.ErrorContains(t, err, "PaymentRelatedInformation")
+
+	batch.Entries[0].Addenda05[0].PaymentRelatedInformation = "death notification"
+	require.ErrorContains(t, batch.Validate(), "unexpected 1 fields")
+
+	batch.Entries[0].Addenda05[0].PaymentRelatedInformation = `DATE OF DEATH*010218*CUSTOMERSSN*333224444*AMOUNT*1234.56\`
+	require.NoError(t, batch.Validate())
+}
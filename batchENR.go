@@ -142,23 +142,23 @@ func (batch *BatchENR) Create() error {
 type ENRPaymentInformation struct {
 	// TransactionCode is the Transaction Code of the holder's account
 	// Values: 22 (Demand  Credit), 27 (Demand Debit), 32 (Savings Credit), 37 (Savings Debit)
-	TransactionCode int
+	TransactionCode int `json:"transactionCode"`
 
 	// RDFIIdentification is the Receiving Depository Identification Number. Typically the first 8 of their ABA routing number.
-	RDFIIdentification string
+	RDFIIdentification string `json:"RDFIIdentification"`
 
 	// CheckDigit is the last digit from an ABA routing number.
-	CheckDigit string
+	CheckDigit string `json:"checkDigit"`
 
 	// DFIAccountNumber contains the holder's account number.
-	DFIAccountNumber string
+	DFIAccountNumber string `json:"DFIAccountNumber"`
 
 	// IndividualIdentification contains the customer's Social Security Number (SSN) for automated enrollments and the
 	// taxpayer ID for companies.
-	IndividualIdentification string
+	IndividualIdentification string `json:"individualIdentification"`
 
 	// IndividualName is the account holders full name.
-	IndividualName string
+	IndividualName string `json:"individualName"`
 
 	// EnrolleeClassificationCode (also called Representative Payee Indicator) returns a code from a specific Addenda05 record.
 	// These codes represent:
@@ -166,7 +166,7 @@ type ENRPaymentInformation struct {
 	//  1: (yes) - Initiated by someone other than named beneficiary
 	//  A: Enrollee is a consumer
 	//  b: Enrollee is a company
-	EnrolleeClassificationCode string
+	EnrolleeClassificationCode string `json:"enrolleeClassificationCode"`
 }
 
 func (info ENRPaymentInformation) String() string {
@@ -202,6 +202,52 @@ func (info ENRPaymentInformation) String() string {
 		info.EnrolleeClassificationCode)
 }
 
+// Validate checks info contains a valid ENR TransactionCode, routing number, account, identification,
+// name and EnrolleeClassificationCode.
+func (info ENRPaymentInformation) Validate() error {
+	switch info.TransactionCode {
+	case CheckingCredit, CheckingDebit, SavingsCredit, SavingsDebit:
+	default:
+		return fieldError("TransactionCode", ErrTransactionCode, info.TransactionCode)
+	}
+	if err := validateDigits(info.RDFIIdentification, 8); err != nil {
+		return fieldError("RDFIIdentification", err, info.RDFIIdentification)
+	}
+	if check := CalculateCheckDigit(info.RDFIIdentification); info.CheckDigit != strconv.Itoa(check) {
+		return fieldError("CheckDigit", NewErrValidCheckDigit(check), info.CheckDigit)
+	}
+	if strings.TrimSpace(info.DFIAccountNumber) == "" {
+		return fieldError("DFIAccountNumber", ErrFieldRequired)
+	}
+	if err := validateDigits(info.IndividualIdentification, 9); err != nil {
+		return fieldError("IndividualIdentification", err, info.IndividualIdentification)
+	}
+	if strings.TrimSpace(info.IndividualName) == "" {
+		return fieldError("IndividualName", ErrFieldRequired)
+	}
+	switch strings.ToUpper(info.EnrolleeClassificationCode) {
+	case "0", "1", "A", "B":
+	default:
+		return fieldError("EnrolleeClassificationCode", ErrEnrolleeClassificationCode, info.EnrolleeClassificationCode)
+	}
+	return nil
+}
+
+// Addenda05 validates info and returns an Addenda05 record containing it. The record is the
+// entry's first addenda, its EntryDetailSequenceNumber is set when added to a batch.
+func (info ENRPaymentInformation) Addenda05() (*Addenda05, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+	addenda05 := NewAddenda05()
+	addenda05.PaymentRelatedInformation = info.String()
+	addenda05.SequenceNumber = 1
+	if utf8.RuneCountInString(addenda05.PaymentRelatedInformation) > 80 {
+		return nil, fieldError("PaymentRelatedInformation", ErrExceedsFieldLength, addenda05.PaymentRelatedInformation)
+	}
+	return addenda05, nil
+}
+
 // ParseENRPaymentInformation returns an ENRPaymentInformation for a given Addenda05 record. The information is parsed from the addenda's
 // PaymentRelatedInformation field.
 //
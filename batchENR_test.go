@@ -18,6 +18,7 @@
 package ach
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/moov-io/base"
@@ -390,3 +391,55 @@ func TestBatchENRAddenda02(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+func TestENRPaymentInformation__Addenda05(t *testing.T) {
+	info := ENRPaymentInformation{
+		TransactionCode:            CheckingDebit,
+		RDFIIdentification:         "12200004",
+		CheckDigit:                 "3",
+		DFIAccountNumber:           "987654321123",
+		IndividualIdentification:   "876543210",
+		IndividualName:             "ABC ELECTRONIC INDUSTRIES",
+		EnrolleeClassificationCode: "B",
+	}
+	addenda05, err := info.Addenda05()
+	require.NoError(t, err)
+	require.NoError(t, addenda05.Validate())
+	require.Equal(t, `27*12200004*3*987654321123*876543210*ABC ELECTRONIC*INDUSTR*B\`, addenda05.PaymentRelatedInformation)
+
+	batch := mockBatchENR(t)
+	batch.Entries[0].Addenda05[0] = addenda05
+	require.NoError(t, batch.Create())
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := info
+		invalid.TransactionCode = CheckingPrenoteCredit
+		require.ErrorIs(t, invalid.Validate(), ErrTransactionCode)
+
+		invalid = info
+		invalid.CheckDigit = "4"
+		require.ErrorContains(t, invalid.Validate(), "does not match calculated check digit 3")
+
+		invalid = info
+		invalid.RDFIIdentification = "1220000"
+		require.ErrorContains(t, invalid.Validate(), "is not length 8")
+
+		invalid = info
+		invalid.IndividualIdentification = "87654321A"
+		require.ErrorIs(t, invalid.Validate(), ErrNonNumeric)
+
+		invalid = info
+		invalid.IndividualName = " "
+		require.ErrorIs(t, invalid.Validate(), ErrFieldRequired)
+
+		invalid = info
+		invalid.EnrolleeClassificationCode = "C"
+		_, err := invalid.Addenda05()
+		require.ErrorIs(t, err, ErrEnrolleeClassificationCode)
+
+		invalid = info
+		invalid.DFIAccountNumber = strings.Repeat("1", 40)
+		_, err = invalid.Addenda05()
+		require.ErrorIs(t, err, ErrExceedsFieldLength)
+	})
+}
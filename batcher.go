@@ -55,6 +55,35 @@ type Batcher interface {
 	SetValidation(*ValidateOpts)
 }
 
+// GenericBatch is the set of methods shared by domestic batches (Batcher) and IAT batches (*IATBatch).
+// Code which only needs to create, validate or total a batch can accept a GenericBatch and work on
+// both File.Batches and File.IATBatches alike. Use a type switch for the header and entry records as
+// their types differ between domestic and IAT batches.
+//
+// Some batch operations stay specific to each kind of batch:
+//   - WithOffset is only on Batcher. An offset is a domestic EntryDetail, and an IAT batch can't hold
+//     one without the Addenda10 through Addenda16 records every IAT entry requires.
+//   - File.FlattenBatches and File.SegmentFile copy headers and move entries between batches, so they
+//     keep a domestic and an IAT path for those differing record types.
+//   - Iterator reads one entry at a time without building batches, returning either kind of entry
+//     from Next.
+type GenericBatch interface {
+	GetControl() *BatchControl
+	SetControl(*BatchControl)
+	Create() error
+	Validate() error
+	ValidateTotals() error
+	// Category defines if a Forward or Return
+	Category() string
+	Error(string, error, ...interface{}) error
+	SetValidation(*ValidateOpts)
+}
+
+var (
+	_ GenericBatch = (Batcher)(nil)
+	_ GenericBatch = (*IATBatch)(nil)
+)
+
 // Offset contains the associated information to append an 'Offset Record' on an ACH batch during Create.
 type Offset struct {
 	RoutingNumber string            `json:"routingNumber"`
@@ -0,0 +1,34 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/cmd/achcli/browse"
+	"github.com/moov-io/ach/describe/mask"
+)
+
+func browseFile(path string, validateOpts *ach.ValidateOpts) error {
+	// Files with errors are still browsed so the errors can be inspected
+	file, err := readIncomingFile(path, validateOpts)
+	if file == nil {
+		if err == nil {
+			err = fmt.Errorf("unable to read %s", path)
+		}
+		return err
+	}
+	return browse.Run(os.Stdin, os.Stdout, file, err, browse.Options{
+		Options: mask.Options{
+			MaskAccountNumbers: *flagMask || *flagMaskAccounts,
+			MaskCorrectedData:  *flagMask || *flagMaskCorrectedData,
+			MaskNames:          *flagMask || *flagMaskNames,
+		},
+		PrettyAmounts: *flagPretty || *flagPrettyAmounts,
+		ClearScreen:   true,
+	})
+}
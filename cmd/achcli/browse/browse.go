@@ -0,0 +1,517 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package browse implements an interactive terminal browser for ACH files.
+//
+// Commands are read line by line so the browser works on any terminal which
+// understands plain ANSI escape codes.
+package browse
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/describe"
+	"github.com/moov-io/ach/describe/mask"
+	"github.com/moov-io/base"
+
+	"github.com/juju/ansiterm"
+)
+
+const (
+	clearScreen = "\033[H\033[2J"
+
+	defaultPageSize = 20
+)
+
+type Options struct {
+	// Options are the fields masked when masking is toggled on. Masking starts
+	// enabled if any option is set, otherwise all fields are masked once toggled.
+	mask.Options
+
+	PrettyAmounts bool
+
+	// ClearScreen clears the terminal before each view is rendered
+	ClearScreen bool
+
+	// PageSize is how many batches, entries or errors are listed at once
+	PageSize int
+}
+
+type view int
+
+const (
+	fileView view = iota
+	batchView
+	entryView
+	searchView
+	errorsView
+)
+
+// state is a screen of the browser which can be returned to
+type state struct {
+	view    view
+	batch   *batchNode
+	entry   *entryNode
+	results []*entryNode
+	query   string
+	page    int
+}
+
+// lineError is an error found in the file and the line it occurred on, if known
+type lineError struct {
+	line int
+	err  error
+}
+
+type browser struct {
+	in   *bufio.Scanner
+	out  io.Writer
+	opts Options
+
+	model  *model
+	errors []lineError
+	masked bool
+
+	current state
+	history []state
+	message string
+}
+
+// Run browses file, reading commands from in and rendering each view to out until
+// the user quits or in is exhausted. fileErr is the error returned when reading the
+// file, whose parse errors can be jumped to by their line number.
+func Run(in io.Reader, out io.Writer, file *ach.File, fileErr error, opts Options) error {
+	if file == nil {
+		return errors.New("nil ACH file")
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultPageSize
+	}
+	b := &browser{
+		in:     bufio.NewScanner(in),
+		out:    out,
+		opts:   opts,
+		model:  newModel(file),
+		errors: collectErrors(fileErr),
+		masked: opts.Options != (mask.Options{}),
+	}
+	for {
+		b.render()
+
+		if !b.in.Scan() {
+			fmt.Fprintln(b.out)
+			return b.in.Err()
+		}
+		if quit := b.handle(strings.TrimSpace(b.in.Text())); quit {
+			return nil
+		}
+	}
+}
+
+func collectErrors(err error) []lineError {
+	if err == nil {
+		return nil
+	}
+	var out []lineError
+	var list base.ErrorList
+	if errors.As(err, &list) {
+		for i := range list {
+			out = append(out, collectErrors(list[i])...)
+		}
+		return out
+	}
+	var perr base.ParseError
+	if errors.As(err, &perr) {
+		return []lineError{{line: perr.Line, err: err}}
+	}
+	return []lineError{{err: err}}
+}
+
+// handle performs a single command and reports if the browser should exit
+func (b *browser) handle(cmd string) bool {
+	b.message = ""
+
+	switch {
+	case cmd == "q" || cmd == "quit" || cmd == "exit":
+		return true
+
+	case cmd == "" || cmd == "n":
+		if b.current.page+1 < b.pages() {
+			b.current.page++
+		} else {
+			b.message = "no more pages"
+		}
+
+	case cmd == "p":
+		if b.current.page > 0 {
+			b.current.page--
+		} else {
+			b.message = "already on the first page"
+		}
+
+	case cmd == "b" || cmd == "..":
+		if len(b.history) == 0 {
+			b.message = "already at the top"
+			return false
+		}
+		b.current = b.history[len(b.history)-1]
+		b.history = b.history[:len(b.history)-1]
+
+	case cmd == "t":
+		b.history = nil
+		b.current = state{view: fileView}
+
+	case cmd == "m":
+		b.masked = !b.masked
+		if b.masked {
+			b.message = "masking enabled"
+		} else {
+			b.message = "masking disabled"
+		}
+
+	case cmd == "e":
+		b.push(state{view: errorsView})
+
+	case cmd == "h" || cmd == "?":
+		b.message = help
+
+	case strings.HasPrefix(cmd, "/"):
+		b.search(strings.TrimSpace(cmd[1:]))
+
+	case strings.HasPrefix(cmd, ":"):
+		n, err := strconv.Atoi(strings.TrimSpace(cmd[1:]))
+		if err != nil {
+			b.message = fmt.Sprintf("invalid line number %q", cmd[1:])
+			return false
+		}
+		b.jump(n)
+
+	default:
+		n, err := strconv.Atoi(cmd)
+		if err != nil {
+			b.message = fmt.Sprintf("unknown command %q, type h for help", cmd)
+			return false
+		}
+		b.open(n)
+	}
+	return false
+}
+
+const help = `Commands:
+  <number>   open the numbered batch, entry or error
+  n, Enter   next page
+  p          previous page
+  b, ..      go back
+  t          go to the top of the file
+  /<query>   search entries by trace number, name or amount (e.g. /1000 or /10.00)
+  e          list errors found in the file
+  :<line>    jump to the record on a line of the file
+  m          toggle masking of names, account numbers and corrected data
+  h, ?       show this help
+  q          quit`
+
+func (b *browser) push(next state) {
+	b.history = append(b.history, b.current)
+	b.current = next
+}
+
+// open selects the numbered item listed on the current page
+func (b *browser) open(n int) {
+	idx := n - 1
+	switch b.current.view {
+	case fileView:
+		if idx < 0 || idx >= len(b.model.batches) {
+			b.message = fmt.Sprintf("no batch #%d", n)
+			return
+		}
+		b.push(state{view: batchView, batch: b.model.batches[idx]})
+
+	case batchView, searchView:
+		entries := b.current.results
+		if b.current.view == batchView {
+			entries = b.current.batch.entries
+		}
+		if idx < 0 || idx >= len(entries) {
+			b.message = fmt.Sprintf("no entry #%d", n)
+			return
+		}
+		b.push(state{view: entryView, batch: entries[idx].batch, entry: entries[idx]})
+
+	case errorsView:
+		if idx < 0 || idx >= len(b.errors) {
+			b.message = fmt.Sprintf("no error #%d", n)
+			return
+		}
+		if b.errors[idx].line <= 0 {
+			b.message = "error has no line number"
+			return
+		}
+		b.jump(b.errors[idx].line)
+
+	default:
+		b.message = "nothing to open, type b to go back"
+	}
+}
+
+// jump shows the batch or entry found on a line of the file
+func (b *browser) jump(line int) {
+	loc, exists := b.model.lines[line]
+	switch {
+	case exists && loc.entry != nil:
+		b.push(state{view: entryView, batch: loc.batch, entry: loc.entry})
+	case exists && loc.batch != nil:
+		b.push(state{view: batchView, batch: loc.batch})
+	case line >= 1:
+		// FileHeader, FileControl or a line the file could not be parsed past
+		b.push(state{view: fileView})
+		b.message = fmt.Sprintf("line %d is not part of a batch", line)
+	default:
+		b.message = fmt.Sprintf("invalid line number %d", line)
+	}
+}
+
+func (b *browser) search(query string) {
+	if query == "" {
+		b.message = "empty search, try /<trace number>, /<name> or /<amount>"
+		return
+	}
+	amount := parseAmount(query)
+	lower := strings.ToLower(query)
+
+	var results []*entryNode
+	for _, batch := range b.model.batches {
+		for _, e := range batch.entries {
+			switch {
+			case strings.HasPrefix(e.traceNumber, query),
+				amount >= 0 && e.amount == amount,
+				strings.Contains(strings.ToLower(e.name), lower):
+				results = append(results, e)
+			}
+		}
+	}
+	b.push(state{view: searchView, results: results, query: query})
+}
+
+// parseAmount reads cents ("1000") or dollars ("10.00") and returns -1 otherwise
+func parseAmount(s string) int {
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return n
+	}
+	if strings.Contains(s, ".") {
+		if f, err := strconv.ParseFloat(s, 64); err == nil && f >= 0 {
+			return int(math.Round(f * 100))
+		}
+	}
+	return -1
+}
+
+// pages returns how many pages the current view has
+func (b *browser) pages() int {
+	var items int
+	switch b.current.view {
+	case fileView:
+		items = len(b.model.batches)
+	case batchView:
+		items = len(b.current.batch.entries)
+	case searchView:
+		items = len(b.current.results)
+	case errorsView:
+		items = len(b.errors)
+	}
+	if items == 0 {
+		return 1
+	}
+	return (items + b.opts.PageSize - 1) / b.opts.PageSize
+}
+
+// pageRange returns the indexes of items shown on the current page
+func (b *browser) pageRange(items int) (int, int) {
+	start := b.current.page * b.opts.PageSize
+	end := start + b.opts.PageSize
+	if end > items {
+		end = items
+	}
+	return start, end
+}
+
+func (b *browser) maskOptions() mask.Options {
+	if !b.masked {
+		return mask.Options{}
+	}
+	if b.opts.Options == (mask.Options{}) {
+		return mask.Options{
+			MaskNames:          true,
+			MaskAccountNumbers: true,
+			MaskCorrectedData:  true,
+			MaskIdentification: true,
+		}
+	}
+	return b.opts.Options
+}
+
+func (b *browser) render() {
+	if b.opts.ClearScreen {
+		io.WriteString(b.out, clearScreen)
+	}
+	w := ansiterm.NewTabWriter(b.out, 0, 0, 2, ' ', 0)
+
+	switch b.current.view {
+	case fileView:
+		b.renderFile(w)
+	case batchView:
+		b.renderBatch(w)
+	case entryView:
+		b.renderEntry(w)
+	case searchView:
+		b.renderSearch(w)
+	case errorsView:
+		b.renderErrors(w)
+	}
+
+	if pages := b.pages(); pages > 1 {
+		fmt.Fprintf(w, "\n  page %d of %d\n", b.current.page+1, pages)
+	}
+	if b.message != "" {
+		fmt.Fprintf(w, "\n%s\n", b.message)
+	}
+	ansiterm.Foreground(ansiterm.Green).Fprint(w, "\n> ")
+	w.Flush()
+}
+
+func title(w *ansiterm.TabWriter, format string, args ...interface{}) {
+	ctx := ansiterm.Foreground(ansiterm.Yellow)
+	ctx.SetStyle(ansiterm.Bold)
+	ctx.Fprintf(w, format, args...)
+	fmt.Fprintln(w)
+}
+
+func (b *browser) renderFile(w *ansiterm.TabWriter) {
+	fh := b.model.file.Header
+	title(w, "File (line 1)")
+	fmt.Fprintln(w, "  Origin\tOriginName\tDestination\tDestinationName\tFileCreationDate\tFileCreationTime")
+	fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\n", fh.ImmediateOriginField(), fh.ImmediateOriginNameField(), fh.ImmediateDestinationField(), fh.ImmediateDestinationNameField(), fh.FileCreationDateField(), fh.FileCreationTimeField())
+
+	if len(b.errors) > 0 {
+		ansiterm.Foreground(ansiterm.Red).Fprintf(w, "\n  %d errors found, type e to list them\n", len(b.errors))
+	}
+
+	title(w, "\nBatches")
+	fmt.Fprintln(w, "  #\tLine\tBatchNumber\tSECCode\tServiceClassCode\tCompanyName\tIdentification\tEffectiveEntryDate\tEntries")
+	start, end := b.pageRange(len(b.model.batches))
+	for i := start; i < end; i++ {
+		batch := b.model.batches[i]
+		fmt.Fprintf(w, "  %d\t%d\t%07d\t%s\t%d %s\t%s\t%s\t%s\t%d\n", i+1, batch.line, batch.batchNumber, batch.secCode,
+			batch.serviceClassCode, describe.ServiceClassCode(batch.serviceClassCode),
+			batch.companyName, batch.companyID, batch.effectiveDate, len(batch.entries))
+	}
+	if len(b.model.batches) == 0 {
+		fmt.Fprintln(w, "  (no batches)")
+	}
+}
+
+func (b *browser) renderBatch(w *ansiterm.TabWriter) {
+	batch := b.current.batch
+	title(w, "Batch %d of %d (line %d)", batch.index+1, len(b.model.batches), batch.line)
+	fmt.Fprintln(w, "  BatchNumber\tSECCode\tServiceClassCode\tCompanyName\tIdentification\tEntryDescription\tEffectiveEntryDate")
+	fmt.Fprintf(w, "  %07d\t%s\t%d %s\t%s\t%s\t%s\t%s\n", batch.batchNumber, batch.secCode,
+		batch.serviceClassCode, describe.ServiceClassCode(batch.serviceClassCode),
+		batch.companyName, batch.companyID, batch.entryDescription, batch.effectiveDate)
+
+	title(w, "\nEntries")
+	b.renderEntries(w, batch.entries)
+}
+
+func (b *browser) renderSearch(w *ansiterm.TabWriter) {
+	title(w, "Search results for %q (%d entries)", b.current.query, len(b.current.results))
+	b.renderEntries(w, b.current.results)
+}
+
+func (b *browser) renderEntries(w *ansiterm.TabWriter, entries []*entryNode) {
+	opts := b.maskOptions()
+
+	fmt.Fprintln(w, "  #\tLine\tBatchNumber\tTraceNumber\tTransactionCode\tRDFIIdentification\tAccountNumber\tAmount\tName\tAddenda")
+	start, end := b.pageRange(len(entries))
+	for i := start; i < end; i++ {
+		e := entries[i]
+		account, name := e.account, e.name
+		if opts.MaskAccountNumbers {
+			account = mask.Number(account)
+		}
+		if opts.MaskNames {
+			name = mask.Name(name)
+		}
+		fmt.Fprintf(w, "  %d\t%d\t%07d\t%s\t%d %s\t%s\t%s\t%s\t%s\t%d\n", i+1, e.line, e.batch.batchNumber, e.traceNumber,
+			e.transactionCode, describe.TransactionCode(e.transactionCode), e.rdfi, strings.TrimSpace(account),
+			describe.FormatAmount(b.opts.PrettyAmounts, e.amount), strings.TrimSpace(name), len(e.addenda))
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "  (no entries)")
+	}
+}
+
+func (b *browser) renderEntry(w *ansiterm.TabWriter) {
+	e := b.current.entry
+	opts := b.maskOptions()
+
+	account, name, identification := e.account, e.name, e.identification
+	if opts.MaskAccountNumbers {
+		account = mask.Number(account)
+	}
+	if opts.MaskNames {
+		name = mask.Name(name)
+	}
+	if opts.MaskIdentification {
+		identification = mask.Number(identification)
+	}
+
+	title(w, "Entry %d of %d in batch %07d (line %d)", e.index+1, len(e.batch.entries), e.batch.batchNumber, e.line)
+	fmt.Fprintln(w, "  TransactionCode\tRDFIIdentification\tAccountNumber\tAmount\tName\tIdentification\tTraceNumber")
+	fmt.Fprintf(w, "  %d %s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.transactionCode, describe.TransactionCode(e.transactionCode),
+		e.rdfi, strings.TrimSpace(account), describe.FormatAmount(b.opts.PrettyAmounts, e.amount),
+		strings.TrimSpace(name), strings.TrimSpace(identification), e.traceNumber)
+
+	if len(e.addenda) == 0 {
+		return
+	}
+	title(w, "\nAddenda")
+	fmt.Fprintln(w, "  Line\tType\tRecord")
+	for _, a := range e.addenda {
+		fmt.Fprintf(w, "  %d\t%s\t%s\n", a.line, a.typeCode, maskAddenda(a, opts))
+	}
+}
+
+// maskAddenda hides corrected data and the names and addresses held in IAT addenda
+func maskAddenda(a *addendaNode, opts mask.Options) string {
+	switch {
+	case a.typeCode == "98" && opts.MaskCorrectedData && a.correctedData != "":
+		return strings.Replace(a.raw, a.correctedData, mask.Number(a.correctedData), 1)
+	case opts.MaskNames && (a.typeCode == "10" || a.typeCode == "11" || a.typeCode == "15" || a.typeCode == "16"):
+		return a.raw[:3] + strings.Repeat("*", len(a.raw)-3)
+	}
+	return a.raw
+}
+
+func (b *browser) renderErrors(w *ansiterm.TabWriter) {
+	title(w, "Errors (%d)", len(b.errors))
+	if len(b.errors) == 0 {
+		fmt.Fprintln(w, "  (no errors)")
+		return
+	}
+	fmt.Fprintln(w, "  #\tLine\tError")
+	start, end := b.pageRange(len(b.errors))
+	for i := start; i < end; i++ {
+		line := "-"
+		if b.errors[i].line > 0 {
+			line = strconv.Itoa(b.errors[i].line)
+		}
+		ansiterm.Foreground(ansiterm.Red).Fprintf(w, "  %d\t%s\t%v\n", i+1, line, b.errors[i].err)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package browse
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/describe/mask"
+	"github.com/moov-io/base"
+
+	"github.com/stretchr/testify/require"
+)
+
+func browse(t *testing.T, file *ach.File, fileErr error, opts Options, commands ...string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	err := Run(strings.NewReader(strings.Join(commands, "\n")+"\n"), &buf, file, fileErr, opts)
+	require.NoError(t, err)
+
+	if testing.Verbose() {
+		os.Stdout.Write(buf.Bytes())
+	}
+	return buf.String()
+}
+
+func readFile(t *testing.T, name string) *ach.File {
+	t.Helper()
+
+	file, err := ach.ReadFile(filepath.Join("..", "..", "..", "test", "testdata", name))
+	require.NoError(t, err)
+	return file
+}
+
+func TestBrowse__navigate(t *testing.T) {
+	file := readFile(t, "ppd-debit.ach")
+
+	out := browse(t, file, nil, Options{}, "1", "1", "b", "b", "q")
+	require.Contains(t, out, "Batch 1 of 1 (line 2)")
+	require.Contains(t, out, "Entry 1 of 1 in batch 0000001 (line 3)")
+	require.Contains(t, out, "121042880000001")
+	require.Contains(t, out, "Receiver Account Name")
+	require.Contains(t, out, "(Checking Debit)")
+	require.Equal(t, 2, strings.Count(out, "File (line 1)"), "at the start and after going back twice")
+
+	out = browse(t, file, nil, Options{}, "9", "x", "b")
+	require.Contains(t, out, "no batch #9")
+	require.Contains(t, out, `unknown command "x"`)
+	require.Contains(t, out, "already at the top")
+}
+
+func TestBrowse__addenda(t *testing.T) {
+	file := readFile(t, "cor-example.ach")
+
+	out := browse(t, file, nil, Options{}, "1", "1")
+	require.Contains(t, out, "Addenda")
+	require.Contains(t, out, "98")
+
+	masked := browse(t, file, nil, Options{Options: mask.Options{MaskCorrectedData: true}}, "1", "1")
+	require.NotEqual(t, out, masked)
+}
+
+func TestBrowse__IAT(t *testing.T) {
+	file := readFile(t, "iat-debit.ach")
+
+	out := browse(t, file, nil, Options{}, "1", "1")
+	require.Contains(t, out, "IAT")
+	require.Contains(t, out, "Addenda")
+
+	masked := browse(t, file, nil, Options{}, "m", "1", "1")
+	require.Contains(t, masked, "masking enabled")
+	require.Contains(t, masked, "710***")
+}
+
+func TestBrowse__search(t *testing.T) {
+	file := readFile(t, "ppd-debit.ach")
+
+	for _, query := range []string{"/121042880000001", "/receiver", "/100000000", "/1000000.00"} {
+		out := browse(t, file, nil, Options{}, query, "1")
+		require.Contains(t, out, "(1 entries)", query)
+		require.Contains(t, out, "Entry 1 of 1", query)
+	}
+
+	out := browse(t, file, nil, Options{}, "/nobody")
+	require.Contains(t, out, "(0 entries)")
+	require.Contains(t, out, "(no entries)")
+}
+
+func TestBrowse__mask(t *testing.T) {
+	file := readFile(t, "ppd-debit.ach")
+
+	out := browse(t, file, nil, Options{}, "m", "1")
+	require.Contains(t, out, "Re****** Ac***** Na**")
+	require.Contains(t, out, "****5678")
+
+	out = browse(t, file, nil, Options{Options: mask.Options{MaskNames: true}}, "1", "m")
+	require.Contains(t, out, "Re****** Ac***** Na**")
+	require.Contains(t, out, "masking disabled")
+	require.Contains(t, out, "12345678")
+}
+
+func TestBrowse__errors(t *testing.T) {
+	file := readFile(t, "ppd-debit.ach")
+	fileErr := base.ErrorList{
+		base.ParseError{Line: 3, Record: "EntryDetail", Err: errors.New("bad entry")},
+		errors.New("other problem"),
+	}
+
+	out := browse(t, file, fileErr, Options{}, "e", "2", "1", ":2", ":99")
+	require.Contains(t, out, "2 errors found")
+	require.Contains(t, out, "bad entry")
+	require.Contains(t, out, "error has no line number")
+	require.Contains(t, out, "Entry 1 of 1 in batch 0000001 (line 3)")
+	require.Contains(t, out, "Batch 1 of 1 (line 2)")
+	require.Contains(t, out, "line 99 is not part of a batch")
+}
+
+func TestBrowse__paging(t *testing.T) {
+	file := readFile(t, "ppd-debit.ach")
+
+	out := browse(t, file, nil, Options{PageSize: 1, ClearScreen: true}, "n", "p", "p")
+	require.Contains(t, out, clearScreen)
+	require.Contains(t, out, "no more pages")
+	require.Contains(t, out, "already on the first page")
+}
+
+func TestParseAmount(t *testing.T) {
+	require.Equal(t, 1000, parseAmount("1000"))
+	require.Equal(t, 1234, parseAmount("12.34"))
+	require.Equal(t, -1, parseAmount("name"))
+}
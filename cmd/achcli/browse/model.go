@@ -0,0 +1,312 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package browse
+
+import (
+	"fmt"
+
+	"github.com/moov-io/ach"
+)
+
+// record is any line of a Nacha file
+type record interface {
+	String() string
+}
+
+// batchNode is a batch of the browsed file along with the line numbers of its records
+type batchNode struct {
+	index int
+	line  int
+
+	batchNumber      int
+	secCode          string
+	serviceClassCode int
+	companyName      string
+	companyID        string
+	entryDescription string
+	effectiveDate    string
+	iat              bool
+
+	header  string
+	control string
+
+	entries []*entryNode
+}
+
+// entryNode is an entry of the browsed file along with its addenda records
+type entryNode struct {
+	batch *batchNode
+	index int
+	line  int
+
+	transactionCode int
+	rdfi            string
+	account         string
+	amount          int
+	name            string
+	identification  string
+	traceNumber     string
+
+	addenda []*addendaNode
+}
+
+// addendaNode is an addenda record of an entry
+type addendaNode struct {
+	line     int
+	typeCode string
+	raw      string
+
+	// correctedData is masked on Addenda98 records
+	correctedData string
+}
+
+// location points to the record found at a line of the file
+type location struct {
+	batch   *batchNode
+	entry   *entryNode
+	addenda int
+}
+
+// model is the tree of batches, entries and addenda which are browsed
+type model struct {
+	file    *ach.File
+	batches []*batchNode
+
+	// lines maps each line number (starting at 1) to its record
+	lines map[int]location
+}
+
+// newModel computes the line numbers of every record in file as they would be written.
+func newModel(file *ach.File) *model {
+	m := &model{
+		file:  file,
+		lines: make(map[int]location),
+	}
+
+	line := 1 // FileHeader
+	for _, batch := range file.Batches {
+		bh := batch.GetHeader()
+		if bh == nil {
+			continue
+		}
+		line++
+		b := &batchNode{
+			index:            len(m.batches),
+			line:             line,
+			batchNumber:      bh.BatchNumber,
+			secCode:          bh.StandardEntryClassCode,
+			serviceClassCode: bh.ServiceClassCode,
+			companyName:      bh.CompanyName,
+			companyID:        bh.CompanyIdentification,
+			entryDescription: bh.CompanyEntryDescription,
+			effectiveDate:    bh.EffectiveEntryDate,
+			header:           bh.String(),
+		}
+		if bh.StandardEntryClassCode == ach.ADV {
+			if bc := batch.GetADVControl(); bc != nil {
+				b.control = bc.String()
+			}
+		} else if bc := batch.GetControl(); bc != nil {
+			b.control = bc.String()
+		}
+		m.lines[line] = location{batch: b}
+
+		for j, e := range batch.GetEntries() {
+			line++
+			entry := &entryNode{
+				batch:           b,
+				index:           j,
+				line:            line,
+				transactionCode: e.TransactionCode,
+				rdfi:            e.RDFIIdentificationField() + e.CheckDigit,
+				account:         e.DFIAccountNumber,
+				amount:          e.Amount,
+				name:            e.IndividualName,
+				identification:  e.IdentificationNumber,
+				traceNumber:     e.TraceNumber,
+			}
+			m.lines[line] = location{batch: b, entry: entry}
+
+			var addenda []record
+			if e.Addenda02 != nil {
+				addenda = append(addenda, e.Addenda02)
+			}
+			for _, a := range e.Addenda05 {
+				if a != nil {
+					addenda = append(addenda, a)
+				}
+			}
+			if e.Addenda98 != nil {
+				addenda = append(addenda, e.Addenda98)
+			}
+			if e.Addenda98Refused != nil {
+				addenda = append(addenda, e.Addenda98Refused)
+			}
+			if e.Addenda99 != nil {
+				addenda = append(addenda, e.Addenda99)
+			}
+			if e.Addenda99Dishonored != nil {
+				addenda = append(addenda, e.Addenda99Dishonored)
+			}
+			if e.Addenda99Contested != nil {
+				addenda = append(addenda, e.Addenda99Contested)
+			}
+			for k := range addenda {
+				line++
+				node := &addendaNode{
+					line:     line,
+					typeCode: addendaTypeCode(addenda[k]),
+					raw:      addenda[k].String(),
+				}
+				if a98, ok := addenda[k].(*ach.Addenda98); ok {
+					node.correctedData = a98.CorrectedData
+				}
+				entry.addenda = append(entry.addenda, node)
+				m.lines[line] = location{batch: b, entry: entry, addenda: k + 1}
+			}
+			b.entries = append(b.entries, entry)
+		}
+
+		for _, e := range batch.GetADVEntries() {
+			line++
+			entry := &entryNode{
+				batch:           b,
+				index:           len(b.entries),
+				line:            line,
+				transactionCode: e.TransactionCode,
+				rdfi:            e.RDFIIdentification + e.CheckDigit,
+				account:         e.DFIAccountNumber,
+				amount:          e.Amount,
+				name:            e.IndividualName,
+				traceNumber:     fmt.Sprintf("%s%04d", e.ACHOperatorRoutingNumber, e.SequenceNumber),
+			}
+			m.lines[line] = location{batch: b, entry: entry}
+			if e.Addenda99 != nil {
+				line++
+				entry.addenda = append(entry.addenda, &addendaNode{
+					line:     line,
+					typeCode: "99",
+					raw:      e.Addenda99.String(),
+				})
+				m.lines[line] = location{batch: b, entry: entry, addenda: 1}
+			}
+			b.entries = append(b.entries, entry)
+		}
+
+		line++ // BatchControl
+		m.lines[line] = location{batch: b}
+		m.batches = append(m.batches, b)
+	}
+
+	for _, batch := range file.IATBatches {
+		bh := batch.GetHeader()
+		if bh == nil {
+			continue
+		}
+		line++
+		b := &batchNode{
+			index:            len(m.batches),
+			line:             line,
+			batchNumber:      bh.BatchNumber,
+			secCode:          bh.StandardEntryClassCode,
+			serviceClassCode: bh.ServiceClassCode,
+			companyID:        bh.OriginatorIdentification,
+			entryDescription: bh.CompanyEntryDescription,
+			effectiveDate:    bh.EffectiveEntryDate,
+			iat:              true,
+			header:           bh.String(),
+		}
+		if bc := batch.GetControl(); bc != nil {
+			b.control = bc.String()
+		}
+		m.lines[line] = location{batch: b}
+
+		for j, e := range batch.GetEntries() {
+			line++
+			entry := &entryNode{
+				batch:           b,
+				index:           j,
+				line:            line,
+				transactionCode: e.TransactionCode,
+				rdfi:            e.RDFIIdentificationField() + e.CheckDigit,
+				account:         e.DFIAccountNumber,
+				amount:          e.Amount,
+				traceNumber:     e.TraceNumber,
+			}
+			if e.Addenda10 != nil {
+				entry.name = e.Addenda10.Name
+			}
+			m.lines[line] = location{batch: b, entry: entry}
+
+			var addenda []record
+			for _, a := range []record{e.Addenda10, e.Addenda11, e.Addenda12, e.Addenda13, e.Addenda14, e.Addenda15, e.Addenda16} {
+				if !isNilRecord(a) {
+					addenda = append(addenda, a)
+				}
+			}
+			for _, a := range e.Addenda17 {
+				if a != nil {
+					addenda = append(addenda, a)
+				}
+			}
+			for _, a := range e.Addenda18 {
+				if a != nil {
+					addenda = append(addenda, a)
+				}
+			}
+			if e.Addenda98 != nil {
+				addenda = append(addenda, e.Addenda98)
+			}
+			if e.Addenda99 != nil {
+				addenda = append(addenda, e.Addenda99)
+			}
+			for k := range addenda {
+				line++
+				entry.addenda = append(entry.addenda, &addendaNode{
+					line:     line,
+					typeCode: addendaTypeCode(addenda[k]),
+					raw:      addenda[k].String(),
+				})
+				m.lines[line] = location{batch: b, entry: entry, addenda: k + 1}
+			}
+			b.entries = append(b.entries, entry)
+		}
+
+		line++ // BatchControl
+		m.lines[line] = location{batch: b}
+		m.batches = append(m.batches, b)
+	}
+
+	return m
+}
+
+// addendaTypeCode returns the two digit type code of an addenda record
+func addendaTypeCode(r record) string {
+	raw := r.String()
+	if len(raw) >= 3 {
+		return raw[1:3]
+	}
+	return ""
+}
+
+func isNilRecord(r record) bool {
+	switch a := r.(type) {
+	case *ach.Addenda10:
+		return a == nil
+	case *ach.Addenda11:
+		return a == nil
+	case *ach.Addenda12:
+		return a == nil
+	case *ach.Addenda13:
+		return a == nil
+	case *ach.Addenda14:
+		return a == nil
+	case *ach.Addenda15:
+		return a == nil
+	case *ach.Addenda16:
+		return a == nil
+	}
+	return r == nil
+}
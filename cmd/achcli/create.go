@@ -0,0 +1,34 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/moov-io/ach/cmd/achcli/create"
+	"github.com/moov-io/ach/cmd/achcli/internal/read"
+	"github.com/moov-io/ach/cmd/achcli/internal/write"
+)
+
+func createFile(path, format string) error {
+	spec, err := create.ReadSpec(path)
+	if err != nil {
+		return err
+	}
+	file, err := create.Build(spec, time.Now())
+	if err != nil {
+		return fmt.Errorf("creating file from %s: %w", path, err)
+	}
+
+	switch format {
+	case "", "ach":
+		return write.File(os.Stdout, file, read.FormatNacha)
+	case "json":
+		return write.File(os.Stdout, file, read.FormatJSON)
+	}
+	return fmt.Errorf("unknown format %s", format)
+}
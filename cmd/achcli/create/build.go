@@ -0,0 +1,285 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package create
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/base"
+)
+
+// Build creates a valid file from spec. Batch numbers, trace numbers, check digits and
+// control records are computed. now is used for the file creation date and default
+// effective entry dates.
+func Build(spec *Spec, now time.Time) (*ach.File, error) {
+	if spec == nil {
+		return nil, errors.New("nil spec")
+	}
+
+	odfi, err := routingNumber(spec.Originator.RoutingNumber)
+	if err != nil {
+		return nil, fmt.Errorf("originator: %w", err)
+	}
+	destination, err := routingNumber(spec.Destination.RoutingNumber)
+	if err != nil {
+		return nil, fmt.Errorf("destination: %w", err)
+	}
+
+	fh := ach.NewFileHeader()
+	fh.ImmediateOrigin = odfi
+	if spec.Originator.ImmediateOrigin != "" {
+		fh.ImmediateOrigin = spec.Originator.ImmediateOrigin
+	}
+	fh.ImmediateOriginName = spec.Originator.Name
+	fh.ImmediateDestination = destination
+	fh.ImmediateDestinationName = spec.Destination.Name
+	fh.FileCreationDate = now.Format("060102")
+	fh.FileCreationTime = now.Format("1504")
+	if spec.FileIDModifier != "" {
+		fh.FileIDModifier = spec.FileIDModifier
+	}
+
+	file := ach.NewFile()
+	file.SetHeader(fh)
+
+	batches := spec.Batches
+	if len(spec.Payees) > 0 {
+		batches = append([]Batch{spec.Batch}, batches...)
+	}
+	if len(batches) == 0 {
+		return nil, errors.New("spec has no payees")
+	}
+
+	// Trace numbers are unique across the file
+	var sequence int
+	for i := range batches {
+		b := withDefaults(batches[i], spec.Batch)
+
+		batch, err := buildBatch(spec.Originator, odfi, b, i+1, &sequence, now)
+		if err != nil {
+			return nil, fmt.Errorf("batch[%d]: %w", i, err)
+		}
+		file.AddBatch(batch)
+	}
+
+	if err := file.Create(); err != nil {
+		return nil, err
+	}
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// withDefaults fills in the empty fields of b from the top level of a spec
+func withDefaults(b, defaults Batch) Batch {
+	if b.SECCode == "" {
+		b.SECCode = defaults.SECCode
+	}
+	if b.Description == "" {
+		b.Description = defaults.Description
+	}
+	if b.CompanyDiscretionaryData == "" {
+		b.CompanyDiscretionaryData = defaults.CompanyDiscretionaryData
+	}
+	if b.EffectiveDate == "" {
+		b.EffectiveDate = defaults.EffectiveDate
+	}
+	if b.Offset == nil {
+		b.Offset = defaults.Offset
+	}
+	return b
+}
+
+func buildBatch(originator Originator, odfi string, b Batch, batchNumber int, sequence *int, now time.Time) (ach.Batcher, error) {
+	if len(b.Payees) == 0 {
+		return nil, errors.New("no payees")
+	}
+	effectiveDate, err := effectiveEntryDate(b.EffectiveDate, now)
+	if err != nil {
+		return nil, err
+	}
+
+	bh := ach.NewBatchHeader()
+	bh.ServiceClassCode = ach.MixedDebitsAndCredits
+	bh.CompanyName = originator.CompanyName
+	if bh.CompanyName == "" {
+		bh.CompanyName = originator.Name
+	}
+	bh.CompanyIdentification = originator.CompanyIdentification
+	bh.CompanyDiscretionaryData = b.CompanyDiscretionaryData
+	bh.StandardEntryClassCode = strings.ToUpper(b.SECCode)
+	bh.CompanyEntryDescription = b.Description
+	bh.EffectiveEntryDate = effectiveDate
+	bh.ODFIIdentification = odfi[:8]
+	bh.BatchNumber = batchNumber
+
+	var credits, debits bool
+	entries := make([]*ach.EntryDetail, len(b.Payees))
+	for i := range b.Payees {
+		*sequence++
+
+		ed, err := buildEntry(b.Payees[i], odfi[:8], *sequence)
+		if err != nil {
+			return nil, fmt.Errorf("payee[%d] %s: %w", i, b.Payees[i].Name, err)
+		}
+		switch ed.CreditOrDebit() {
+		case "C":
+			credits = true
+		case "D":
+			debits = true
+		}
+		entries[i] = ed
+	}
+	switch {
+	case b.Offset != nil:
+		// Offsets add entries of the opposite type
+	case credits && !debits:
+		bh.ServiceClassCode = ach.CreditsOnly
+	case debits && !credits:
+		bh.ServiceClassCode = ach.DebitsOnly
+	}
+
+	batch, err := ach.NewBatch(bh)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		batch.AddEntry(entries[i])
+	}
+
+	if b.Offset != nil {
+		off, err := offset(b.Offset)
+		if err != nil {
+			return nil, fmt.Errorf("offset: %w", err)
+		}
+		batch.WithOffset(off)
+		*sequence += 2 // room for the debit and credit offset entries
+	}
+
+	if err := batch.Create(); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+func buildEntry(p Payee, odfi string, sequence int) (*ach.EntryDetail, error) {
+	rdfi, err := routingNumber(p.RoutingNumber)
+	if err != nil {
+		return nil, err
+	}
+	code, err := transactionCode(p)
+	if err != nil {
+		return nil, err
+	}
+
+	ed := ach.NewEntryDetail()
+	ed.TransactionCode = code
+	ed.SetRDFI(rdfi)
+	ed.DFIAccountNumber = p.AccountNumber
+	ed.Amount = p.Amount
+	ed.IdentificationNumber = p.IdentificationNumber
+	ed.IndividualName = p.Name
+	ed.DiscretionaryData = p.DiscretionaryData
+	ed.SetTraceNumber(odfi, sequence)
+
+	for i := range p.Addenda {
+		addenda := ach.NewAddenda05()
+		addenda.PaymentRelatedInformation = p.Addenda[i]
+		ed.AddAddenda05(addenda)
+	}
+	if len(ed.Addenda05) > 0 {
+		ed.AddendaRecordIndicator = 1
+	}
+	return ed, nil
+}
+
+// transactionCodes are indexed by account type then credit, debit, prenote credit and prenote debit
+var transactionCodes = map[string][4]int{
+	"checking": {ach.CheckingCredit, ach.CheckingDebit, ach.CheckingPrenoteCredit, ach.CheckingPrenoteDebit},
+	"savings":  {ach.SavingsCredit, ach.SavingsDebit, ach.SavingsPrenoteCredit, ach.SavingsPrenoteDebit},
+	"gl":       {ach.GLCredit, ach.GLDebit, ach.GLPrenoteCredit, ach.GLPrenoteDebit},
+	"loan":     {ach.LoanCredit, ach.LoanDebit, ach.LoanPrenoteCredit, 0},
+}
+
+func transactionCode(p Payee) (int, error) {
+	accountType := strings.ToLower(p.AccountType)
+	if accountType == "" {
+		accountType = "checking"
+	}
+	codes, exists := transactionCodes[accountType]
+	if !exists {
+		return 0, fmt.Errorf("unknown account type %q", p.AccountType)
+	}
+
+	var idx int
+	switch strings.ToLower(p.Type) {
+	case "", "credit":
+	case "debit":
+		idx = 1
+	default:
+		return 0, fmt.Errorf("unknown type %q", p.Type)
+	}
+	if p.Prenote {
+		idx += 2
+	}
+	if codes[idx] == 0 {
+		return 0, fmt.Errorf("%s prenotes can not be debits", accountType)
+	}
+	return codes[idx], nil
+}
+
+// routingNumber returns a nine digit routing number, calculating the check digit of eight digit numbers
+func routingNumber(rn string) (string, error) {
+	rn = strings.TrimSpace(rn)
+	switch len(rn) {
+	case 8:
+		digit := ach.CalculateCheckDigit(rn)
+		if digit < 0 {
+			return "", fmt.Errorf("invalid routing number %q", rn)
+		}
+		return rn + strconv.Itoa(digit), nil
+	case 9:
+		if err := ach.CheckRoutingNumber(rn); err != nil {
+			return "", err
+		}
+		return rn, nil
+	}
+	return "", fmt.Errorf("routing number %q must be eight or nine digits", rn)
+}
+
+func effectiveEntryDate(value string, now time.Time) (string, error) {
+	if value == "" {
+		return base.NewTime(now).AddBankingDay(1).Format("060102"), nil
+	}
+	for _, layout := range []string{"2006-01-02", "060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("060102"), nil
+		}
+	}
+	return "", fmt.Errorf("effective date %q must be formatted as YYYY-MM-DD or YYMMDD", value)
+}
+
+func offset(o *Offset) (*ach.Offset, error) {
+	rn, err := routingNumber(o.RoutingNumber)
+	if err != nil {
+		return nil, err
+	}
+	out := &ach.Offset{
+		RoutingNumber: rn,
+		AccountNumber: o.AccountNumber,
+		AccountType:   ach.OffsetChecking,
+		Description:   o.Description,
+	}
+	if o.AccountType != "" {
+		out.AccountType = ach.OffsetAccountType(strings.ToLower(o.AccountType))
+	}
+	return out, nil
+}
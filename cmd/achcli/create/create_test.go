@@ -0,0 +1,134 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package create
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach"
+
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, time.October, 16, 10, 30, 0, 0, time.UTC) // Friday
+
+func TestBuild(t *testing.T) {
+	spec, err := ReadSpec(filepath.Join("..", "..", "..", "test", "testdata", "create-spec.yaml"))
+	require.NoError(t, err)
+
+	file, err := Build(spec, now)
+	require.NoError(t, err)
+
+	require.Equal(t, "121042882", file.Header.ImmediateOrigin)
+	require.Equal(t, "231380104", file.Header.ImmediateDestination)
+	require.Equal(t, "261016", file.Header.FileCreationDate)
+	require.Equal(t, "1030", file.Header.FileCreationTime)
+	require.Len(t, file.Batches, 2)
+
+	// Top level payees are the first batch
+	payroll := file.Batches[0]
+	require.Equal(t, 1, payroll.GetHeader().BatchNumber)
+	require.Equal(t, ach.PPD, payroll.GetHeader().StandardEntryClassCode)
+	require.Equal(t, ach.CreditsOnly, payroll.GetHeader().ServiceClassCode)
+	require.Equal(t, "261020", payroll.GetHeader().EffectiveEntryDate)
+
+	entries := payroll.GetEntries()
+	require.Len(t, entries, 2)
+	require.Equal(t, ach.CheckingCredit, entries[0].TransactionCode)
+	require.Equal(t, "4", entries[0].CheckDigit)
+	require.Equal(t, "121042880000001", entries[0].TraceNumber)
+	require.Equal(t, 1, entries[0].AddendaRecordIndicator)
+	require.Equal(t, "October salary", entries[0].Addenda05[0].PaymentRelatedInformation)
+	require.Equal(t, ach.SavingsCredit, entries[1].TransactionCode)
+	require.Equal(t, "121042880000002", entries[1].TraceNumber)
+
+	// Batches inherit the top level defaults and add offsets
+	vendors := file.Batches[1]
+	require.Equal(t, 2, vendors.GetHeader().BatchNumber)
+	require.Equal(t, ach.CCD, vendors.GetHeader().StandardEntryClassCode)
+	require.Equal(t, ach.MixedDebitsAndCredits, vendors.GetHeader().ServiceClassCode)
+	require.Equal(t, "261020", vendors.GetHeader().EffectiveEntryDate)
+	require.Len(t, vendors.GetEntries(), 4)
+	require.Equal(t, vendors.GetControl().TotalCreditEntryDollarAmount, vendors.GetControl().TotalDebitEntryDollarAmount)
+
+	// The file can be written and read back
+	var buf bytes.Buffer
+	require.NoError(t, ach.NewWriter(&buf).Write(file))
+	_, err = ach.NewReader(&buf).Read()
+	require.NoError(t, err)
+}
+
+func TestBuild__JSON(t *testing.T) {
+	spec, err := ParseSpec([]byte(`{
+  "originator": {"name": "My Company", "routingNumber": "12104288", "companyIdentification": "1234567890"},
+  "destination": {"name": "Federal Reserve Bank", "routingNumber": "231380104"},
+  "secCode": "web",
+  "description": "ONLINE",
+  "payees": [
+    {"name": "Jane Doe", "routingNumber": "231380104", "accountNumber": "12345678", "type": "debit", "amount": 1000, "discretionaryData": "S"},
+    {"name": "Jane Doe", "routingNumber": "231380104", "accountNumber": "12345678", "type": "debit", "prenote": true, "discretionaryData": "S"}
+  ]
+}`))
+	require.NoError(t, err)
+
+	file, err := Build(spec, now)
+	require.NoError(t, err)
+
+	bh := file.Batches[0].GetHeader()
+	require.Equal(t, ach.WEB, bh.StandardEntryClassCode)
+	require.Equal(t, ach.DebitsOnly, bh.ServiceClassCode)
+	require.Equal(t, "261019", bh.EffectiveEntryDate, "next banking day")
+
+	entries := file.Batches[0].GetEntries()
+	require.Equal(t, ach.CheckingDebit, entries[0].TransactionCode)
+	require.Equal(t, ach.CheckingPrenoteDebit, entries[1].TransactionCode)
+}
+
+func TestBuild__Errors(t *testing.T) {
+	valid := func() *Spec {
+		return &Spec{
+			Originator:  Originator{Name: "My Company", RoutingNumber: "121042882", CompanyIdentification: "1234567890"},
+			Destination: Destination{Name: "Federal Reserve Bank", RoutingNumber: "231380104"},
+			Batch: Batch{
+				SECCode:     ach.PPD,
+				Description: "PAYROLL",
+				Payees: []Payee{
+					{Name: "Jane Doe", RoutingNumber: "23138010", AccountNumber: "12345678", Amount: 100},
+				},
+			},
+		}
+	}
+
+	_, err := Build(valid(), now)
+	require.NoError(t, err)
+
+	cases := map[string]func(s *Spec){
+		"originator: routing number":                   func(s *Spec) { s.Originator.RoutingNumber = "1234" },
+		"destination: routing number":                  func(s *Spec) { s.Destination.RoutingNumber = "231380105" },
+		"spec has no payees":                           func(s *Spec) { s.Payees = nil },
+		"batch[1]: no payees":                          func(s *Spec) { s.Batches = []Batch{{}} },
+		`batch[0]: effective date "tomorrow"`:          func(s *Spec) { s.EffectiveDate = "tomorrow" },
+		`batch[0]: payee[0] Jane Doe: unknown account`: func(s *Spec) { s.Payees[0].AccountType = "brokerage" },
+		`batch[0]: payee[0] Jane Doe: unknown type`:    func(s *Spec) { s.Payees[0].Type = "refund" },
+		"batch[0]: payee[0] Jane Doe: loan prenotes": func(s *Spec) {
+			s.Payees[0].AccountType, s.Payees[0].Type, s.Payees[0].Prenote = "loan", "debit", true
+		},
+		"batch[0]: offset: routing number": func(s *Spec) { s.Offset = &Offset{RoutingNumber: "123"} },
+		"unknown offset account type":      func(s *Spec) { s.Offset = &Offset{RoutingNumber: "121042882", AccountType: "gl"} },
+	}
+	for msg, modify := range cases {
+		spec := valid()
+		modify(spec)
+
+		_, err := Build(spec, now)
+		require.ErrorContains(t, err, msg)
+	}
+
+	_, err = ParseSpec([]byte("originator:\n  nme: typo\n"))
+	require.ErrorContains(t, err, "field nme not found")
+}
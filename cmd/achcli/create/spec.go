@@ -0,0 +1,139 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package create builds Nacha files from a compact YAML or JSON payments spec.
+package create
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec describes the payments of a file. Batch fields set at the top level are defaults for
+// each of Batches, and top level Payees are placed in their own (first) batch.
+//
+//	originator:
+//	  name: My Company
+//	  routingNumber: "121042882"
+//	  companyIdentification: "1234567890"
+//	destination:
+//	  name: Federal Reserve Bank
+//	  routingNumber: "231380104"
+//	secCode: PPD
+//	description: PAYROLL
+//	effectiveDate: "2026-10-20"
+//	payees:
+//	  - name: Jane Doe
+//	    routingNumber: "23138010"
+//	    accountNumber: "12345678"
+//	    amount: 125000
+//	    addenda: ["October salary"]
+type Spec struct {
+	Originator  Originator  `yaml:"originator"`
+	Destination Destination `yaml:"destination"`
+
+	// FileIDModifier defaults to "A"
+	FileIDModifier string `yaml:"fileIDModifier"`
+
+	Batch `yaml:",inline"`
+
+	Batches []Batch `yaml:"batches"`
+}
+
+// Originator is the company and ODFI sending the payments
+type Originator struct {
+	Name string `yaml:"name"`
+
+	// RoutingNumber of the ODFI, trace numbers start with its first eight digits
+	RoutingNumber string `yaml:"routingNumber"`
+
+	// CompanyName defaults to Name
+	CompanyName           string `yaml:"companyName"`
+	CompanyIdentification string `yaml:"companyIdentification"`
+
+	// ImmediateOrigin defaults to RoutingNumber
+	ImmediateOrigin string `yaml:"immediateOrigin"`
+}
+
+// Destination is the receiving point of the file, often the Federal Reserve or another ACH operator
+type Destination struct {
+	Name          string `yaml:"name"`
+	RoutingNumber string `yaml:"routingNumber"`
+}
+
+// Batch groups payees under a single batch header
+type Batch struct {
+	SECCode string `yaml:"secCode"`
+
+	// Description is the CompanyEntryDescription (e.g. PAYROLL)
+	Description              string `yaml:"description"`
+	CompanyDiscretionaryData string `yaml:"companyDiscretionaryData"`
+
+	// EffectiveDate is formatted as YYYY-MM-DD or YYMMDD and defaults to the next banking day
+	EffectiveDate string `yaml:"effectiveDate"`
+
+	// Offset adds balancing entries to the batch
+	Offset *Offset `yaml:"offset"`
+
+	Payees []Payee `yaml:"payees"`
+}
+
+// Offset is the account which balances each batch
+type Offset struct {
+	RoutingNumber string `yaml:"routingNumber"`
+	AccountNumber string `yaml:"accountNumber"`
+
+	// AccountType is checking (default) or savings
+	AccountType string `yaml:"accountType"`
+	Description string `yaml:"description"`
+}
+
+// Payee is the receiver of a single entry
+type Payee struct {
+	Name string `yaml:"name"`
+
+	// RoutingNumber of the RDFI, its check digit is calculated when eight digits are given
+	RoutingNumber string `yaml:"routingNumber"`
+	AccountNumber string `yaml:"accountNumber"`
+
+	// AccountType is checking (default), savings, gl or loan
+	AccountType string `yaml:"accountType"`
+
+	// Type is credit (default) or debit
+	Type string `yaml:"type"`
+
+	// Amount is in cents
+	Amount  int  `yaml:"amount"`
+	Prenote bool `yaml:"prenote"`
+
+	IdentificationNumber string `yaml:"identificationNumber"`
+	DiscretionaryData    string `yaml:"discretionaryData"`
+
+	// Addenda are written as Addenda05 payment related information
+	Addenda []string `yaml:"addenda"`
+}
+
+// ReadSpec parses a YAML or JSON spec, rejecting fields which are unknown.
+func ReadSpec(path string) (*Spec, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSpec(bs)
+}
+
+// ParseSpec parses a YAML or JSON spec, rejecting fields which are unknown.
+func ParseSpec(data []byte) (*Spec, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var spec Spec
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("reading spec: %w", err)
+	}
+	return &spec, nil
+}
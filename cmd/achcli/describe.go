@@ -10,10 +10,14 @@ import (
 	"os"
 
 	"github.com/moov-io/ach"
-	"github.com/moov-io/ach/cmd/achcli/describe"
-	"github.com/moov-io/ach/cmd/achcli/describe/mask"
+	"github.com/moov-io/ach/describe"
+	"github.com/moov-io/ach/describe/mask"
+	"github.com/moov-io/ach/fedach"
 )
 
+// fedachDirectory is read from -fedach to show the bank name of each RDFI
+var fedachDirectory *fedach.Directory
+
 func dumpFiles(paths []string, validateOpts *ach.ValidateOpts) error {
 	files := make([]*ach.File, len(paths))
 	for i := range paths {
@@ -55,14 +59,7 @@ func dumpFiles(paths []string, validateOpts *ach.ValidateOpts) error {
 			fmt.Printf("Describing ACH file '%s'\n\n", paths[i])
 		}
 		if files[i] != nil {
-			describe.File(os.Stdout, files[i], &describe.Opts{
-				Options: mask.Options{
-					MaskAccountNumbers: *flagMask || *flagMaskAccounts,
-					MaskCorrectedData:  *flagMask || *flagMaskCorrectedData,
-					MaskNames:          *flagMask || *flagMaskNames,
-				},
-				PrettyAmounts: *flagPretty || *flagPrettyAmounts,
-			})
+			describe.File(os.Stdout, files[i], describeOpts())
 		} else {
 			fmt.Printf("nil ACH file in position %d\n", i)
 		}
@@ -71,6 +68,19 @@ func dumpFiles(paths []string, validateOpts *ach.ValidateOpts) error {
 	return nil
 }
 
+func describeOpts() *describe.Opts {
+	return &describe.Opts{
+		Options: mask.Options{
+			MaskAccountNumbers: *flagMask || *flagMaskAccounts,
+			MaskCorrectedData:  *flagMask || *flagMaskCorrectedData,
+			MaskNames:          *flagMask || *flagMaskNames,
+		},
+		PrettyAmounts: *flagPretty || *flagPrettyAmounts,
+		Enrich:        *flagEnrich,
+		Directory:     fedachDirectory,
+	}
+}
+
 func readACHFile(input []byte, validateOpts *ach.ValidateOpts) (*ach.File, error) {
 	r := ach.NewReader(bytes.NewReader(input))
 	r.SetValidation(validateOpts)
@@ -0,0 +1,25 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package describe has moved to github.com/moov-io/ach/describe so the HTTP server can render
+// file reports without importing a command package. It forwards to the new package so existing
+// imports keep compiling.
+//
+// Deprecated: Use github.com/moov-io/ach/describe instead.
+package describe
+
+import (
+	"io"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/describe"
+)
+
+// Opts controls how files are described.
+type Opts = describe.Opts
+
+// File writes a human readable description of file to ww.
+func File(ww io.Writer, file *ach.File, opts *Opts) {
+	describe.File(ww, file, opts)
+}
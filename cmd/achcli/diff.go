@@ -5,16 +5,14 @@
 package main
 
 import (
-	"bytes"
 	"fmt"
 	"os"
 
 	"github.com/moov-io/ach"
-
-	"github.com/juju/ansiterm"
+	"github.com/moov-io/ach/cmd/achcli/diff"
 )
 
-func diffFiles(paths []string, validateOpts *ach.ValidateOpts) error {
+func diffFiles(paths []string, format string, validateOpts *ach.ValidateOpts) error {
 	if len(paths) != 2 {
 		return fmt.Errorf("expected 2 files, but got %d", len(paths))
 	}
@@ -22,23 +20,7 @@ func diffFiles(paths []string, validateOpts *ach.ValidateOpts) error {
 	if err != nil {
 		return err
 	}
-
-	w := ansiterm.NewTabWriter(os.Stdout, 0, 0, 1, ' ', 0)
-	w = w.Init(os.Stdout, 0, 0, 1, ' ', 0)
-	defer w.Flush()
-
-	switch {
-	case f1.Header.ImmediateOrigin != f2.Header.ImmediateOrigin:
-		printDiffedFileHeader(w, f1, f2)
-	case f1.Header.ImmediateOriginName != f2.Header.ImmediateOriginName:
-		printDiffedFileHeader(w, f1, f2)
-	case f1.Header.ImmediateDestination != f2.Header.ImmediateDestination:
-		printDiffedFileHeader(w, f1, f2)
-	case f1.Header.ImmediateDestinationName != f2.Header.ImmediateDestinationName:
-		printDiffedFileHeader(w, f1, f2)
-	}
-
-	return nil
+	return diff.Write(os.Stdout, diff.Files(f1, f2), format)
 }
 
 func readTwoFiles(paths []string, validateOpts *ach.ValidateOpts) (*ach.File, *ach.File, error) {
@@ -52,45 +34,3 @@ func readTwoFiles(paths []string, validateOpts *ach.ValidateOpts) (*ach.File, *a
 	}
 	return f1, f2, nil
 }
-
-// TODO(adam): we should lookup batches which are in f1 against those in f2 and if a similar
-// batch is found (TODO: what, if any fields can we do exact matches on?) print that in the
-// order of f1. Otherwise show missing batches (from f1's view) and additional batches (from
-// f2's view) at the end.
-
-func printDiffedFileHeader(w *ansiterm.TabWriter, f1, f2 *ach.File) {
-	fmt.Fprintln(w, "  Origin\tOriginName\tDestination\tDestinationName")
-
-	var minusBuf bytes.Buffer
-	var plusBuf bytes.Buffer
-
-	minusBuf.WriteString("- ")
-	plusBuf.WriteString("+ ")
-
-	printColumn(&minusBuf, &plusBuf, f1.Header.ImmediateOrigin, f2.Header.ImmediateOrigin)
-	printColumn(&minusBuf, &plusBuf, f1.Header.ImmediateOriginName, f2.Header.ImmediateOriginName)
-	printColumn(&minusBuf, &plusBuf, f1.Header.ImmediateDestination, f2.Header.ImmediateDestination)
-	printColumn(&minusBuf, &plusBuf, f1.Header.ImmediateDestinationName, f2.Header.ImmediateDestinationName)
-
-	fmt.Fprintln(w, minusBuf.String())
-	fmt.Fprintln(w, plusBuf.String())
-}
-
-func printColumn(minusBuf, plusBuf *bytes.Buffer, v1, v2 string) {
-	if v1 != v2 {
-		w := ansiterm.NewWriter(minusBuf)
-		ctx := ansiterm.Foreground(ansiterm.Green)
-		ctx.Fprint(w, v2)
-		ctx.SetForeground(ansiterm.Default)
-		ctx.Fprint(w, "\t")
-
-		w = ansiterm.NewWriter(plusBuf)
-		ctx = ansiterm.Foreground(ansiterm.Red)
-		ctx.Fprint(w, v1)
-		ctx.SetForeground(ansiterm.Default)
-		ctx.Fprint(w, "\t")
-	} else {
-		minusBuf.WriteString(v2 + "\t")
-		plusBuf.WriteString(v1 + "\t")
-	}
-}
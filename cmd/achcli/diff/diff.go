@@ -0,0 +1,383 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package diff compares two ACH files by their contents rather than their lines.
+//
+// Batches are matched by their header signature (service class, SEC code, company, entry
+// description, effective date and ODFI) and entries within matched batches are matched by
+// trace number, falling back to the RDFI, account number and amount.
+package diff
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/ach"
+)
+
+type Status string
+
+const (
+	Added    Status = "added"
+	Removed  Status = "removed"
+	Modified Status = "modified"
+)
+
+// Entry matching strategies
+const (
+	MatchedByTraceNumber   = "traceNumber"
+	MatchedByAccountAmount = "rdfiAccountAmount"
+)
+
+// Result is every difference found between an old and new file
+type Result struct {
+	Equal bool `json:"equal"`
+
+	Header  []Change `json:"header,omitempty"`
+	Control []Change `json:"control,omitempty"`
+
+	Batches []Batch `json:"batches,omitempty"`
+	Summary Summary `json:"summary"`
+}
+
+// Change is a field whose value differs between the old and new record
+type Change struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Batch is a batch which was added, removed or modified
+type Batch struct {
+	Status Status `json:"status"`
+
+	// Old and New are the batch headers found in each file
+	Old *ach.BatchHeader `json:"old,omitempty"`
+	New *ach.BatchHeader `json:"new,omitempty"`
+
+	Header  []Change `json:"header,omitempty"`
+	Control []Change `json:"control,omitempty"`
+	Entries []Entry  `json:"entries,omitempty"`
+}
+
+// Entry is an entry which was added, removed or modified
+type Entry struct {
+	Status    Status `json:"status"`
+	MatchedBy string `json:"matchedBy,omitempty"`
+
+	Old *ach.EntryDetail `json:"old,omitempty"`
+	New *ach.EntryDetail `json:"new,omitempty"`
+
+	Changes []Change `json:"changes,omitempty"`
+}
+
+type Summary struct {
+	BatchesAdded    int `json:"batchesAdded"`
+	BatchesRemoved  int `json:"batchesRemoved"`
+	BatchesModified int `json:"batchesModified"`
+
+	EntriesAdded    int `json:"entriesAdded"`
+	EntriesRemoved  int `json:"entriesRemoved"`
+	EntriesModified int `json:"entriesModified"`
+}
+
+// Files compares old against new. IAT batches are not compared.
+func Files(old, new *ach.File) *Result {
+	out := &Result{
+		Header:  compare(fileHeaderFields, &old.Header, &new.Header),
+		Control: compare(fileControlFields, &old.Control, &new.Control),
+	}
+
+	for _, pair := range matchBatches(old.Batches, new.Batches) {
+		b := diffBatch(pair[0], pair[1])
+		if b == nil {
+			continue
+		}
+		out.Batches = append(out.Batches, *b)
+
+		switch b.Status {
+		case Added:
+			out.Summary.BatchesAdded++
+		case Removed:
+			out.Summary.BatchesRemoved++
+		case Modified:
+			out.Summary.BatchesModified++
+		}
+		for _, e := range b.Entries {
+			switch e.Status {
+			case Added:
+				out.Summary.EntriesAdded++
+			case Removed:
+				out.Summary.EntriesRemoved++
+			case Modified:
+				out.Summary.EntriesModified++
+			}
+		}
+	}
+
+	out.Equal = len(out.Header) == 0 && len(out.Control) == 0 && len(out.Batches) == 0
+	return out
+}
+
+// matchBatches pairs each old batch with a new batch, either side is nil when unmatched.
+// Batches are first matched on their full signature and then on their SEC code, company and ODFI.
+func matchBatches(old, new []ach.Batcher) [][2]ach.Batcher {
+	pairs := make([][2]ach.Batcher, len(old))
+	used := make([]bool, len(new))
+
+	for _, key := range []func(*ach.BatchHeader) string{signature, looseSignature} {
+		for i := range old {
+			if pairs[i][1] != nil {
+				continue
+			}
+			want := key(old[i].GetHeader())
+			for j := range new {
+				if !used[j] && key(new[j].GetHeader()) == want {
+					pairs[i][1] = new[j]
+					used[j] = true
+					break
+				}
+			}
+		}
+	}
+	for i := range old {
+		pairs[i][0] = old[i]
+	}
+	for j := range new {
+		if !used[j] {
+			pairs = append(pairs, [2]ach.Batcher{nil, new[j]})
+		}
+	}
+	return pairs
+}
+
+func signature(bh *ach.BatchHeader) string {
+	return strings.Join([]string{
+		strconv.Itoa(bh.ServiceClassCode),
+		bh.StandardEntryClassCode,
+		strings.TrimSpace(bh.CompanyIdentification),
+		strings.TrimSpace(bh.CompanyEntryDescription),
+		bh.EffectiveEntryDate,
+		bh.ODFIIdentification,
+	}, "|")
+}
+
+func looseSignature(bh *ach.BatchHeader) string {
+	return strings.Join([]string{
+		bh.StandardEntryClassCode,
+		strings.TrimSpace(bh.CompanyIdentification),
+		bh.ODFIIdentification,
+	}, "|")
+}
+
+// diffBatch returns nil when both batches are equal
+func diffBatch(old, new ach.Batcher) *Batch {
+	switch {
+	case new == nil:
+		b := &Batch{Status: Removed, Old: old.GetHeader()}
+		for _, ed := range old.GetEntries() {
+			b.Entries = append(b.Entries, Entry{Status: Removed, Old: ed})
+		}
+		return b
+
+	case old == nil:
+		b := &Batch{Status: Added, New: new.GetHeader()}
+		for _, ed := range new.GetEntries() {
+			b.Entries = append(b.Entries, Entry{Status: Added, New: ed})
+		}
+		return b
+	}
+
+	b := &Batch{
+		Status:  Modified,
+		Old:     old.GetHeader(),
+		New:     new.GetHeader(),
+		Header:  compare(batchHeaderFields, old.GetHeader(), new.GetHeader()),
+		Entries: diffEntries(old.GetEntries(), new.GetEntries()),
+	}
+	if oc, nc := old.GetControl(), new.GetControl(); oc != nil && nc != nil {
+		b.Control = compare(batchControlFields, oc, nc)
+	}
+	if len(b.Header) == 0 && len(b.Control) == 0 && len(b.Entries) == 0 {
+		return nil
+	}
+	return b
+}
+
+// diffEntries matches entries by trace number and then by RDFI, account number and amount
+func diffEntries(old, new []*ach.EntryDetail) []Entry {
+	matches := make([]int, len(old))
+	matchedBy := make([]string, len(old))
+	for i := range matches {
+		matches[i] = -1
+	}
+	used := make([]bool, len(new))
+
+	strategies := []struct {
+		name string
+		key  func(*ach.EntryDetail) string
+	}{
+		{MatchedByTraceNumber, func(ed *ach.EntryDetail) string { return ed.TraceNumber }},
+		{MatchedByAccountAmount, func(ed *ach.EntryDetail) string {
+			return ed.RDFIIdentification + ed.CheckDigit + "|" + strings.TrimSpace(ed.DFIAccountNumber) + "|" + strconv.Itoa(ed.Amount)
+		}},
+	}
+	for _, strategy := range strategies {
+		index := make(map[string][]int)
+		for j := range new {
+			if !used[j] {
+				k := strategy.key(new[j])
+				index[k] = append(index[k], j)
+			}
+		}
+		for i := range old {
+			if matches[i] >= 0 {
+				continue
+			}
+			k := strategy.key(old[i])
+			if candidates := index[k]; len(candidates) > 0 {
+				matches[i], matchedBy[i] = candidates[0], strategy.name
+				used[candidates[0]] = true
+				index[k] = candidates[1:]
+			}
+		}
+	}
+
+	var out []Entry
+	for i := range old {
+		if matches[i] < 0 {
+			out = append(out, Entry{Status: Removed, Old: old[i]})
+			continue
+		}
+		ed := new[matches[i]]
+		if changes := compareEntries(old[i], ed); len(changes) > 0 {
+			out = append(out, Entry{
+				Status:    Modified,
+				MatchedBy: matchedBy[i],
+				Old:       old[i],
+				New:       ed,
+				Changes:   changes,
+			})
+		}
+	}
+	for j := range new {
+		if !used[j] {
+			out = append(out, Entry{Status: Added, New: new[j]})
+		}
+	}
+	return out
+}
+
+func compareEntries(old, new *ach.EntryDetail) []Change {
+	changes := compare(entryFields, old, new)
+	if o, n := addenda(old), addenda(new); o != n {
+		changes = append(changes, Change{Field: "addenda", Old: o, New: n})
+	}
+	return changes
+}
+
+// addenda returns every addenda record of an entry, one per line
+func addenda(ed *ach.EntryDetail) string {
+	var lines []string
+	if ed.Addenda02 != nil {
+		lines = append(lines, ed.Addenda02.String())
+	}
+	for _, a := range ed.Addenda05 {
+		if a != nil {
+			lines = append(lines, a.String())
+		}
+	}
+	if ed.Addenda98 != nil {
+		lines = append(lines, ed.Addenda98.String())
+	}
+	if ed.Addenda98Refused != nil {
+		lines = append(lines, ed.Addenda98Refused.String())
+	}
+	if ed.Addenda99 != nil {
+		lines = append(lines, ed.Addenda99.String())
+	}
+	if ed.Addenda99Dishonored != nil {
+		lines = append(lines, ed.Addenda99Dishonored.String())
+	}
+	if ed.Addenda99Contested != nil {
+		lines = append(lines, ed.Addenda99Contested.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+type field[T any] struct {
+	name string
+	get  func(T) string
+}
+
+func compare[T any](fields []field[T], old, new T) []Change {
+	var out []Change
+	for _, f := range fields {
+		if o, n := f.get(old), f.get(new); o != n {
+			out = append(out, Change{Field: f.name, Old: o, New: n})
+		}
+	}
+	return out
+}
+
+func trim(s string) string {
+	return strings.TrimSpace(s)
+}
+
+var fileHeaderFields = []field[*ach.FileHeader]{
+	{"immediateDestination", func(fh *ach.FileHeader) string { return trim(fh.ImmediateDestination) }},
+	{"immediateOrigin", func(fh *ach.FileHeader) string { return trim(fh.ImmediateOrigin) }},
+	{"fileCreationDate", func(fh *ach.FileHeader) string { return fh.FileCreationDate }},
+	{"fileCreationTime", func(fh *ach.FileHeader) string { return fh.FileCreationTime }},
+	{"fileIDModifier", func(fh *ach.FileHeader) string { return fh.FileIDModifier }},
+	{"immediateDestinationName", func(fh *ach.FileHeader) string { return trim(fh.ImmediateDestinationName) }},
+	{"immediateOriginName", func(fh *ach.FileHeader) string { return trim(fh.ImmediateOriginName) }},
+	{"referenceCode", func(fh *ach.FileHeader) string { return trim(fh.ReferenceCode) }},
+}
+
+var fileControlFields = []field[*ach.FileControl]{
+	{"batchCount", func(fc *ach.FileControl) string { return strconv.Itoa(fc.BatchCount) }},
+	{"blockCount", func(fc *ach.FileControl) string { return strconv.Itoa(fc.BlockCount) }},
+	{"entryAddendaCount", func(fc *ach.FileControl) string { return strconv.Itoa(fc.EntryAddendaCount) }},
+	{"entryHash", func(fc *ach.FileControl) string { return strconv.Itoa(fc.EntryHash) }},
+	{"totalDebit", func(fc *ach.FileControl) string { return strconv.Itoa(fc.TotalDebitEntryDollarAmountInFile) }},
+	{"totalCredit", func(fc *ach.FileControl) string { return strconv.Itoa(fc.TotalCreditEntryDollarAmountInFile) }},
+}
+
+var batchHeaderFields = []field[*ach.BatchHeader]{
+	{"serviceClassCode", func(bh *ach.BatchHeader) string { return strconv.Itoa(bh.ServiceClassCode) }},
+	{"companyName", func(bh *ach.BatchHeader) string { return trim(bh.CompanyName) }},
+	{"companyDiscretionaryData", func(bh *ach.BatchHeader) string { return trim(bh.CompanyDiscretionaryData) }},
+	{"companyIdentification", func(bh *ach.BatchHeader) string { return trim(bh.CompanyIdentification) }},
+	{"standardEntryClassCode", func(bh *ach.BatchHeader) string { return bh.StandardEntryClassCode }},
+	{"companyEntryDescription", func(bh *ach.BatchHeader) string { return trim(bh.CompanyEntryDescription) }},
+	{"companyDescriptiveDate", func(bh *ach.BatchHeader) string { return trim(bh.CompanyDescriptiveDate) }},
+	{"effectiveEntryDate", func(bh *ach.BatchHeader) string { return bh.EffectiveEntryDate }},
+	{"settlementDate", func(bh *ach.BatchHeader) string { return trim(bh.SettlementDate) }},
+	{"originatorStatusCode", func(bh *ach.BatchHeader) string { return strconv.Itoa(bh.OriginatorStatusCode) }},
+	{"ODFIIdentification", func(bh *ach.BatchHeader) string { return bh.ODFIIdentification }},
+	{"batchNumber", func(bh *ach.BatchHeader) string { return strconv.Itoa(bh.BatchNumber) }},
+}
+
+var batchControlFields = []field[*ach.BatchControl]{
+	{"serviceClassCode", func(bc *ach.BatchControl) string { return strconv.Itoa(bc.ServiceClassCode) }},
+	{"entryAddendaCount", func(bc *ach.BatchControl) string { return strconv.Itoa(bc.EntryAddendaCount) }},
+	{"entryHash", func(bc *ach.BatchControl) string { return strconv.Itoa(bc.EntryHash) }},
+	{"totalDebit", func(bc *ach.BatchControl) string { return strconv.Itoa(bc.TotalDebitEntryDollarAmount) }},
+	{"totalCredit", func(bc *ach.BatchControl) string { return strconv.Itoa(bc.TotalCreditEntryDollarAmount) }},
+	{"messageAuthentication", func(bc *ach.BatchControl) string { return trim(bc.MessageAuthenticationCode) }},
+}
+
+var entryFields = []field[*ach.EntryDetail]{
+	{"transactionCode", func(ed *ach.EntryDetail) string { return strconv.Itoa(ed.TransactionCode) }},
+	{"RDFIIdentification", func(ed *ach.EntryDetail) string { return ed.RDFIIdentification }},
+	{"checkDigit", func(ed *ach.EntryDetail) string { return ed.CheckDigit }},
+	{"DFIAccountNumber", func(ed *ach.EntryDetail) string { return trim(ed.DFIAccountNumber) }},
+	{"amount", func(ed *ach.EntryDetail) string { return strconv.Itoa(ed.Amount) }},
+	{"identificationNumber", func(ed *ach.EntryDetail) string { return trim(ed.IdentificationNumber) }},
+	{"individualName", func(ed *ach.EntryDetail) string { return trim(ed.IndividualName) }},
+	{"discretionaryData", func(ed *ach.EntryDetail) string { return trim(ed.DiscretionaryData) }},
+	{"addendaRecordIndicator", func(ed *ach.EntryDetail) string { return strconv.Itoa(ed.AddendaRecordIndicator) }},
+	{"traceNumber", func(ed *ach.EntryDetail) string { return ed.TraceNumber }},
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readFile(t *testing.T, name string) *ach.File {
+	t.Helper()
+
+	file, err := ach.ReadFile(filepath.Join("..", "..", "..", "test", "testdata", name))
+	require.NoError(t, err)
+	return file
+}
+
+func TestFiles__Equal(t *testing.T) {
+	r := Files(readFile(t, "ppd-mixedDebitCredit.ach"), readFile(t, "ppd-mixedDebitCredit.ach"))
+	require.True(t, r.Equal)
+	require.Empty(t, r.Batches)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, r, "text"))
+	require.Equal(t, "Files are equal\n", buf.String())
+}
+
+func TestFiles__Entries(t *testing.T) {
+	old := readFile(t, "ppd-mixedDebitCredit.ach")
+	new := readFile(t, "ppd-mixedDebitCredit.ach")
+
+	entries := new.Batches[0].GetEntries()
+	entries[0].IndividualName = "Renamed Account"    // modified, matched by trace number
+	entries[1].DFIAccountNumber = "555555555       " // removed and added
+	entries[1].TraceNumber = "121042880000005"
+	entries[2].TraceNumber = "121042880000009" // matched by account and amount
+
+	added := ach.NewAddenda05()
+	added.PaymentRelatedInformation = "invoice 123"
+	entries[2].AddAddenda05(added)
+	entries[2].AddendaRecordIndicator = 1
+
+	new.Batches[0].GetHeader().BatchNumber = 2
+	require.NoError(t, new.Batches[0].Create())
+	require.NoError(t, new.Create())
+
+	r := Files(old, new)
+	require.False(t, r.Equal)
+	require.Len(t, r.Batches, 1)
+
+	b := r.Batches[0]
+	require.Equal(t, Modified, b.Status)
+	require.Equal(t, []Change{{Field: "batchNumber", Old: "1", New: "2"}}, b.Header)
+	require.Len(t, b.Entries, 4)
+
+	require.Equal(t, Modified, b.Entries[0].Status)
+	require.Equal(t, MatchedByTraceNumber, b.Entries[0].MatchedBy)
+	require.Equal(t, []Change{{Field: "individualName", Old: "Debit Account", New: "Renamed Account"}}, b.Entries[0].Changes)
+
+	require.Equal(t, Removed, b.Entries[1].Status)
+	require.Equal(t, "987654321", strings.TrimSpace(b.Entries[1].Old.DFIAccountNumber))
+
+	require.Equal(t, Modified, b.Entries[2].Status)
+	require.Equal(t, MatchedByAccountAmount, b.Entries[2].MatchedBy)
+	fields := make([]string, len(b.Entries[2].Changes))
+	for i, c := range b.Entries[2].Changes {
+		fields[i] = c.Field
+	}
+	require.Equal(t, []string{"addendaRecordIndicator", "traceNumber", "addenda"}, fields)
+
+	require.Equal(t, Added, b.Entries[3].Status)
+	require.Equal(t, "555555555", strings.TrimSpace(b.Entries[3].New.DFIAccountNumber))
+
+	require.Equal(t, Summary{BatchesModified: 1, EntriesAdded: 1, EntriesRemoved: 1, EntriesModified: 2}, r.Summary)
+
+	// Control totals follow the entries
+	require.Contains(t, r.Control, Change{Field: "entryAddendaCount", Old: "3", New: "4"})
+	require.Contains(t, b.Control, Change{Field: "entryAddendaCount", Old: "3", New: "4"})
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, r, ""))
+
+		out := buf.String()
+		require.Contains(t, out, "~ Batch 2 PPD Name on Account REG.SALARY (modified)")
+		require.Contains(t, out, "individualName: Debit Account -> Renamed Account")
+		require.Contains(t, out, "~ Entry 121042880000009 231380104 837098765 100000000 (matched by RDFI, account and amount)")
+		require.Contains(t, out, "- Entry 121042880000002 231380104 987654321 100000000")
+		require.Contains(t, out, "+ 705invoice 123")
+		require.Contains(t, out, "Entries: 1 added, 1 removed, 2 modified")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, r, "JSON"))
+
+		var decoded Result
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		require.Equal(t, r.Summary, decoded.Summary)
+		require.Equal(t, "modified", string(decoded.Batches[0].Entries[0].Status))
+		require.Equal(t, "Renamed Account", decoded.Batches[0].Entries[0].New.IndividualName)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		require.ErrorContains(t, Write(nil, r, "xml"), `unknown diff output format "xml"`)
+	})
+}
+
+func TestFiles__Batches(t *testing.T) {
+	old := readFile(t, "ppd-mixedDebitCredit.ach")
+	new := readFile(t, "ppd-mixedDebitCredit.ach")
+
+	// Effective date changes still match on the SEC code, company and ODFI
+	new.Batches[0].GetHeader().EffectiveEntryDate = "190720"
+
+	web := readFile(t, "web-debit.ach")
+	new.AddBatch(web.Batches[0])
+	require.NoError(t, new.Create())
+
+	r := Files(old, new)
+	require.Len(t, r.Batches, 2)
+
+	require.Equal(t, Modified, r.Batches[0].Status)
+	require.Equal(t, []Change{{Field: "effectiveEntryDate", Old: "190719", New: "190720"}}, r.Batches[0].Header)
+	require.Empty(t, r.Batches[0].Entries)
+
+	require.Equal(t, Added, r.Batches[1].Status)
+	require.Len(t, r.Batches[1].Entries, len(web.Batches[0].GetEntries()))
+
+	// Swapping old and new removes the batch
+	r = Files(new, old)
+	require.Equal(t, Removed, r.Batches[1].Status)
+	require.Equal(t, 1, r.Summary.BatchesRemoved)
+	require.Equal(t, len(web.Batches[0].GetEntries()), r.Summary.EntriesRemoved)
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/ansiterm"
+)
+
+// Write prints a Result as text (the default) or json
+func Write(w io.Writer, r *Result, format string) error {
+	switch strings.ToLower(format) {
+	case "", "text":
+		writeText(ansiterm.NewWriter(w), r)
+		return nil
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+	return fmt.Errorf("unknown diff output format %q", format)
+}
+
+var colors = map[Status]ansiterm.Color{
+	Added:    ansiterm.Green,
+	Removed:  ansiterm.Red,
+	Modified: ansiterm.Yellow,
+}
+
+var symbols = map[Status]string{
+	Added:    "+",
+	Removed:  "-",
+	Modified: "~",
+}
+
+func writeText(w *ansiterm.Writer, r *Result) {
+	if r.Equal {
+		fmt.Fprintln(w, "Files are equal")
+		return
+	}
+
+	if len(r.Header) > 0 {
+		fmt.Fprintln(w, "File Header")
+		writeChanges(w, "  ", r.Header)
+	}
+
+	for _, b := range r.Batches {
+		bh := b.New
+		if bh == nil {
+			bh = b.Old
+		}
+		colored(w, b.Status, fmt.Sprintf("%s Batch %d %s %s %s (%s)\n", symbols[b.Status],
+			bh.BatchNumber, bh.StandardEntryClassCode, strings.TrimSpace(bh.CompanyName), strings.TrimSpace(bh.CompanyEntryDescription), b.Status))
+
+		if len(b.Header) > 0 {
+			fmt.Fprintln(w, "    Header")
+			writeChanges(w, "      ", b.Header)
+		}
+		for _, e := range b.Entries {
+			ed := e.New
+			if ed == nil {
+				ed = e.Old
+			}
+			line := fmt.Sprintf("    %s Entry %s %s %s %d", symbols[e.Status],
+				ed.TraceNumber, ed.RDFIIdentification+ed.CheckDigit, strings.TrimSpace(ed.DFIAccountNumber), ed.Amount)
+			if e.MatchedBy == MatchedByAccountAmount {
+				line += " (matched by RDFI, account and amount)"
+			}
+			colored(w, e.Status, line+"\n")
+			writeChanges(w, "        ", e.Changes)
+		}
+		if len(b.Control) > 0 {
+			fmt.Fprintln(w, "    Control")
+			writeChanges(w, "      ", b.Control)
+		}
+	}
+
+	if len(r.Control) > 0 {
+		fmt.Fprintln(w, "File Control")
+		writeChanges(w, "  ", r.Control)
+	}
+
+	s := r.Summary
+	fmt.Fprintf(w, "\nBatches: %d added, %d removed, %d modified\n", s.BatchesAdded, s.BatchesRemoved, s.BatchesModified)
+	fmt.Fprintf(w, "Entries: %d added, %d removed, %d modified\n", s.EntriesAdded, s.EntriesRemoved, s.EntriesModified)
+}
+
+func writeChanges(w *ansiterm.Writer, indent string, changes []Change) {
+	for _, c := range changes {
+		if c.Field == "addenda" {
+			writeAddenda(w, indent, c)
+			continue
+		}
+		fmt.Fprintf(w, "%s%s: ", indent, c.Field)
+		colored(w, Removed, quote(c.Old))
+		fmt.Fprint(w, " -> ")
+		colored(w, Added, quote(c.New))
+		fmt.Fprintln(w)
+	}
+}
+
+func writeAddenda(w *ansiterm.Writer, indent string, c Change) {
+	fmt.Fprintf(w, "%saddenda:\n", indent)
+	for _, line := range strings.Split(c.Old, "\n") {
+		if line != "" {
+			colored(w, Removed, fmt.Sprintf("%s  - %s\n", indent, line))
+		}
+	}
+	for _, line := range strings.Split(c.New, "\n") {
+		if line != "" {
+			colored(w, Added, fmt.Sprintf("%s  + %s\n", indent, line))
+		}
+	}
+}
+
+func quote(s string) string {
+	if s == "" || strings.TrimSpace(s) != s {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+func colored(w *ansiterm.Writer, status Status, s string) {
+	w.SetForeground(colors[status])
+	fmt.Fprint(w, s)
+	w.Reset()
+}
@@ -20,9 +20,18 @@ USAGE
    achcli [-mask] [-pretty] [-validate opts.json] path/to/file.ach
 
 EXAMPLES
-  achcli -diff first.ach second.ach    Show the difference between two ACH files
+  achcli -browse file.ach              Interactively browse batches, entries, addenda and errors of a file
+  achcli -create spec.yaml             Create a Nacha file from a payments spec (see README)
+  achcli -diff first.ach second.ach    Show the batches and entries added, removed or changed between two files
+  achcli -duplicates new.ach old/*.ach Check new.ach for entries or contents already in previous files
+  achcli -enrich returns.ach           Print file details with the reason and rules of each return and NOC (also for -reformat=json)
+  achcli -fedach FedACHdir.txt f.ach   Print file details with the bank name of each RDFI (also for -reformat=html and pdf)
+  achcli -fix -fixers=all file.ach     Repair a file, writing file.ach.fix (add -dry-run to only report changes)
   achcli -mask file.ach                Print file details with personally identifiable information partially removed
-  achcli -reformat=json first.ach      Convert an incoming ACH file into another format (options: ach, json)
+  achcli -ofac sdn.csv,alt.csv f.ach   Screen the parties of each entry against OFAC sanctions lists
+  achcli -query 'amount > 50000' dir/  Print entries of files matching an expression (see README)
+  achcli -reformat=json first.ach      Convert an incoming ACH file into another format (options: ach, json, html, pdf)
+  achcli -report 2026-10-16/           Print totals and counts by SEC code, company, RDFI, return code and more
   achcli -validate opts.json file.ach  Read an ACH File with the provided ValidateOpts
   achcli -version                      Print the version of achcli (Example: %s)
   achcli 20060102.ach                  Summarize an ACH file for human readability
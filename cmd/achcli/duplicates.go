@@ -0,0 +1,84 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/describe/mask"
+	"github.com/moov-io/ach/duplicates"
+)
+
+var errDuplicatesFound = errors.New("duplicates found")
+
+// checkDuplicates compares the first file in paths against every other file. Account numbers are
+// masked in the printed report when maskAccounts is set.
+func checkDuplicates(paths []string, window time.Duration, maskAccounts bool, validateOpts *ach.ValidateOpts) error {
+	if len(paths) < 2 {
+		return fmt.Errorf("expected a file and at least one previous file, but got %d", len(paths))
+	}
+
+	detector := duplicates.NewDetector(duplicates.NewMemoryHistory(), duplicates.Options{
+		Window: window,
+	})
+	for _, path := range paths[1:] {
+		file, err := readIncomingFile(path, validateOpts)
+		if err != nil {
+			return fmt.Errorf("problem reading %s: %v", path, err)
+		}
+		file.ID = path
+		if err := detector.Record(file); err != nil {
+			return fmt.Errorf("problem recording %s: %v", path, err)
+		}
+	}
+
+	file, err := readIncomingFile(paths[0], validateOpts)
+	if err != nil {
+		return fmt.Errorf("problem reading %s: %v", paths[0], err)
+	}
+	report, err := detector.Check(file)
+	if err != nil {
+		return err
+	}
+
+	printDuplicatesReport(os.Stdout, paths[0], report, maskAccounts)
+	if report.Found() {
+		return errDuplicatesFound
+	}
+	return nil
+}
+
+func printDuplicatesReport(ww io.Writer, path string, report *duplicates.Report, maskAccounts bool) {
+	w := tabwriter.NewWriter(ww, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintf(w, "Checked %s (hash %s)\n", path, report.FileHash)
+	if report.DuplicateFile {
+		fmt.Fprintf(w, "  Duplicate of %s\n", report.DuplicateFileID)
+	}
+	if len(report.Entries) == 0 {
+		fmt.Fprintln(w, "  No duplicate entries found")
+		return
+	}
+
+	fmt.Fprintln(w, "\n  BatchNumber\tTraceNumber\tRDFIIdentification\tAccountNumber\tAmount\tPreviousFile\tPreviousTraceNumber\tEffectiveEntryDate")
+	for _, entry := range report.Entries {
+		for _, match := range entry.Matches {
+			accountNumber := match.DFIAccountNumber
+			if maskAccounts {
+				accountNumber = mask.Number(accountNumber)
+			}
+			fmt.Fprintf(w, "  %d\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+				entry.BatchNumber, entry.TraceNumber, match.RDFIIdentification, accountNumber,
+				match.Amount, match.FileID, match.TraceNumber, match.EffectiveEntryDate.Format("2006-01-02"))
+		}
+	}
+}
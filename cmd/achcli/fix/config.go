@@ -2,4 +2,10 @@ package fix
 
 type Config struct {
 	UpdateEED string
+
+	// Fixers are the names of repairs to apply (see Fixers), "all" applies every one.
+	Fixers []string
+
+	// DryRun reports every change without writing the fixed file
+	DryRun bool
 }
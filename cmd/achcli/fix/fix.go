@@ -4,16 +4,53 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/moov-io/ach"
 	"github.com/moov-io/ach/cmd/achcli/internal/read"
 	"github.com/moov-io/ach/cmd/achcli/internal/write"
 )
 
-func Perform(path string, validateOptsPath *string, skipAll *bool, conf Config) (string, error) {
-	file, format, err := read.Filepath(path, validateOptsPath, skipAll)
+// Result describes what Perform changed
+type Result struct {
+	// Path of the fixed file, which is empty for dry runs
+	Path string
+
+	Changes []Change
+
+	// Invalid is the validation error remaining after fixes were applied
+	Invalid error
+}
+
+// Perform reads the file at path, applies each configured fixer and writes the fixed file
+// alongside it with a .fix suffix.
+//
+// Files are read without validation so malformed files can be repaired, the fixed file is
+// validated afterwards with the ValidateOpts at validateOptsPath.
+func Perform(path string, validateOptsPath *string, skipAll *bool, conf Config) (*Result, error) {
+	validateOpts, err := read.ValidationOpts(validateOptsPath, skipAll)
 	if err != nil {
-		return "", fmt.Errorf("reading %s failed: %w", path, err)
+		return nil, err
+	}
+	names, err := selectFixers(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s failed: %w", path, err)
+	}
+
+	rep := &report{}
+	if read.DetectFormat(bs) == read.FormatNacha {
+		bs = fixLines(bs, names, rep)
+	}
+
+	file, format, err := read.Bytes(bs, &ach.ValidateOpts{SkipAll: true})
+	if err != nil {
+		return nil, fmt.Errorf("reading %s failed: %w", path, err)
 	}
 
 	// Build up our fixers
@@ -27,28 +64,69 @@ func Perform(path string, validateOptsPath *string, skipAll *bool, conf Config)
 		// Batch headers
 		bh := file.Batches[idx].GetHeader()
 		for _, fn := range batchHeaderFixers {
-			if err := fn(bh); err != nil {
-				return "", fmt.Errorf("applying %T to batch header: %w", fn, err)
+			if err := fn.fix(bh, rep.fixer(fn.name).at(fmt.Sprintf("batch %d header", idx+1))); err != nil {
+				return nil, fmt.Errorf("applying %s to batch header: %w", fn.name, err)
 			}
 		}
 		file.Batches[idx].SetHeader(bh)
 	}
+	for _, f := range fileFixers {
+		if slices.Contains(names, f.name) {
+			f.fix(file, rep.fixer(f.name))
+		}
+	}
+
+	result := &Result{
+		Changes: rep.changes,
+		Invalid: file.ValidateWith(validateOpts),
+	}
+	if conf.DryRun {
+		return result, nil
+	}
 
 	// Write file
 	newpath := path + ".fix"
 
 	var buf bytes.Buffer
-	err = write.File(&buf, file, format)
+	if format == read.FormatNacha {
+		// Keep the line endings of the input
+		w := ach.NewWriter(&buf)
+		w.LineEnding = lineEnding(bs)
+		err = w.Write(file)
+	} else {
+		err = write.File(&buf, file, format)
+	}
 	if err != nil {
-		return "", fmt.Errorf("encoding fixed file as %s: %w", format, err)
+		return nil, fmt.Errorf("encoding fixed file as %s: %w", format, err)
 	}
 
 	err = os.WriteFile(newpath, buf.Bytes(), 0600)
 	if err != nil {
-		return "", fmt.Errorf("writing %s failed: %w", newpath, err)
+		return nil, fmt.Errorf("writing %s failed: %w", newpath, err)
 	}
+	result.Path = newpath
+
+	return result, nil
+}
 
-	return newpath, nil
+func selectFixers(conf Config) ([]string, error) {
+	var out []string
+	for _, name := range conf.Fixers {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "":
+		case name == "all":
+			return Fixers(), nil
+		case slices.Contains(Fixers(), name):
+			out = append(out, name)
+		default:
+			return nil, fmt.Errorf("unknown fixer %q (options: all, %s)", name, strings.Join(Fixers(), ", "))
+		}
+	}
+	return out, nil
 }
 
-type batchHeaderFixer func(bh *ach.BatchHeader) error
+type batchHeaderFixer struct {
+	name string
+	fix  func(bh *ach.BatchHeader, rec *recorder) error
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/moov-io/ach"
 	"github.com/moov-io/ach/cmd/achcli/fix"
 
 	"github.com/stretchr/testify/require"
@@ -31,10 +33,16 @@ func TestPerform(t *testing.T) {
 		_, filename := filepath.Split(tc.inputFilepath)
 
 		t.Run(filename, func(t *testing.T) {
-			newpath, err := fix.Perform(tc.inputFilepath, tc.validateOptsPath, tc.skipAll, tc.config)
+			// Fix a copy of the input so the .fix file is written outside of testdata
+			bs, err := os.ReadFile(tc.inputFilepath)
 			require.NoError(t, err)
+			path := filepath.Join(t.TempDir(), filename)
+			require.NoError(t, os.WriteFile(path, bs, 0600))
 
-			got, err := os.ReadFile(newpath)
+			result, err := fix.Perform(path, tc.validateOptsPath, tc.skipAll, tc.config)
+			require.NoError(t, err)
+
+			got, err := os.ReadFile(result.Path)
 			require.NoError(t, err)
 
 			expected, err := os.ReadFile(tc.expectedFilepath)
@@ -53,3 +61,102 @@ func ptr[T any](in T) *T { return &in }
 func normalize(input []byte) []byte {
 	return bytes.TrimSpace(bytes.ReplaceAll(input, []byte("\r\n"), []byte("\n")))
 }
+
+func TestPerform__Fixers(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("..", "..", "..", "test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	lines := strings.Split(string(bs), "\n")
+	replace := func(line, pos int, value string) {
+		lines[line] = lines[line][:pos] + value + lines[line][pos+len(value):]
+	}
+	replace(1, 1, "220")                        // credits only, but has a debit
+	replace(1, 87, "0000007")                   // batch number
+	replace(2, 11, "9")                         // check digit
+	replace(2, 54, "Debit Accounté")            // non-ASCII
+	replace(3, 12, "ab")                        // lowercase account number
+	replace(0, 33, "a")                         // lowercase FileIDModifier
+	lines[0] = strings.TrimRight(lines[0], " ") // short line
+	replace(4, 78, "1")                         // addenda record indicator
+	replace(4, 87, "0000002")                   // duplicate trace number
+	replace(5, 10, "0000000001")                // entry hash
+	replace(6, 31, "000000000001")              // file total debits
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "broken.ach")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0600))
+
+	t.Run("dry run", func(t *testing.T) {
+		result, err := fix.Perform(path, nil, nil, fix.Config{
+			Fixers: []string{"all"},
+			DryRun: true,
+		})
+		require.NoError(t, err)
+		require.Empty(t, result.Path)
+		require.NoError(t, result.Invalid)
+
+		changes := make(map[string]fix.Change)
+		for _, c := range result.Changes {
+			changes[c.Fixer+" "+c.Record+" "+c.Field] = c
+		}
+		require.Equal(t, fix.Change{Fixer: "characters", Record: "line 3", Field: "characters", Old: "é", New: " "}, changes["characters line 3 characters"])
+		require.Equal(t, fix.Change{Fixer: "characters", Record: "line 1", Field: "fileIDModifier", Old: "a", New: "A"}, changes["characters line 1 fileIDModifier"])
+		require.Equal(t, "94", changes["line-length line 1 length"].New)
+		require.Equal(t, "0", changes["addenda batch 1 entry 3 addendaRecordIndicator"].New)
+		require.Equal(t, "9", changes["check-digits batch 1 entry 1 checkDigit"].Old)
+		require.Equal(t, "200", changes["service-class-codes batch 1 header serviceClassCode"].New)
+		require.Equal(t, "7", changes["batch-numbers batch 1 header batchNumber"].Old)
+		require.Equal(t, "121042880000003", changes["trace-numbers batch 1 entry 3 traceNumber"].New)
+		require.Equal(t, "1", changes["controls batch 1 control entryHash"].Old)
+		require.Equal(t, "200000000", changes["controls file control totalDebit"].New)
+
+		_, err = os.Stat(path + ".fix")
+		require.ErrorIs(t, err, os.ErrNotExist)
+
+		var buf bytes.Buffer
+		require.NoError(t, fix.WriteReport(&buf, result.Changes))
+		require.Contains(t, buf.String(), `check-digits         batch 1 entry 1`)
+	})
+
+	t.Run("write", func(t *testing.T) {
+		result, err := fix.Perform(path, nil, nil, fix.Config{
+			Fixers: []string{"characters", "line-length", "check-digits", "service-class-codes", "batch-numbers", "trace-numbers", "controls"},
+		})
+		require.NoError(t, err)
+		require.NoError(t, result.Invalid)
+
+		file, err := ach.ReadFile(result.Path)
+		require.NoError(t, err)
+		require.Equal(t, "Debit Account", strings.TrimSpace(file.Batches[0].GetEntries()[0].IndividualName))
+		require.True(t, strings.HasPrefix(file.Batches[0].GetEntries()[1].DFIAccountNumber, "ab"))
+		require.Equal(t, "A", file.Header.FileIDModifier)
+	})
+
+	t.Run("CRLF", func(t *testing.T) {
+		path := filepath.Join(dir, "crlf.ach")
+		require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\r\n")), 0600))
+
+		result, err := fix.Perform(path, nil, nil, fix.Config{Fixers: []string{"all"}})
+		require.NoError(t, err)
+		require.NoError(t, result.Invalid)
+
+		bs, err := os.ReadFile(result.Path)
+		require.NoError(t, err)
+		require.NotContains(t, strings.ReplaceAll(string(bs), "\r\n", ""), "\n")
+		require.Equal(t, 10, strings.Count(string(bs), "\r\n"))
+	})
+
+	t.Run("not fixed", func(t *testing.T) {
+		result, err := fix.Perform(path, nil, nil, fix.Config{
+			Fixers: []string{"characters", "line-length"},
+			DryRun: true,
+		})
+		require.NoError(t, err)
+		require.Error(t, result.Invalid)
+	})
+
+	t.Run("unknown fixer", func(t *testing.T) {
+		_, err := fix.Perform(path, nil, nil, fix.Config{Fixers: []string{"controls", "typo"}})
+		require.ErrorContains(t, err, `unknown fixer "typo"`)
+	})
+}
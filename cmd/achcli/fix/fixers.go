@@ -1,12 +1,418 @@
 package fix
 
 import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/moov-io/ach"
 )
 
 func updateEED(conf Config) batchHeaderFixer {
-	return func(bh *ach.BatchHeader) error {
-		bh.EffectiveEntryDate = conf.UpdateEED
-		return nil
+	return batchHeaderFixer{
+		name: "update-eed",
+		fix: func(bh *ach.BatchHeader, rec *recorder) error {
+			rec.record("effectiveEntryDate", bh.EffectiveEntryDate, conf.UpdateEED)
+			bh.EffectiveEntryDate = conf.UpdateEED
+			return nil
+		},
+	}
+}
+
+// Fixers returns the name of each fixer in the order they are applied.
+func Fixers() []string {
+	var out []string
+	for _, f := range lineFixers {
+		out = append(out, f.name)
+	}
+	for _, f := range fileFixers {
+		out = append(out, f.name)
+	}
+	return out
+}
+
+// lineFixers repair the lines of Nacha files before they're parsed
+var lineFixers = []struct {
+	name string
+	fix  func(lineNumber int, line string, rec *recorder) string
+}{
+	{"characters", fixCharacters},
+	{"line-length", fixLineLength},
+}
+
+func fixLines(bs []byte, names []string, rep *report) []byte {
+	// Fixers are given each record without its line ending, which is put back afterwards
+	lines := strings.Split(string(bs), "\n")
+	crlf := make([]bool, len(lines))
+	for i := range lines {
+		lines[i], crlf[i] = strings.CutSuffix(lines[i], "\r")
+	}
+	for _, f := range lineFixers {
+		if !slices.Contains(names, f.name) {
+			continue
+		}
+		rec := rep.fixer(f.name)
+		for i := range lines {
+			lines[i] = f.fix(i+1, lines[i], rec.at(fmt.Sprintf("line %d", i+1)))
+		}
+	}
+	for i := range lines {
+		if crlf[i] {
+			lines[i] += "\r"
+		}
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// lineEnding returns the line ending used by a Nacha formatted file
+func lineEnding(bs []byte) string {
+	if bytes.Contains(bs, []byte("\r\n")) {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// fileIDModifierPos is the position of the FileIDModifier, which Nacha requires to be uppercase, in a FileHeader
+const fileIDModifierPos = 33
+
+// fixCharacters replaces non-printable and non-ASCII characters with spaces and uppercases
+// the FileIDModifier of the FileHeader
+func fixCharacters(_ int, line string, rec *recorder) string {
+	var buf strings.Builder
+	for _, r := range line {
+		if r < 0x20 || r > 0x7E {
+			r = ' '
+		}
+		buf.WriteRune(r)
+	}
+	fixed := buf.String()
+	if fixed != line {
+		before, after := changedSpan(line, fixed)
+		rec.record("characters", before, after)
+	}
+
+	if strings.HasPrefix(fixed, "1") && len(fixed) > fileIDModifierPos {
+		modifier := fixed[fileIDModifierPos : fileIDModifierPos+1]
+		if upper := strings.ToUpper(modifier); upper != modifier {
+			rec.record("fileIDModifier", modifier, upper)
+			fixed = fixed[:fileIDModifierPos] + upper + fixed[fileIDModifierPos+1:]
+		}
+	}
+	return fixed
+}
+
+// changedSpan returns the portion of before and after between their first and last differing characters
+func changedSpan(before, after string) (string, string) {
+	o, n := []rune(before), []rune(after)
+	if len(o) != len(n) {
+		return before, after
+	}
+	first, last := 0, len(o)-1
+	for first < len(o) && o[first] == n[first] {
+		first++
+	}
+	for last > first && o[last] == n[last] {
+		last--
+	}
+	return string(o[first : last+1]), string(n[first : last+1])
+}
+
+// fixLineLength pads short lines with spaces and trims trailing spaces from long lines.
+// Blank lines and files written without line breaks are left alone.
+func fixLineLength(lineNumber int, line string, rec *recorder) string {
+	length := utf8.RuneCountInString(line)
+	switch {
+	case strings.TrimSpace(line) == "", length == ach.RecordLength:
+		return line
+
+	case length < ach.RecordLength:
+		line += strings.Repeat(" ", ach.RecordLength-length)
+
+	case lineNumber == 1 && length%ach.RecordLength == 0:
+		return line
+
+	default:
+		trimmed := []rune(line)[:ach.RecordLength]
+		if strings.TrimSpace(string([]rune(line)[ach.RecordLength:])) != "" {
+			rec.record("length", strconv.Itoa(length), "not trimmed, characters after position 94")
+			return line
+		}
+		line = string(trimmed)
+	}
+	rec.record("length", strconv.Itoa(length), strconv.Itoa(ach.RecordLength))
+	return line
+}
+
+// fileFixers repair the records of a parsed file. IAT and ADV batches are not modified.
+var fileFixers = []struct {
+	name string
+	fix  func(file *ach.File, rec *recorder)
+}{
+	{"check-digits", fixCheckDigits},
+	{"addenda", fixAddenda},
+	{"service-class-codes", fixServiceClassCodes},
+	{"batch-numbers", fixBatchNumbers},
+	{"trace-numbers", fixTraceNumbers},
+	{"controls", fixControls},
+}
+
+// eachEntry calls fn for every entry of non-ADV batches along with its location
+func eachEntry(file *ach.File, rec *recorder, fn func(bh *ach.BatchHeader, ed *ach.EntryDetail, rec *recorder)) {
+	for i, batch := range file.Batches {
+		if batch.GetHeader().StandardEntryClassCode == ach.ADV {
+			continue
+		}
+		for j, ed := range batch.GetEntries() {
+			fn(batch.GetHeader(), ed, rec.at(fmt.Sprintf("batch %d entry %d", i+1, j+1)))
+		}
+	}
+}
+
+func fixCheckDigits(file *ach.File, rec *recorder) {
+	eachEntry(file, rec, func(_ *ach.BatchHeader, ed *ach.EntryDetail, rec *recorder) {
+		digit := ach.CalculateCheckDigit(ed.RDFIIdentification)
+		if digit < 0 {
+			return
+		}
+		rec.record("checkDigit", ed.CheckDigit, strconv.Itoa(digit))
+		ed.CheckDigit = strconv.Itoa(digit)
+	})
+}
+
+// fixAddenda sets AddendaRecordIndicator from the addenda present and renumbers Addenda05 records
+func fixAddenda(file *ach.File, rec *recorder) {
+	eachEntry(file, rec, func(_ *ach.BatchHeader, ed *ach.EntryDetail, rec *recorder) {
+		indicator := 0
+		if addendaCount(ed) > 0 {
+			indicator = 1
+		}
+		rec.record("addendaRecordIndicator", strconv.Itoa(ed.AddendaRecordIndicator), strconv.Itoa(indicator))
+		ed.AddendaRecordIndicator = indicator
+
+		for i, a := range ed.Addenda05 {
+			rec.record(fmt.Sprintf("addenda05[%d].sequenceNumber", i), strconv.Itoa(a.SequenceNumber), strconv.Itoa(i+1))
+			a.SequenceNumber = i + 1
+
+			seq := entrySequenceNumber(ed)
+			rec.record(fmt.Sprintf("addenda05[%d].entryDetailSequenceNumber", i), strconv.Itoa(a.EntryDetailSequenceNumber), strconv.Itoa(seq))
+			a.EntryDetailSequenceNumber = seq
+		}
+	})
+}
+
+func addendaCount(ed *ach.EntryDetail) int {
+	n := len(ed.Addenda05)
+	for _, present := range []bool{
+		ed.Addenda02 != nil, ed.Addenda98 != nil, ed.Addenda98Refused != nil,
+		ed.Addenda99 != nil, ed.Addenda99Dishonored != nil, ed.Addenda99Contested != nil,
+	} {
+		if present {
+			n++
+		}
+	}
+	return n
+}
+
+// entrySequenceNumber is the last seven digits of an entry's trace number
+func entrySequenceNumber(ed *ach.EntryDetail) int {
+	n, _ := strconv.Atoi(ed.TraceNumberField()[8:])
+	return n
+}
+
+// fixServiceClassCodes sets the batch header from the entries when it doesn't allow them
+// and copies it onto the batch control.
+func fixServiceClassCodes(file *ach.File, rec *recorder) {
+	for i, batch := range file.Batches {
+		bh := batch.GetHeader()
+		if bh.StandardEntryClassCode == ach.ADV {
+			continue
+		}
+
+		var credits, debits bool
+		for _, ed := range batch.GetEntries() {
+			switch ed.CreditOrDebit() {
+			case "C":
+				credits = true
+			case "D":
+				debits = true
+			}
+		}
+		code := bh.ServiceClassCode
+		switch {
+		case credits && debits:
+			code = ach.MixedDebitsAndCredits
+		case credits && code != ach.MixedDebitsAndCredits:
+			code = ach.CreditsOnly
+		case debits && code != ach.MixedDebitsAndCredits:
+			code = ach.DebitsOnly
+		}
+		rec.at(fmt.Sprintf("batch %d header", i+1)).record("serviceClassCode", strconv.Itoa(bh.ServiceClassCode), strconv.Itoa(code))
+		bh.ServiceClassCode = code
+
+		if bc := batch.GetControl(); bc != nil {
+			rec.at(fmt.Sprintf("batch %d control", i+1)).record("serviceClassCode", strconv.Itoa(bc.ServiceClassCode), strconv.Itoa(code))
+			bc.ServiceClassCode = code
+		}
+	}
+}
+
+func fixBatchNumbers(file *ach.File, rec *recorder) {
+	number := 1
+	renumber := func(location string, header, control *int) {
+		rec.at(location+" header").record("batchNumber", strconv.Itoa(*header), strconv.Itoa(number))
+		*header = number
+		if control != nil {
+			rec.at(location+" control").record("batchNumber", strconv.Itoa(*control), strconv.Itoa(number))
+			*control = number
+		}
+		number++
+	}
+	for i, batch := range file.Batches {
+		var control *int
+		if bc := batch.GetControl(); bc != nil {
+			control = &bc.BatchNumber
+		}
+		renumber(fmt.Sprintf("batch %d", i+1), &batch.GetHeader().BatchNumber, control)
+	}
+	for i := range file.IATBatches {
+		var control *int
+		if bc := file.IATBatches[i].GetControl(); bc != nil {
+			control = &bc.BatchNumber
+		}
+		renumber(fmt.Sprintf("iat batch %d", i+1), &file.IATBatches[i].GetHeader().BatchNumber, control)
+	}
+}
+
+// fixTraceNumbers renumbers entries in ascending order across the file from each batch's ODFI.
+// Trace numbers in addenda records are updated to match.
+func fixTraceNumbers(file *ach.File, rec *recorder) {
+	seq := 1
+	eachEntry(file, rec, func(bh *ach.BatchHeader, ed *ach.EntryDetail, rec *recorder) {
+		old := ed.TraceNumber
+		ed.SetTraceNumber(bh.ODFIIdentification, seq)
+		rec.record("traceNumber", old, ed.TraceNumber)
+		seq++
+
+		if ed.Addenda02 != nil {
+			ed.Addenda02.TraceNumber = ed.TraceNumber
+		}
+		for _, a := range ed.Addenda05 {
+			a.EntryDetailSequenceNumber = entrySequenceNumber(ed)
+		}
+		if ed.Addenda98 != nil {
+			ed.Addenda98.TraceNumber = ed.TraceNumber
+		}
+		if ed.Addenda98Refused != nil {
+			ed.Addenda98Refused.TraceNumber = ed.TraceNumber
+		}
+		if ed.Addenda99 != nil {
+			ed.Addenda99.TraceNumber = ed.TraceNumber
+		}
+		if ed.Addenda99Dishonored != nil {
+			ed.Addenda99Dishonored.TraceNumber = ed.TraceNumber
+		}
+		if ed.Addenda99Contested != nil {
+			ed.Addenda99Contested.TraceNumber = ed.TraceNumber
+		}
+	})
+}
+
+// fixControls recomputes the counts, entry hashes and totals of each batch control and the file control
+func fixControls(file *ach.File, rec *recorder) {
+	if file.IsADV() {
+		return
+	}
+
+	var fc ach.FileControl
+	records := 2 // file header and control
+	for i, batch := range file.Batches {
+		bc := batch.GetControl()
+		if bc == nil {
+			continue
+		}
+		bh := batch.GetHeader()
+
+		entryAddendaCount, entryHash, debits, credits := 0, 0, 0, 0
+		for _, ed := range batch.GetEntries() {
+			entryAddendaCount += 1 + addendaCount(ed)
+
+			rdfi, _ := strconv.Atoi(ed.RDFIIdentificationField())
+			entryHash += rdfi
+
+			switch ed.CreditOrDebit() {
+			case "C":
+				credits += ed.Amount
+			case "D":
+				debits += ed.Amount
+			}
+		}
+		entryHash = leastSignificantDigits(entryHash, 10)
+
+		rec := rec.at(fmt.Sprintf("batch %d control", i+1))
+		rec.record("entryAddendaCount", strconv.Itoa(bc.EntryAddendaCount), strconv.Itoa(entryAddendaCount))
+		rec.record("entryHash", strconv.Itoa(bc.EntryHash), strconv.Itoa(entryHash))
+		rec.record("totalDebit", strconv.Itoa(bc.TotalDebitEntryDollarAmount), strconv.Itoa(debits))
+		rec.record("totalCredit", strconv.Itoa(bc.TotalCreditEntryDollarAmount), strconv.Itoa(credits))
+		rec.record("serviceClassCode", strconv.Itoa(bc.ServiceClassCode), strconv.Itoa(bh.ServiceClassCode))
+		rec.record("companyIdentification", bc.CompanyIdentification, bh.CompanyIdentification)
+		rec.record("ODFIIdentification", bc.ODFIIdentification, bh.ODFIIdentification)
+		rec.record("batchNumber", strconv.Itoa(bc.BatchNumber), strconv.Itoa(bh.BatchNumber))
+
+		bc.EntryAddendaCount = entryAddendaCount
+		bc.EntryHash = entryHash
+		bc.TotalDebitEntryDollarAmount = debits
+		bc.TotalCreditEntryDollarAmount = credits
+		bc.ServiceClassCode = bh.ServiceClassCode
+		bc.CompanyIdentification = bh.CompanyIdentification
+		bc.ODFIIdentification = bh.ODFIIdentification
+		bc.BatchNumber = bh.BatchNumber
+
+		addBatch(&fc, &records, bc)
+	}
+	for i := range file.IATBatches {
+		addBatch(&fc, &records, file.IATBatches[i].GetControl())
+	}
+
+	fc.BatchCount = len(file.Batches) + len(file.IATBatches)
+	fc.BlockCount = (records + 9) / 10
+	fc.EntryHash = leastSignificantDigits(fc.EntryHash, 10)
+
+	old := &file.Control
+	rec = rec.at("file control")
+	rec.record("batchCount", strconv.Itoa(old.BatchCount), strconv.Itoa(fc.BatchCount))
+	rec.record("blockCount", strconv.Itoa(old.BlockCount), strconv.Itoa(fc.BlockCount))
+	rec.record("entryAddendaCount", strconv.Itoa(old.EntryAddendaCount), strconv.Itoa(fc.EntryAddendaCount))
+	rec.record("entryHash", strconv.Itoa(old.EntryHash), strconv.Itoa(fc.EntryHash))
+	rec.record("totalDebit", strconv.Itoa(old.TotalDebitEntryDollarAmountInFile), strconv.Itoa(fc.TotalDebitEntryDollarAmountInFile))
+	rec.record("totalCredit", strconv.Itoa(old.TotalCreditEntryDollarAmountInFile), strconv.Itoa(fc.TotalCreditEntryDollarAmountInFile))
+
+	old.BatchCount = fc.BatchCount
+	old.BlockCount = fc.BlockCount
+	old.EntryAddendaCount = fc.EntryAddendaCount
+	old.EntryHash = fc.EntryHash
+	old.TotalDebitEntryDollarAmountInFile = fc.TotalDebitEntryDollarAmountInFile
+	old.TotalCreditEntryDollarAmountInFile = fc.TotalCreditEntryDollarAmountInFile
+}
+
+func addBatch(fc *ach.FileControl, records *int, bc *ach.BatchControl) {
+	if bc == nil {
+		return
+	}
+	*records += 2 + bc.EntryAddendaCount
+	fc.EntryAddendaCount += bc.EntryAddendaCount
+	fc.EntryHash += bc.EntryHash
+	fc.TotalDebitEntryDollarAmountInFile += bc.TotalDebitEntryDollarAmount
+	fc.TotalCreditEntryDollarAmountInFile += bc.TotalCreditEntryDollarAmount
+}
+
+func leastSignificantDigits(n, digits int) int {
+	s := strconv.Itoa(n)
+	if len(s) <= digits {
+		return n
 	}
+	n, _ = strconv.Atoi(s[len(s)-digits:])
+	return n
 }
@@ -0,0 +1,67 @@
+package fix
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Change is a single field modified by a fixer
+type Change struct {
+	Fixer string
+
+	// Record is where the change was made, such as "line 3" or "batch 1 entry 2"
+	Record string
+
+	Field string
+	Old   string
+	New   string
+}
+
+type report struct {
+	changes []Change
+}
+
+func (r *report) fixer(name string) *recorder {
+	return &recorder{report: r, fixer: name}
+}
+
+type recorder struct {
+	report *report
+	fixer  string
+	where  string
+}
+
+// at returns a recorder for changes to another record
+func (r *recorder) at(where string) *recorder {
+	return &recorder{report: r.report, fixer: r.fixer, where: where}
+}
+
+// record saves a change when the values differ
+func (r *recorder) record(field, old, new string) {
+	if old == new {
+		return
+	}
+	r.report.changes = append(r.report.changes, Change{
+		Fixer:  r.fixer,
+		Record: r.where,
+		Field:  field,
+		Old:    old,
+		New:    new,
+	})
+}
+
+// WriteReport prints each change as a table
+func WriteReport(w io.Writer, changes []Change) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "No changes")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Fixer\tRecord\tField\tOld\tNew")
+	for _, c := range changes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%q\t%q\n", c.Fixer, c.Record, c.Field, c.Old, c.New)
+	}
+	return tw.Flush()
+}
@@ -18,14 +18,31 @@ var (
 )
 
 func Filepath(path string, validateOptsPath *string, skipAll *bool) (*ach.File, Format, error) {
-	validateOpts, err := readValidationOpts(validateOptsPath, skipAll)
+	validateOpts, err := ValidationOpts(validateOptsPath, skipAll)
 	if err != nil {
 		return nil, FormatUnknown, err
 	}
 	return readFile(path, validateOpts)
 }
 
-func readValidationOpts(path *string, skipAll *bool) (*ach.ValidateOpts, error) {
+// Bytes parses the contents of a Nacha or JSON file
+func Bytes(bs []byte, validateOpts *ach.ValidateOpts) (*ach.File, Format, error) {
+	if DetectFormat(bs) == FormatJSON {
+		return readJsonFile(bs, validateOpts)
+	}
+	return readACHFile(bs, validateOpts)
+}
+
+// DetectFormat returns FormatJSON for valid JSON and FormatNacha otherwise
+func DetectFormat(bs []byte) Format {
+	if json.Valid(bs) {
+		return FormatJSON
+	}
+	return FormatNacha
+}
+
+// ValidationOpts reads the ValidateOpts from a JSON file at path, unless skipAll is set.
+func ValidationOpts(path *string, skipAll *bool) (*ach.ValidateOpts, error) {
 	var opts ach.ValidateOpts
 
 	if skipAll != nil && *skipAll {
@@ -54,10 +71,7 @@ func readFile(path string, validateOpts *ach.ValidateOpts) (*ach.File, Format, e
 	if err != nil {
 		return nil, FormatUnknown, err
 	}
-	if json.Valid(bs) {
-		return readJsonFile(bs, validateOpts)
-	}
-	return readACHFile(bs, validateOpts)
+	return Bytes(bs, validateOpts)
 }
 
 func readACHFile(input []byte, validateOpts *ach.ValidateOpts) (*ach.File, Format, error) {
@@ -6,6 +6,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -13,22 +14,45 @@ import (
 
 	"github.com/moov-io/ach"
 	"github.com/moov-io/ach/cmd/achcli/fix"
+	"github.com/moov-io/ach/fedach"
+	"github.com/moov-io/ach/ofac"
 )
 
 var (
 	flagVerbose = flag.Bool("v", false, "Print verbose details about each ACH file")
 	flagVersion = flag.Bool("version", false, "Print moov-io/ach cli version")
 
+	flagBrowse   = flag.Bool("browse", false, "Interactively browse the batches, entries and errors of a file")
+	flagCreate   = flag.Bool("create", false, "Create a Nacha file from a YAML or JSON payments spec")
 	flagDiff     = flag.Bool("diff", false, "Compare two files against each other")
 	flagFlatten  = flag.Bool("flatten", false, "Flatten batches in each file")
 	flagMerge    = flag.Bool("merge", false, "Merge files before describing")
 	flagReformat = flag.String("reformat", "", "Reformat an incoming ACH file to another format")
 
+	flagDiffFormat = flag.String("diff.format", "text", "Output format of -diff (options: text, json)")
+
+	flagDuplicates       = flag.Bool("duplicates", false, "Check the first file for duplicate entries or contents of the other files")
+	flagDuplicatesWindow = flag.Duration("duplicates.window", 0, "How far apart EffectiveEntryDates of duplicate entries can be")
+	flagDuplicatesUnmask = flag.Bool("duplicates.unmask", false, "Print full account numbers in -duplicates output")
+
+	flagOFAC      = flag.String("ofac", "", "Screen the parties of entries against comma separated OFAC SDN or consolidated list files (CSV or XML)")
+	flagOFACScore = flag.Float64("ofac.score", ofac.DefaultMinScore, "Lowest name similarity, from 0 to 1, reported as an OFAC hit")
+
+	flagQuery       = flag.String("query", "", "Print entries of files or directories matching an expression")
+	flagQueryFormat = flag.String("query.format", "table", "Output format of -query (options: table, json, csv)")
+
+	flagReport        = flag.Bool("report", false, "Print summary statistics of the entries in files or directories")
+	flagReportFormat  = flag.String("report.format", "text", "Output format of -report (options: text, json, csv)")
+	flagReportLargest = flag.Int("report.largest", 10, "How many of the largest entries -report lists")
+
 	flagMask              = flag.Bool("mask", false, "Mask/hide full account numbers and individual names")
 	flagMaskAccounts      = flag.Bool("mask.accounts", false, "Mask/hide full account numbers")
 	flagMaskCorrectedData = flag.Bool("mask.corrections", false, "Mask/Hide Corrected Data in Addenda98 records")
 	flagMaskNames         = flag.Bool("mask.names", false, "Mask/hide full individual names")
 
+	flagEnrich = flag.Bool("enrich", false, "Include the reason and rules of returns and the decoded corrected data of NOCs")
+	flagFedACH = flag.String("fedach", "", "Path to a FedACH participant directory (FedACHdir.txt) used to show the bank name of each RDFI")
+
 	flagPretty        = flag.Bool("pretty", false, "Display all values in their human readable format")
 	flagPrettyAmounts = flag.Bool("pretty.amounts", false, "Display human readable amounts instead of exact values")
 
@@ -37,6 +61,8 @@ var (
 
 	// Fix commands
 	flagFix       = flag.Bool("fix", false, "Trigger fix tasks")
+	flagFixers    = flag.String("fixers", "", fmt.Sprintf("Comma separated repairs for -fix to apply (options: all, %s)", strings.Join(fix.Fixers(), ", ")))
+	flagDryRun    = flag.Bool("dry-run", false, "Report the changes -fix would make without writing a file")
 	flagUpdateEED = flag.String("update-eed", "", "Set the EffectiveEntryDate to a new value")
 )
 
@@ -56,9 +82,18 @@ func main() {
 
 	// error conditions, verify we're okay for whatever the task at hand is
 	switch {
+	case *flagBrowse && len(args) != 1:
+		fmt.Printf("with -browse exactly one file is expected, found %d files\n", len(args))
+		os.Exit(1)
+	case *flagCreate && len(args) != 1:
+		fmt.Printf("with -create exactly one spec is expected, found %d files\n", len(args))
+		os.Exit(1)
 	case *flagDiff && len(args) != 2:
 		fmt.Printf("with -diff exactly two files are expected, found %d files\n", len(args))
 		os.Exit(1)
+	case *flagDuplicates && len(args) < 2:
+		fmt.Printf("with -duplicates at least two files are expected, found %d files\n", len(args))
+		os.Exit(1)
 	}
 
 	// minor debugging
@@ -75,10 +110,59 @@ func main() {
 	// Read validation options from the command
 	validateOpts := readValidationOpts(*flagValidateOpts)
 
+	if *flagFedACH != "" {
+		dir, err := fedach.ReadFile(*flagFedACH)
+		if err != nil {
+			fmt.Printf("ERROR: reading FedACH directory failed: %v\n", err)
+			os.Exit(1)
+		}
+		fedachDirectory = dir
+	}
+
 	// pick our command to do
 	switch {
+	case *flagBrowse:
+		if err := browseFile(args[0], validateOpts); err != nil {
+			fmt.Printf("ERROR: %v\n", err)
+			os.Exit(1)
+		}
+
+	case *flagCreate:
+		if err := createFile(args[0], *flagReformat); err != nil {
+			fmt.Printf("ERROR: %v\n", err)
+			os.Exit(1)
+		}
+
 	case *flagDiff:
-		if err := diffFiles(args, validateOpts); err != nil {
+		if err := diffFiles(args, *flagDiffFormat, validateOpts); err != nil {
+			fmt.Printf("ERROR: %v\n", err)
+			os.Exit(1)
+		}
+
+	case *flagDuplicates:
+		if err := checkDuplicates(args, *flagDuplicatesWindow, !*flagDuplicatesUnmask, validateOpts); err != nil {
+			if !errors.Is(err, errDuplicatesFound) {
+				fmt.Printf("ERROR: %v\n", err)
+			}
+			os.Exit(1)
+		}
+
+	case *flagOFAC != "":
+		if err := screenFiles(args, *flagOFAC, *flagOFACScore, validateOpts); err != nil {
+			if !errors.Is(err, errOFACHitsFound) {
+				fmt.Printf("ERROR: %v\n", err)
+			}
+			os.Exit(1)
+		}
+
+	case *flagQuery != "":
+		if err := queryFiles(args, *flagQuery, *flagQueryFormat, validateOpts); err != nil {
+			fmt.Printf("ERROR: %v\n", err)
+			os.Exit(1)
+		}
+
+	case *flagReport:
+		if err := reportFiles(args, *flagReportFormat, *flagReportLargest, validateOpts); err != nil {
 			fmt.Printf("ERROR: %v\n", err)
 			os.Exit(1)
 		}
@@ -90,13 +174,24 @@ func main() {
 		}
 		conf := fix.Config{
 			UpdateEED: *flagUpdateEED,
+			Fixers:    strings.Split(*flagFixers, ","),
+			DryRun:    *flagDryRun,
 		}
-		newpath, err := fix.Perform(args[0], flagValidateOpts, flagSkipValidation, conf)
+		result, err := fix.Perform(args[0], flagValidateOpts, flagSkipValidation, conf)
 		if err != nil {
 			fmt.Printf("ERROR: %v\n", err)
 			os.Exit(1)
 		}
-		fmt.Printf("Fixed file: %s\n", newpath)
+		if err := fix.WriteReport(os.Stdout, result.Changes); err != nil {
+			fmt.Printf("ERROR: %v\n", err)
+			os.Exit(1)
+		}
+		if result.Invalid != nil {
+			fmt.Printf("WARNING: fixed file is still invalid: %v\n", result.Invalid)
+		}
+		if result.Path != "" {
+			fmt.Printf("Fixed file: %s\n", result.Path)
+		}
 
 	case *flagReformat != "" && len(args) == 1:
 		if err := reformat(*flagReformat, args[0], validateOpts); err != nil {
@@ -0,0 +1,63 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/ofac"
+)
+
+var errOFACHitsFound = errors.New("OFAC hits found")
+
+// screenFiles screens the entries of each file against the comma separated OFAC list files
+func screenFiles(paths []string, lists string, minScore float64, validateOpts *ach.ValidateOpts) error {
+	list, err := ofac.ReadFiles(strings.Split(lists, ",")...)
+	if err != nil {
+		return fmt.Errorf("problem reading OFAC list: %v", err)
+	}
+	screener := ofac.NewScreener(list, ofac.Options{
+		MinScore: minScore,
+	})
+
+	var found bool
+	for _, path := range paths {
+		file, err := readIncomingFile(path, validateOpts)
+		if err != nil {
+			return fmt.Errorf("problem reading %s: %v", path, err)
+		}
+		hits := screener.ScreenFile(file)
+		printOFACHits(os.Stdout, path, hits)
+		found = found || len(hits) > 0
+	}
+	if found {
+		return errOFACHitsFound
+	}
+	return nil
+}
+
+func printOFACHits(ww io.Writer, path string, hits []ofac.Hit) {
+	w := tabwriter.NewWriter(ww, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintf(w, "Screened %s\n", path)
+	if len(hits) == 0 {
+		fmt.Fprintln(w, "  No OFAC hits found")
+		return
+	}
+
+	fmt.Fprintln(w, "\n  BatchNumber\tTraceNumber\tParty\tName\tScore\tEntityID\tMatchedName\tType\tPrograms")
+	for _, hit := range hits {
+		fmt.Fprintf(w, "  %d\t%s\t%s\t%s\t%.3f\t%s\t%s\t%s\t%s\n",
+			hit.BatchNumber, hit.TraceNumber, hit.Party, hit.Name, hit.Score,
+			hit.Entity.ID, hit.MatchedName, hit.Entity.Type, strings.Join(hit.Entity.Programs, " "))
+	}
+}
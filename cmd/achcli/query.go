@@ -0,0 +1,24 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/cmd/achcli/query"
+)
+
+func queryFiles(paths []string, expr, format string, validateOpts *ach.ValidateOpts) error {
+	q, err := query.Parse(expr)
+	if err != nil {
+		return err
+	}
+	out, err := query.NewWriter(os.Stdout, format)
+	if err != nil {
+		return err
+	}
+	return query.Run(paths, q, out, validateOpts)
+}
@@ -0,0 +1,316 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package query
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/moov-io/ach"
+)
+
+// Record is an entry along with the file and batch it was read from
+//
+// IAT entries are queried through the fields they share with domestic entries. Their BatchHeader
+// and Entry are filled from IATBatchHeader and IATEntry, with the originator identification as the
+// company identification and the receiver's name from the Addenda10 as the individual name.
+type Record struct {
+	Path        string
+	FileHeader  *ach.FileHeader
+	BatchHeader *ach.BatchHeader
+	Entry       *ach.EntryDetail
+
+	IATBatchHeader *ach.IATBatchHeader
+	IATEntry       *ach.IATEntryDetail
+}
+
+// newIATRecord returns a Record for an IAT entry
+func newIATRecord(path string, fh *ach.FileHeader, iatBH *ach.IATBatchHeader, iatED *ach.IATEntryDetail) *Record {
+	bh := ach.NewBatchHeader()
+	bh.ServiceClassCode = iatBH.ServiceClassCode
+	bh.CompanyIdentification = iatBH.OriginatorIdentification
+	bh.StandardEntryClassCode = iatBH.StandardEntryClassCode
+	bh.CompanyEntryDescription = iatBH.CompanyEntryDescription
+	bh.EffectiveEntryDate = iatBH.EffectiveEntryDate
+	bh.SettlementDate = iatBH.SettlementDate
+	bh.OriginatorStatusCode = iatBH.OriginatorStatusCode
+	bh.ODFIIdentification = iatBH.ODFIIdentification
+	bh.BatchNumber = iatBH.BatchNumber
+
+	ed := ach.NewEntryDetail()
+	ed.TransactionCode = iatED.TransactionCode
+	ed.RDFIIdentification = iatED.RDFIIdentification
+	ed.CheckDigit = iatED.CheckDigit
+	ed.DFIAccountNumber = iatED.DFIAccountNumber
+	ed.Amount = iatED.Amount
+	ed.AddendaRecordIndicator = iatED.AddendaRecordIndicator
+	ed.TraceNumber = iatED.TraceNumber
+	ed.Category = iatED.Category
+	ed.Addenda98 = iatED.Addenda98
+	ed.Addenda99 = iatED.Addenda99
+	if iatED.Addenda10 != nil {
+		ed.IndividualName = iatED.Addenda10.Name
+	}
+
+	return &Record{
+		Path:           path,
+		FileHeader:     fh,
+		BatchHeader:    bh,
+		Entry:          ed,
+		IATBatchHeader: iatBH,
+		IATEntry:       iatED,
+	}
+}
+
+type kind int
+
+const (
+	kindString kind = iota
+	kindNumber
+	kindBool
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindNumber:
+		return "number"
+	case kindBool:
+		return "bool"
+	}
+	return "string"
+}
+
+type value struct {
+	s string
+	n int64
+	b bool
+}
+
+type field struct {
+	kind kind
+	get  func(r *Record) value
+}
+
+func stringField(get func(r *Record) string) field {
+	return field{
+		kind: kindString,
+		get: func(r *Record) value {
+			return value{s: strings.TrimSpace(get(r))}
+		},
+	}
+}
+
+func numberField(get func(r *Record) int) field {
+	return field{
+		kind: kindNumber,
+		get: func(r *Record) value {
+			return value{n: int64(get(r))}
+		},
+	}
+}
+
+func boolField(get func(r *Record) bool) field {
+	return field{
+		kind: kindBool,
+		get: func(r *Record) value {
+			return value{b: get(r)}
+		},
+	}
+}
+
+// fields are the values which can be queried, named after their JSON representation
+var fields = map[string]field{
+	"path": stringField(func(r *Record) string { return r.Path }),
+
+	// FileHeader
+	"immediateOrigin":          stringField(func(r *Record) string { return r.FileHeader.ImmediateOrigin }),
+	"immediateOriginName":      stringField(func(r *Record) string { return r.FileHeader.ImmediateOriginName }),
+	"immediateDestination":     stringField(func(r *Record) string { return r.FileHeader.ImmediateDestination }),
+	"immediateDestinationName": stringField(func(r *Record) string { return r.FileHeader.ImmediateDestinationName }),
+	"fileCreationDate":         stringField(func(r *Record) string { return r.FileHeader.FileCreationDate }),
+	"fileCreationTime":         stringField(func(r *Record) string { return r.FileHeader.FileCreationTime }),
+	"fileIDModifier":           stringField(func(r *Record) string { return r.FileHeader.FileIDModifier }),
+
+	// BatchHeader
+	"serviceClassCode":         numberField(func(r *Record) int { return r.BatchHeader.ServiceClassCode }),
+	"companyName":              stringField(func(r *Record) string { return r.BatchHeader.CompanyName }),
+	"companyDiscretionaryData": stringField(func(r *Record) string { return r.BatchHeader.CompanyDiscretionaryData }),
+	"companyIdentification":    stringField(func(r *Record) string { return r.BatchHeader.CompanyIdentification }),
+	"secCode":                  stringField(func(r *Record) string { return r.BatchHeader.StandardEntryClassCode }),
+	"companyEntryDescription":  stringField(func(r *Record) string { return r.BatchHeader.CompanyEntryDescription }),
+	"companyDescriptiveDate":   stringField(func(r *Record) string { return r.BatchHeader.CompanyDescriptiveDate }),
+	"effectiveEntryDate":       stringField(func(r *Record) string { return r.BatchHeader.EffectiveEntryDate }),
+	"settlementDate":           stringField(func(r *Record) string { return r.BatchHeader.SettlementDate }),
+	"originatorStatusCode":     numberField(func(r *Record) int { return r.BatchHeader.OriginatorStatusCode }),
+	"odfiIdentification":       stringField(func(r *Record) string { return r.BatchHeader.ODFIIdentification }),
+	"batchNumber":              numberField(func(r *Record) int { return r.BatchHeader.BatchNumber }),
+
+	// EntryDetail
+	"transactionCode":        numberField(func(r *Record) int { return r.Entry.TransactionCode }),
+	"rdfiIdentification":     stringField(func(r *Record) string { return r.Entry.RDFIIdentification }),
+	"checkDigit":             stringField(func(r *Record) string { return r.Entry.CheckDigit }),
+	"accountNumber":          stringField(func(r *Record) string { return r.Entry.DFIAccountNumber }),
+	"amount":                 numberField(func(r *Record) int { return r.Entry.Amount }),
+	"identificationNumber":   stringField(func(r *Record) string { return r.Entry.IdentificationNumber }),
+	"individualName":         stringField(func(r *Record) string { return r.Entry.IndividualName }),
+	"discretionaryData":      stringField(func(r *Record) string { return r.Entry.DiscretionaryData }),
+	"addendaRecordIndicator": numberField(func(r *Record) int { return r.Entry.AddendaRecordIndicator }),
+	"traceNumber":            stringField(func(r *Record) string { return r.Entry.TraceNumber }),
+	"category":               stringField(func(r *Record) string { return r.Entry.Category }),
+	"credit":                 boolField(func(r *Record) bool { return r.Entry.CreditOrDebit() == "C" }),
+	"debit":                  boolField(func(r *Record) bool { return r.Entry.CreditOrDebit() == "D" }),
+	"prenote":                boolField(func(r *Record) bool { return isPrenote(r.Entry.TransactionCode) }),
+
+	// Addenda
+	"addendaCount":              numberField(addendaCount),
+	"paymentRelatedInformation": stringField(paymentRelatedInformation),
+	"returnCode":                stringField(returnCode),
+	"changeCode":                stringField(changeCode),
+	"correctedData":             stringField(correctedData),
+	"originalTrace":             stringField(originalTrace),
+	"originalDFI":               stringField(originalDFI),
+	"dateOfDeath": stringField(func(r *Record) string {
+		if r.Entry.Addenda99 != nil {
+			return r.Entry.Addenda99.DateOfDeath
+		}
+		return ""
+	}),
+}
+
+// Fields returns the names of every field which can be queried
+func Fields() []string {
+	out := make([]string, 0, len(fields))
+	for name := range fields {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func isPrenote(code int) bool {
+	switch code {
+	case ach.CheckingPrenoteCredit, ach.CheckingPrenoteDebit,
+		ach.SavingsPrenoteCredit, ach.SavingsPrenoteDebit,
+		ach.GLPrenoteCredit, ach.GLPrenoteDebit,
+		ach.LoanPrenoteCredit:
+		return true
+	}
+	return false
+}
+
+func addendaCount(r *Record) int {
+	if r.IATEntry != nil {
+		return r.IATEntry.AddendaRecords
+	}
+	ed := r.Entry
+	count := len(ed.Addenda05)
+	if ed.Addenda02 != nil {
+		count++
+	}
+	if ed.Addenda98 != nil {
+		count++
+	}
+	if ed.Addenda98Refused != nil {
+		count++
+	}
+	if ed.Addenda99 != nil {
+		count++
+	}
+	if ed.Addenda99Dishonored != nil {
+		count++
+	}
+	if ed.Addenda99Contested != nil {
+		count++
+	}
+	return count
+}
+
+func paymentRelatedInformation(r *Record) string {
+	var out []string
+	for _, a := range r.Entry.Addenda05 {
+		if a != nil {
+			out = append(out, strings.TrimSpace(a.PaymentRelatedInformation))
+		}
+	}
+	if r.IATEntry != nil {
+		for _, a := range r.IATEntry.Addenda17 {
+			if a != nil {
+				out = append(out, strings.TrimSpace(a.PaymentRelatedInformation))
+			}
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// returnCode returns the return reason code of a return, dishonored or contested return entry
+func returnCode(r *Record) string {
+	ed := r.Entry
+	switch {
+	case ed.Addenda99 != nil:
+		return ed.Addenda99.ReturnCode
+	case ed.Addenda99Dishonored != nil:
+		return ed.Addenda99Dishonored.DishonoredReturnReasonCode
+	case ed.Addenda99Contested != nil:
+		return ed.Addenda99Contested.ContestedReturnCode
+	}
+	return ""
+}
+
+// changeCode returns the change code of a notification of change or refused notification of change entry
+func changeCode(r *Record) string {
+	ed := r.Entry
+	switch {
+	case ed.Addenda98 != nil:
+		return ed.Addenda98.ChangeCode
+	case ed.Addenda98Refused != nil:
+		return ed.Addenda98Refused.RefusedChangeCode
+	}
+	return ""
+}
+
+func correctedData(r *Record) string {
+	ed := r.Entry
+	switch {
+	case ed.Addenda98 != nil:
+		return ed.Addenda98.CorrectedData
+	case ed.Addenda98Refused != nil:
+		return ed.Addenda98Refused.CorrectedData
+	}
+	return ""
+}
+
+func originalTrace(r *Record) string {
+	ed := r.Entry
+	switch {
+	case ed.Addenda98 != nil:
+		return ed.Addenda98.OriginalTrace
+	case ed.Addenda98Refused != nil:
+		return ed.Addenda98Refused.OriginalTrace
+	case ed.Addenda99 != nil:
+		return ed.Addenda99.OriginalTrace
+	case ed.Addenda99Dishonored != nil:
+		return ed.Addenda99Dishonored.OriginalEntryTraceNumber
+	case ed.Addenda99Contested != nil:
+		return ed.Addenda99Contested.OriginalEntryTraceNumber
+	}
+	return ""
+}
+
+func originalDFI(r *Record) string {
+	ed := r.Entry
+	switch {
+	case ed.Addenda98 != nil:
+		return ed.Addenda98.OriginalDFI
+	case ed.Addenda98Refused != nil:
+		return ed.Addenda98Refused.OriginalDFI
+	case ed.Addenda99 != nil:
+		return ed.Addenda99.OriginalDFI
+	case ed.Addenda99Dishonored != nil:
+		return ed.Addenda99Dishonored.OriginalReceivingDFIIdentification
+	case ed.Addenda99Contested != nil:
+		return ed.Addenda99Contested.OriginalReceivingDFIIdentification
+	}
+	return ""
+}
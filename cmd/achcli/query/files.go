@@ -0,0 +1,184 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package query
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/base"
+)
+
+// Run streams the entries of each file or directory in paths and writes those matching q to out.
+func Run(paths []string, q *Query, out Writer, validateOpts *ach.ValidateOpts) error {
+	err := Each(paths, validateOpts, func(r *Record) error {
+		if q.Match(r) {
+			return out.Write(r)
+		}
+		return nil
+	})
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Each calls fn with every entry found in paths. Directories are read like ach.ReadDir, where
+// every file directly inside the directory is included.
+//
+// Nacha formatted files are read one entry at a time so they are never fully held in memory.
+// Files which can't be read are skipped and their errors returned
+// once every path is read, but an error from fn stops reading immediately.
+func Each(paths []string, validateOpts *ach.ValidateOpts, fn func(r *Record) error) error {
+	var stop error
+	read := func(r *Record) error {
+		stop = fn(r)
+		return stop
+	}
+
+	var errs base.ErrorList
+	each := func(path string) error {
+		err := eachEntry(path, validateOpts, read)
+		if stop != nil {
+			return stop
+		}
+		if err != nil {
+			errs.Add(err)
+		}
+		return nil
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		if !info.IsDir() {
+			if err := each(path); err != nil {
+				return err
+			}
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		for i := range entries {
+			if entries[i].IsDir() {
+				continue
+			}
+			if err := each(filepath.Join(path, entries[i].Name())); err != nil {
+				return err
+			}
+		}
+	}
+	if errs.Empty() {
+		return nil
+	}
+	return errs
+}
+
+func eachEntry(path string, validateOpts *ach.ValidateOpts, fn func(r *Record) error) error {
+	fd, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	r := bufio.NewReader(fd)
+	if isJSON(r) {
+		return eachJSONEntry(path, r, validateOpts, fn)
+	}
+
+	return eachNachaEntry(path, r, validateOpts, fn)
+}
+
+// eachNachaEntry reads a Nacha formatted file one entry at a time with an ach.Iterator
+func eachNachaEntry(path string, r io.Reader, validateOpts *ach.ValidateOpts, fn func(r *Record) error) error {
+	iter := ach.NewIterator(r)
+	iter.SetValidation(validateOpts)
+
+	for {
+		entry, err := iter.Next()
+		if err != nil {
+			return fmt.Errorf("reading %s failed: %w", path, err)
+		}
+		if entry == nil {
+			return nil
+		}
+
+		header := *iter.GetHeader()
+		record := &Record{
+			Path:        path,
+			FileHeader:  &header,
+			BatchHeader: entry.BatchHeader,
+			Entry:       entry.Entry,
+		}
+		if entry.IATEntry != nil {
+			record = newIATRecord(path, &header, entry.IATBatchHeader, entry.IATEntry)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+// isJSON peeks at the first non-whitespace character of r
+func isJSON(r *bufio.Reader) bool {
+	for n := 1; ; n++ {
+		bs, err := r.Peek(n)
+		if len(bs) < n || err != nil {
+			return false
+		}
+		switch bs[n-1] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '{':
+			return true
+		}
+		return false
+	}
+}
+
+// eachJSONEntry reads a JSON file entirely as it can't be streamed
+func eachJSONEntry(path string, r io.Reader, validateOpts *ach.ValidateOpts, fn func(r *Record) error) error {
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		return err
+	}
+	file, err := ach.FileFromJSONWith(buf.Bytes(), validateOpts)
+	if err != nil {
+		return fmt.Errorf("reading %s failed: %w", path, err)
+	}
+	for _, batch := range file.Batches {
+		for _, ed := range batch.GetEntries() {
+			record := &Record{
+				Path:        path,
+				FileHeader:  &file.Header,
+				BatchHeader: batch.GetHeader(),
+				Entry:       ed,
+			}
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+	}
+	for _, batch := range file.IATBatches {
+		for _, ed := range batch.Entries {
+			if err := fn(newIATRecord(path, &file.Header, batch.Header, ed)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package query
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testdata(name string) string {
+	return filepath.Join("..", "..", "..", "test", "testdata", name)
+}
+
+func copyTestdata(t *testing.T, dir string, names ...string) {
+	t.Helper()
+
+	for _, name := range names {
+		bs, err := os.ReadFile(testdata(name))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), bs, 0600))
+	}
+}
+
+func TestEach(t *testing.T) {
+	dir := t.TempDir()
+	copyTestdata(t, dir, "ppd-mixedDebitCredit.ach", "web-debit.ach", "ppd-valid.json")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0700))
+
+	var traceNumbers []string
+	err := Each([]string{dir, testdata("return-WEB.ach")}, nil, func(r *Record) error {
+		require.NotNil(t, r.FileHeader)
+		require.NotNil(t, r.BatchHeader)
+		require.NotEmpty(t, r.FileHeader.ImmediateOrigin)
+		traceNumbers = append(traceNumbers, r.Entry.TraceNumber)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, traceNumbers, 3+6+1+2)
+
+	t.Run("stop early", func(t *testing.T) {
+		stop := errors.New("stop")
+		var count int
+		err := Each([]string{dir}, nil, func(r *Record) error {
+			count++
+			return stop
+		})
+		require.ErrorIs(t, err, stop)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("unreadable files", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not an ACH file"), 0600))
+
+		var count int
+		err := Each([]string{dir, filepath.Join(dir, "missing.ach")}, nil, func(r *Record) error {
+			count++
+			return nil
+		})
+		require.ErrorContains(t, err, "notes.txt")
+		require.ErrorContains(t, err, "missing.ach")
+		require.Equal(t, 3+6+1, count)
+	})
+}
+
+func entryLines(ed *ach.EntryDetail) string {
+	out := ed.String()
+	for _, addenda := range ed.Addenda05 {
+		out += "\n" + addenda.String()
+	}
+	return out
+}
+
+func TestEach__Entries(t *testing.T) {
+	names := []string{
+		"web-debit.ach",
+		"rck.ach",
+		"nonascii-utf8.ach", // multiple Addenda05
+		"20110805A.ach",
+	}
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			file, err := ach.ReadFile(testdata(name))
+			require.NoError(t, err)
+
+			// Every entry is found once with its addenda records
+			var expected, found []string
+			for _, batch := range file.Batches {
+				for _, ed := range batch.GetEntries() {
+					expected = append(expected, entryLines(ed))
+				}
+			}
+			for _, batch := range file.IATBatches {
+				for _, ed := range batch.Entries {
+					expected = append(expected, ed.String())
+				}
+			}
+			err = Each([]string{testdata(name)}, nil, func(r *Record) error {
+				require.Equal(t, file.Header.ImmediateOrigin, r.FileHeader.ImmediateOrigin)
+				if r.IATEntry != nil {
+					found = append(found, r.IATEntry.String())
+				} else {
+					found = append(found, entryLines(r.Entry))
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, expected, found)
+		})
+	}
+}
+
+func TestEach__IAT(t *testing.T) {
+	q, err := Parse(`secCode == "IAT" && companyIdentification == "123456789" && individualName == "BEK Enterprises"`)
+	require.NoError(t, err)
+
+	var records []*Record
+	err = Each([]string{testdata("iat-debit.ach")}, nil, func(r *Record) error {
+		if q.Match(r) {
+			records = append(records, r)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "IAT", records[0].IATBatchHeader.StandardEntryClassCode)
+	require.Equal(t, records[0].IATEntry.Amount, records[0].Entry.Amount)
+}
+
+func TestRun(t *testing.T) {
+	q, err := Parse(`(credit && amount >= 2000 && returnCode == "") || returnCode == "R01"`)
+	require.NoError(t, err)
+
+	paths := []string{testdata("web-debit.ach"), testdata("return-WEB.ach")}
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		out, err := NewWriter(&buf, "")
+		require.NoError(t, err)
+		require.NoError(t, Run(paths, q, out, nil))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 1+5)
+		require.True(t, strings.HasPrefix(lines[0], "Path"))
+		require.Contains(t, lines[5], "R01")
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		out, err := NewWriter(&buf, "csv")
+		require.NoError(t, err)
+		require.NoError(t, Run(paths, q, out, nil))
+
+		rows, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 1+5)
+		require.Equal(t, columns, rows[0])
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		out, err := NewWriter(&buf, "JSON")
+		require.NoError(t, err)
+		require.NoError(t, Run(paths, q, out, nil))
+
+		var records []jsonRecord
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &records))
+		require.Len(t, records, 5)
+		require.Equal(t, "R01", records[4].EntryDetail.Addenda99.ReturnCode)
+		require.Equal(t, "WEB", records[4].BatchHeader.StandardEntryClassCode)
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		q, err := Parse(`amount < 0`)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		out, err := NewWriter(&buf, "json")
+		require.NoError(t, err)
+		require.NoError(t, Run(paths, q, out, nil))
+		require.Equal(t, "[]\n", buf.String())
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := NewWriter(nil, "xml")
+		require.ErrorContains(t, err, `unknown query output format "xml"`)
+	})
+}
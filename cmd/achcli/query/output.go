@@ -0,0 +1,160 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package query
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/moov-io/ach"
+)
+
+// Writer outputs each matching entry
+type Writer interface {
+	Write(r *Record) error
+
+	// Close writes anything buffered and must be called once all entries are written
+	Close() error
+}
+
+// NewWriter returns a Writer for the format: table, json or csv.
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch strings.ToLower(format) {
+	case "", "table":
+		return &tableWriter{w: tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)}, nil
+	case "json":
+		return &jsonWriter{w: w}, nil
+	case "csv":
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown query output format %q (options: table, json, csv)", format)
+}
+
+var columns = []string{
+	"Path", "BatchNumber", "SECCode", "CompanyIdentification", "CompanyName", "EffectiveEntryDate",
+	"TransactionCode", "RDFIIdentification", "AccountNumber", "Amount", "IndividualName", "TraceNumber",
+	"ReturnCode", "ChangeCode",
+}
+
+func row(r *Record) []string {
+	bh, ed := r.BatchHeader, r.Entry
+	return []string{
+		r.Path,
+		strconv.Itoa(bh.BatchNumber),
+		bh.StandardEntryClassCode,
+		strings.TrimSpace(bh.CompanyIdentification),
+		strings.TrimSpace(bh.CompanyName),
+		bh.EffectiveEntryDate,
+		strconv.Itoa(ed.TransactionCode),
+		ed.RDFIIdentification + ed.CheckDigit,
+		strings.TrimSpace(ed.DFIAccountNumber),
+		strconv.Itoa(ed.Amount),
+		strings.TrimSpace(ed.IndividualName),
+		ed.TraceNumber,
+		returnCode(r),
+		changeCode(r),
+	}
+}
+
+// tableWriter aligns entries into columns, which requires buffering until Close
+type tableWriter struct {
+	w    *tabwriter.Writer
+	rows int
+}
+
+func (t *tableWriter) Write(r *Record) error {
+	if t.rows == 0 {
+		if _, err := fmt.Fprintln(t.w, strings.Join(columns, "\t")); err != nil {
+			return err
+		}
+	}
+	t.rows++
+	_, err := fmt.Fprintln(t.w, strings.Join(row(r), "\t"))
+	return err
+}
+
+func (t *tableWriter) Close() error {
+	return t.w.Flush()
+}
+
+// jsonWriter streams a JSON array of entries
+type jsonWriter struct {
+	w    io.Writer
+	rows int
+}
+
+type jsonRecord struct {
+	Path                 string           `json:"path"`
+	ImmediateOrigin      string           `json:"immediateOrigin"`
+	ImmediateDestination string           `json:"immediateDestination"`
+	BatchHeader          *ach.BatchHeader `json:"batchHeader,omitempty"`
+	EntryDetail          *ach.EntryDetail `json:"entryDetail,omitempty"`
+
+	IATBatchHeader *ach.IATBatchHeader `json:"IATBatchHeader,omitempty"`
+	IATEntryDetail *ach.IATEntryDetail `json:"IATEntryDetail,omitempty"`
+}
+
+func (j *jsonWriter) Write(r *Record) error {
+	record := jsonRecord{
+		Path:                 r.Path,
+		ImmediateOrigin:      r.FileHeader.ImmediateOrigin,
+		ImmediateDestination: r.FileHeader.ImmediateDestination,
+		BatchHeader:          r.BatchHeader,
+		EntryDetail:          r.Entry,
+	}
+	if r.IATEntry != nil {
+		// Write the IAT records rather than their domestic fields
+		record.BatchHeader, record.EntryDetail = nil, nil
+		record.IATBatchHeader, record.IATEntryDetail = r.IATBatchHeader, r.IATEntry
+	}
+	bs, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	prefix := ",\n  "
+	if j.rows == 0 {
+		prefix = "[\n  "
+	}
+	j.rows++
+	_, err = fmt.Fprintf(j.w, "%s%s", prefix, bs)
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	if j.rows == 0 {
+		_, err := fmt.Fprintln(j.w, "[]")
+		return err
+	}
+	_, err := fmt.Fprintln(j.w, "\n]")
+	return err
+}
+
+type csvWriter struct {
+	w    *csv.Writer
+	rows int
+}
+
+func (c *csvWriter) Write(r *Record) error {
+	if c.rows == 0 {
+		if err := c.w.Write(columns); err != nil {
+			return err
+		}
+	}
+	c.rows++
+	return c.w.Write(row(r))
+}
+
+func (c *csvWriter) Close() error {
+	if c.rows == 0 {
+		c.w.Write(columns)
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,333 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package query implements a small expression language for filtering the entries of ACH files.
+//
+// Expressions compare fields of the file header, batch header, entry and its addenda:
+//
+//	secCode == "PPD" && amount > 50000 && returnCode in ["R01", "R09"]
+//	rdfiIdentification == "23138010" || individualName contains "smith"
+//	!(credit) && effectiveEntryDate >= "190601"
+//
+// Strings are quoted, numbers are integers (amounts are in cents) and lists are written
+// in brackets. Comparisons are ==, !=, <, <=, >, >=, in, contains (ignoring case) and
+// startsWith. Expressions are combined with &&, || and ! along with parentheses.
+// Boolean fields (credit, debit, prenote) can be used on their own.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Query is a parsed expression which can be matched against entries
+type Query struct {
+	source string
+	match  func(r *Record) bool
+}
+
+// Parse reads an expression and checks it only compares fields with values of the same type.
+func Parse(expr string) (*Query, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	match, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %v", next)
+	}
+	return &Query{source: expr, match: match}, nil
+}
+
+// Match returns true when the entry satisfies the query
+func (q *Query) Match(r *Record) bool {
+	if q == nil || r == nil || r.Entry == nil {
+		return false
+	}
+	return q.match(r)
+}
+
+func (q *Query) String() string {
+	if q == nil {
+		return ""
+	}
+	return q.source
+}
+
+type predicate func(r *Record) bool
+
+// operand is a field or literal value of a comparison
+type operand struct {
+	kind    kind
+	get     func(r *Record) value
+	literal bool
+	name    string
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	t := p.next()
+	if t.kind != kind {
+		return fmt.Errorf("expected %q but found %v", text, t)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (predicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isOperator(p.peek(), "||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(r *Record) bool { return l(r) || right(r) }
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (predicate, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for isOperator(p.peek(), "&&") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(r *Record) bool { return l(r) && right(r) }
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (predicate, error) {
+	if isOperator(p.peek(), "!") {
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(r *Record) bool { return !inner(r) }, nil
+	}
+	if p.peek().kind == tokenLParen {
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (predicate, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.peek()
+	switch {
+	case op.kind == tokenOperator && isComparison(op.text):
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return compare(left, op, right)
+
+	case op.kind == tokenIdent && op.text == "in":
+		p.next()
+		list, err := p.parseList(left)
+		if err != nil {
+			return nil, err
+		}
+		return func(r *Record) bool {
+			v := left.get(r)
+			for i := range list {
+				if v == list[i] {
+					return true
+				}
+			}
+			return false
+		}, nil
+
+	case op.kind == tokenIdent && (op.text == "contains" || op.text == "startsWith"):
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if left.kind != kindString || right.kind != kindString {
+			return nil, fmt.Errorf("%s requires strings but found %s and %s", op.text, left.kind, right.kind)
+		}
+		if op.text == "contains" {
+			return func(r *Record) bool {
+				return strings.Contains(strings.ToLower(left.get(r).s), strings.ToLower(right.get(r).s))
+			}, nil
+		}
+		return func(r *Record) bool {
+			return strings.HasPrefix(left.get(r).s, right.get(r).s)
+		}, nil
+	}
+
+	// Boolean fields and literals can stand on their own
+	if left.kind == kindBool {
+		return func(r *Record) bool { return left.get(r).b }, nil
+	}
+	return nil, fmt.Errorf("expected a comparison after %s but found %v", left.name, op)
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		v := value{s: t.text}
+		return operand{kind: kindString, literal: true, name: strconv.Quote(t.text), get: func(*Record) value { return v }}, nil
+
+	case tokenNumber:
+		n, err := strconv.ParseInt(t.text, 10, 64)
+		if err != nil {
+			return operand{}, fmt.Errorf("invalid number %v: %w", t, err)
+		}
+		v := value{n: n}
+		return operand{kind: kindNumber, literal: true, name: t.text, get: func(*Record) value { return v }}, nil
+
+	case tokenIdent:
+		if t.text == "true" || t.text == "false" {
+			v := value{b: t.text == "true"}
+			return operand{kind: kindBool, literal: true, name: t.text, get: func(*Record) value { return v }}, nil
+		}
+		f, exists := fields[t.text]
+		if !exists {
+			return operand{}, fmt.Errorf("unknown field %v", t)
+		}
+		return operand{kind: f.kind, name: t.text, get: f.get}, nil
+	}
+	return operand{}, fmt.Errorf("expected a field or value but found %v", t)
+}
+
+func (p *parser) parseList(left operand) ([]value, error) {
+	if err := p.expect(tokenLBracket, "["); err != nil {
+		return nil, err
+	}
+	var out []value
+	for {
+		item, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if !item.literal {
+			return nil, fmt.Errorf("lists can only contain values but found %s", item.name)
+		}
+		if item.kind != left.kind {
+			return nil, fmt.Errorf("%s is a %s but the list contains %s", left.name, left.kind, item.name)
+		}
+		out = append(out, item.get(nil))
+
+		switch t := p.next(); t.kind {
+		case tokenComma:
+			continue
+		case tokenRBracket:
+			return out, nil
+		default:
+			return nil, fmt.Errorf("expected \",\" or \"]\" but found %v", t)
+		}
+	}
+}
+
+func compare(left operand, op token, right operand) (predicate, error) {
+	if left.kind != right.kind {
+		return nil, fmt.Errorf("cannot compare %s (%s) with %s (%s)", left.name, left.kind, right.name, right.kind)
+	}
+	if left.kind == kindBool && op.text != "==" && op.text != "!=" {
+		return nil, fmt.Errorf("%v cannot be used with booleans", op)
+	}
+
+	var cmp func(a, b value) int
+	switch left.kind {
+	case kindString:
+		cmp = func(a, b value) int { return strings.Compare(a.s, b.s) }
+	case kindNumber:
+		cmp = func(a, b value) int {
+			switch {
+			case a.n < b.n:
+				return -1
+			case a.n > b.n:
+				return 1
+			}
+			return 0
+		}
+	case kindBool:
+		cmp = func(a, b value) int {
+			if a.b == b.b {
+				return 0
+			}
+			return 1
+		}
+	}
+
+	var accept func(int) bool
+	switch op.text {
+	case "==":
+		accept = func(c int) bool { return c == 0 }
+	case "!=":
+		accept = func(c int) bool { return c != 0 }
+	case "<":
+		accept = func(c int) bool { return c < 0 }
+	case "<=":
+		accept = func(c int) bool { return c <= 0 }
+	case ">":
+		accept = func(c int) bool { return c > 0 }
+	case ">=":
+		accept = func(c int) bool { return c >= 0 }
+	}
+	return func(r *Record) bool {
+		return accept(cmp(left.get(r), right.get(r)))
+	}, nil
+}
+
+func isOperator(t token, op string) bool {
+	return t.kind == tokenOperator && t.text == op
+}
+
+func isComparison(op string) bool {
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testRecord() *Record {
+	fh := ach.NewFileHeader()
+	fh.ImmediateOrigin = "121042882"
+	fh.ImmediateDestination = "231380104"
+
+	bh := ach.NewBatchHeader()
+	bh.StandardEntryClassCode = ach.PPD
+	bh.CompanyIdentification = "1234567890"
+	bh.CompanyName = "Acme Corp"
+	bh.EffectiveEntryDate = "190625"
+	bh.BatchNumber = 2
+
+	ed := ach.NewEntryDetail()
+	ed.TransactionCode = ach.CheckingDebit
+	ed.RDFIIdentification = "23138010"
+	ed.CheckDigit = "4"
+	ed.DFIAccountNumber = "12345678         "
+	ed.Amount = 75000
+	ed.IndividualName = "Jane Smith            "
+	ed.TraceNumber = "121042880000001"
+	ed.Addenda99 = ach.NewAddenda99()
+	ed.Addenda99.ReturnCode = "R01"
+
+	return &Record{
+		Path:        "returns.ach",
+		FileHeader:  &fh,
+		BatchHeader: bh,
+		Entry:       ed,
+	}
+}
+
+func TestQuery__Match(t *testing.T) {
+	r := testRecord()
+
+	cases := map[string]bool{
+		`secCode == "PPD" && amount > 50000 && returnCode in ["R01", "R09"]`: true,
+		`secCode == "PPD" && amount > 80000`:                                 false,
+		`returnCode in ['R02', 'R09']`:                                       false,
+		`rdfiIdentification == "23138010"`:                                   true,
+		`accountNumber == "12345678"`:                                        true,
+		`individualName contains "SMITH"`:                                    true,
+		`individualName startsWith "Smith"`:                                  false,
+		`debit && !credit && !prenote`:                                       true,
+		`credit == false`:                                                    true,
+		`!(amount < 100 || companyIdentification != "1234567890")`:           true,
+		`effectiveEntryDate >= "190601" && effectiveEntryDate <= "190630"`:   true,
+		`batchNumber == 2 && transactionCode == 27`:                          true,
+		`immediateOrigin == "121042882" && path == "returns.ach"`:            true,
+		`changeCode == "" && addendaCount == 1`:                              true,
+		`amount == -1`:                                                       false,
+	}
+	for expr, expected := range cases {
+		q, err := Parse(expr)
+		require.NoError(t, err, expr)
+		require.Equal(t, expected, q.Match(r), expr)
+		require.Equal(t, expr, q.String())
+	}
+}
+
+func TestQuery__ParseErrors(t *testing.T) {
+	cases := map[string]string{
+		`amount > "500"`:                  `cannot compare amount (number) with "500" (string)`,
+		`secCode == PPD`:                  `unknown field "PPD" at position 12`,
+		`foo == 1`:                        `unknown field "foo" at position 1`,
+		`secCode`:                         `expected a comparison after secCode but found end of query`,
+		`secCode == "PPD" &&`:             `expected a field or value but found end of query`,
+		`(amount > 1`:                     `expected ")" but found end of query`,
+		`returnCode in ["R01", 2]`:        `returnCode is a string but the list contains 2`,
+		`returnCode in [traceNumber]`:     `lists can only contain values but found traceNumber`,
+		`amount contains "1"`:             `contains requires strings but found number and string`,
+		`credit > true`:                   `">" at position 8 cannot be used with booleans`,
+		`secCode == "PPD`:                 `unterminated string starting at position 12`,
+		`amount = 1`:                      `unexpected character '=' at position 8`,
+		`amount > 1 amount`:               `unexpected "amount" at position 12`,
+		`returnCode in ["R01" "R02"]`:     `expected "," or "]" but found "R02" at position 22`,
+		`amount > 99999999999999999999`:   `invalid number "99999999999999999999" at position 10`,
+		`individualName contains`:         `expected a field or value but found end of query`,
+		`returnCode in "R01"`:             `expected "[" but found "R01" at position 15`,
+		`secCode == "PPD" || ) `:          `expected a field or value but found ")" at position 21`,
+		`secCode == "PPD" && amount 1`:    `expected a comparison after amount but found "1" at position 28`,
+		`secCode == "PPD" && == "PPD"`:    `expected a field or value but found "==" at position 21`,
+		`secCode == "PPD" & amount > 500`: `unexpected character '&' at position 18`,
+	}
+	for expr, msg := range cases {
+		_, err := Parse(expr)
+		require.Error(t, err, expr)
+		require.Contains(t, err.Error(), msg, expr)
+	}
+}
+
+func TestQuery__Fields(t *testing.T) {
+	names := Fields()
+	require.Contains(t, names, "amount")
+	require.Contains(t, names, "returnCode")
+	require.IsIncreasing(t, names)
+
+	// every field can be read from an entry without addenda
+	r := testRecord()
+	r.Entry.Addenda99 = nil
+	for _, name := range names {
+		require.NotPanics(t, func() { fields[name].get(r) }, name)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 
 	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/describe"
 )
 
 func reformat(as string, filepath string, validateOpts *ach.ValidateOpts) error {
@@ -30,10 +31,23 @@ func reformat(as string, filepath string, validateOpts *ach.ValidateOpts) error
 		}
 
 	case "json":
+		if *flagEnrich {
+			file.Enrich()
+		}
 		if err := json.NewEncoder(os.Stdout).Encode(file); err != nil {
 			return err
 		}
 
+	case "html":
+		if err := describe.HTML(os.Stdout, file, describeOpts()); err != nil {
+			return err
+		}
+
+	case "pdf":
+		if err := describe.PDF(os.Stdout, file, describeOpts()); err != nil {
+			return err
+		}
+
 	default:
 		return fmt.Errorf("unknown format %s", as)
 	}
@@ -0,0 +1,23 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/cmd/achcli/report"
+)
+
+func reportFiles(paths []string, format string, largest int, validateOpts *ach.ValidateOpts) error {
+	r, err := report.Files(paths, report.Options{
+		Largest:      largest,
+		ValidateOpts: validateOpts,
+	})
+	if writeErr := report.Write(os.Stdout, r, format, *flagPretty || *flagPrettyAmounts); writeErr != nil {
+		return writeErr
+	}
+	return err
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/moov-io/ach/describe"
+)
+
+// Write prints a Report as text (the default), json or csv. Text amounts are formatted as
+// dollars when prettyAmounts is set.
+func Write(w io.Writer, r *Report, format string, prettyAmounts bool) error {
+	switch strings.ToLower(format) {
+	case "", "text":
+		return writeText(w, r, prettyAmounts)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case "csv":
+		return writeCSV(w, r)
+	}
+	return fmt.Errorf("unknown report output format %q", format)
+}
+
+type section struct {
+	name   string
+	groups []Group
+}
+
+func (r *Report) sections() []section {
+	return []section{
+		{"SEC Code", r.SECCodes},
+		{"Transaction Code", r.TransactionCodes},
+		{"Company", r.Companies},
+		{"RDFI", r.RDFIs},
+		{"Return Code", r.Returns},
+		{"Change Code", r.NOCs},
+	}
+}
+
+func (r *Report) totals() []struct {
+	name  string
+	total Total
+} {
+	return []struct {
+		name  string
+		total Total
+	}{
+		{"Credits", r.Credits},
+		{"Debits", r.Debits},
+		{"Prenotes", r.Prenotes},
+		{"Same Day", r.SameDay},
+		{"Next Day", r.NextDay},
+	}
+}
+
+func writeText(w io.Writer, r *Report, prettyAmounts bool) error {
+	amount := func(amt int) string {
+		return describe.FormatAmount(prettyAmounts, amt)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Files: %d  Batches: %d  Entries: %d\n\n", r.Files, r.Batches, r.Entries)
+
+	fmt.Fprintln(tw, "\tCount\tAmount")
+	for _, t := range r.totals() {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", t.name, t.total.Count, amount(t.total.Amount))
+	}
+
+	for _, s := range r.sections() {
+		if len(s.groups) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "\n%s\tDescription\tCount\tCredits\tDebits\n", s.name)
+		for _, g := range s.groups {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", g.Key, g.Description, g.Count, amount(g.Credits), amount(g.Debits))
+		}
+	}
+
+	if len(r.Largest) > 0 {
+		fmt.Fprintln(tw, "\nLargest Entries\tSEC Code\tCompany\tTransaction Code\tRDFI\tName\tAmount\tPath")
+		for _, e := range r.Largest {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d %s\t%s\t%s\t%s\t%s\n", e.TraceNumber, e.SECCode, e.CompanyName,
+				e.TransactionCode, describe.TransactionCode(e.TransactionCode), e.RDFI, e.IndividualName, amount(e.Amount), e.Path)
+		}
+	}
+	return tw.Flush()
+}
+
+// writeCSV writes every statistic as a row of section, key, description, count, amount, credits and debits.
+// Credits and debits are empty for totals and the largest entries, which are keyed by trace number.
+func writeCSV(w io.Writer, r *Report) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"section", "key", "description", "count", "amount", "credits", "debits"})
+
+	itoa := strconv.Itoa
+	cw.Write([]string{"Files", "", "", itoa(r.Files), "", "", ""})
+	cw.Write([]string{"Batches", "", "", itoa(r.Batches), "", "", ""})
+	cw.Write([]string{"Entries", "", "", itoa(r.Entries), itoa(r.Credits.Amount + r.Debits.Amount), itoa(r.Credits.Amount), itoa(r.Debits.Amount)})
+	for _, t := range r.totals() {
+		cw.Write([]string{"Total", t.name, "", itoa(t.total.Count), itoa(t.total.Amount), "", ""})
+	}
+	for _, s := range r.sections() {
+		for _, g := range s.groups {
+			cw.Write([]string{s.name, g.Key, g.Description, itoa(g.Count), itoa(g.Credits + g.Debits), itoa(g.Credits), itoa(g.Debits)})
+		}
+	}
+	for _, e := range r.Largest {
+		description := strings.Join(strings.Fields(fmt.Sprintf("%s %s %s", e.SECCode, e.CompanyName, e.IndividualName)), " ")
+		cw.Write([]string{"Largest Entries", e.TraceNumber, description, "1", itoa(e.Amount), "", ""})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
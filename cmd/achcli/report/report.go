@@ -0,0 +1,270 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package report summarizes the entries of many ACH files into aggregate statistics.
+package report
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/cmd/achcli/query"
+	"github.com/moov-io/ach/describe"
+)
+
+// Report contains the counts and amounts (in cents) of every entry read
+type Report struct {
+	Files   int `json:"files"`
+	Batches int `json:"batches"`
+	Entries int `json:"entries"`
+
+	Credits  Total `json:"credits"`
+	Debits   Total `json:"debits"`
+	Prenotes Total `json:"prenotes"`
+	SameDay  Total `json:"sameDay"`
+	NextDay  Total `json:"nextDay"`
+
+	SECCodes         []Group `json:"secCodes"`
+	TransactionCodes []Group `json:"transactionCodes"`
+	Companies        []Group `json:"companies"`
+	RDFIs            []Group `json:"rdfis"`
+	Returns          []Group `json:"returns"`
+	NOCs             []Group `json:"nocs"`
+
+	Largest []Entry `json:"largest"`
+}
+
+// Total is a count of entries and their summed amount
+type Total struct {
+	Count  int `json:"count"`
+	Amount int `json:"amount"`
+}
+
+// Group is the entries sharing a key, such as an SEC code or return code
+type Group struct {
+	Key         string `json:"key"`
+	Description string `json:"description,omitempty"`
+	Count       int    `json:"count"`
+	Credits     int    `json:"credits"`
+	Debits      int    `json:"debits"`
+}
+
+// Entry is one of the largest entries found
+type Entry struct {
+	Path            string `json:"path"`
+	SECCode         string `json:"secCode"`
+	CompanyName     string `json:"companyName"`
+	TransactionCode int    `json:"transactionCode"`
+	RDFI            string `json:"rdfi"`
+	IndividualName  string `json:"individualName"`
+	TraceNumber     string `json:"traceNumber"`
+	Amount          int    `json:"amount"`
+}
+
+// Options for building a Report
+type Options struct {
+	// Largest is how many of the largest entries to keep, defaults to 10
+	Largest int
+
+	ValidateOpts *ach.ValidateOpts
+}
+
+// Files builds a Report over each file or directory in paths. IAT entries are included through the
+// fields they share with other entries.
+//
+// Files which can't be read are skipped and their errors returned along with the Report of
+// every other file.
+func Files(paths []string, opts Options) (*Report, error) {
+	b := newBuilder(opts)
+	err := query.Each(paths, opts.ValidateOpts, func(r *query.Record) error {
+		b.add(r)
+		return nil
+	})
+	return b.build(), err
+}
+
+type builder struct {
+	report  Report
+	largest int
+
+	lastPath  string
+	lastBatch *ach.BatchHeader
+
+	secCodes, transactionCodes, companies, rdfis, returns, nocs map[string]*Group
+}
+
+func newBuilder(opts Options) *builder {
+	if opts.Largest <= 0 {
+		opts.Largest = 10
+	}
+	return &builder{
+		largest:          opts.Largest,
+		secCodes:         make(map[string]*Group),
+		transactionCodes: make(map[string]*Group),
+		companies:        make(map[string]*Group),
+		rdfis:            make(map[string]*Group),
+		returns:          make(map[string]*Group),
+		nocs:             make(map[string]*Group),
+	}
+}
+
+func (b *builder) add(r *query.Record) {
+	if r.Path != b.lastPath {
+		b.report.Files++
+		b.lastPath = r.Path
+	}
+	if r.BatchHeader != b.lastBatch {
+		b.report.Batches++
+		b.lastBatch = r.BatchHeader
+	}
+	b.report.Entries++
+
+	bh, ed := r.BatchHeader, r.Entry
+	credits, debits := 0, 0
+	switch ed.CreditOrDebit() {
+	case "C":
+		credits = ed.Amount
+		b.report.Credits.add(ed.Amount)
+	case "D":
+		debits = ed.Amount
+		b.report.Debits.add(ed.Amount)
+	}
+	if isPrenote(ed.TransactionCode) {
+		b.report.Prenotes.add(ed.Amount)
+	}
+	if isSameDay(r.FileHeader, bh) {
+		b.report.SameDay.add(ed.Amount)
+	} else {
+		b.report.NextDay.add(ed.Amount)
+	}
+
+	addTo(b.secCodes, bh.StandardEntryClassCode, "", credits, debits)
+	addTo(b.transactionCodes, strconv.Itoa(ed.TransactionCode), describe.TransactionCode(ed.TransactionCode), credits, debits)
+	addTo(b.companies, strings.TrimSpace(bh.CompanyIdentification), strings.TrimSpace(bh.CompanyName), credits, debits)
+	addTo(b.rdfis, ed.RDFIIdentification+ed.CheckDigit, "", credits, debits)
+
+	if code := returnCode(ed); code != "" {
+		var reason string
+		if rc := ach.LookupReturnCode(code); rc != nil {
+			reason = rc.Reason
+		}
+		addTo(b.returns, code, reason, credits, debits)
+	}
+	if ed.Addenda98 != nil {
+		var reason string
+		if cc := ach.LookupChangeCode(ed.Addenda98.ChangeCode); cc != nil {
+			reason = cc.Reason
+		}
+		addTo(b.nocs, ed.Addenda98.ChangeCode, reason, credits, debits)
+	}
+
+	b.addLargest(Entry{
+		Path:            r.Path,
+		SECCode:         bh.StandardEntryClassCode,
+		CompanyName:     strings.TrimSpace(bh.CompanyName),
+		TransactionCode: ed.TransactionCode,
+		RDFI:            ed.RDFIIdentification + ed.CheckDigit,
+		IndividualName:  strings.TrimSpace(ed.IndividualName),
+		TraceNumber:     ed.TraceNumber,
+		Amount:          ed.Amount,
+	})
+}
+
+// addLargest keeps the largest entries in descending order of their amount
+func (b *builder) addLargest(e Entry) {
+	largest := b.report.Largest
+	if len(largest) == b.largest && largest[len(largest)-1].Amount >= e.Amount {
+		return
+	}
+	idx, _ := slices.BinarySearchFunc(largest, e.Amount, func(have Entry, amount int) int {
+		return cmp.Compare(amount, have.Amount)
+	})
+	largest = slices.Insert(largest, idx, e)
+	if len(largest) > b.largest {
+		largest = largest[:b.largest]
+	}
+	b.report.Largest = largest
+}
+
+func (b *builder) build() *Report {
+	out := b.report
+	out.SECCodes = sortedByKey(b.secCodes)
+	out.TransactionCodes = sortedByKey(b.transactionCodes)
+	out.Companies = sortedByAmount(b.companies)
+	out.RDFIs = sortedByAmount(b.rdfis)
+	out.Returns = sortedByKey(b.returns)
+	out.NOCs = sortedByKey(b.nocs)
+	return &out
+}
+
+func (t *Total) add(amount int) {
+	t.Count++
+	t.Amount += amount
+}
+
+func addTo(groups map[string]*Group, key, description string, credits, debits int) {
+	g, exists := groups[key]
+	if !exists {
+		g = &Group{Key: key, Description: description}
+		groups[key] = g
+	}
+	g.Count++
+	g.Credits += credits
+	g.Debits += debits
+}
+
+func sortedByKey(groups map[string]*Group) []Group {
+	out := make([]Group, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, *g)
+	}
+	slices.SortFunc(out, func(a, b Group) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return out
+}
+
+// sortedByAmount orders groups from the largest credits and debits to the smallest
+func sortedByAmount(groups map[string]*Group) []Group {
+	out := sortedByKey(groups)
+	slices.SortStableFunc(out, func(a, b Group) int {
+		return cmp.Compare(b.Credits+b.Debits, a.Credits+a.Debits)
+	})
+	return out
+}
+
+func returnCode(ed *ach.EntryDetail) string {
+	switch {
+	case ed.Addenda99 != nil:
+		return ed.Addenda99.ReturnCode
+	case ed.Addenda99Dishonored != nil:
+		return ed.Addenda99Dishonored.DishonoredReturnReasonCode
+	case ed.Addenda99Contested != nil:
+		return ed.Addenda99Contested.ContestedReturnCode
+	}
+	return ""
+}
+
+func isPrenote(code int) bool {
+	switch code {
+	case ach.CheckingPrenoteCredit, ach.CheckingPrenoteDebit,
+		ach.SavingsPrenoteCredit, ach.SavingsPrenoteDebit,
+		ach.GLPrenoteCredit, ach.GLPrenoteDebit,
+		ach.LoanPrenoteCredit:
+		return true
+	}
+	return false
+}
+
+// isSameDay returns true for batches using the SDHHMM CompanyDescriptiveDate convention or
+// which are effective on the day their file was created.
+func isSameDay(fh *ach.FileHeader, bh *ach.BatchHeader) bool {
+	if ach.IsSameDayIndicator(bh.CompanyDescriptiveDate) {
+		return true
+	}
+	return fh != nil && bh.EffectiveEntryDate != "" && bh.EffectiveEntryDate == fh.FileCreationDate
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testdata(names ...string) []string {
+	var out []string
+	for _, name := range names {
+		out = append(out, filepath.Join("..", "..", "..", "test", "testdata", name))
+	}
+	return out
+}
+
+func TestFiles(t *testing.T) {
+	paths := testdata("ppd-mixedDebitCredit.ach", "return-WEB.ach", "cor-example.ach", "web-debit.ach")
+	r, err := Files(paths, Options{Largest: 2})
+	require.NoError(t, err)
+
+	require.Equal(t, 4, r.Files)
+	require.Equal(t, 1+2+1+3, r.Batches)
+	require.Equal(t, 3+2+1+6, r.Entries)
+	require.Equal(t, r.Entries, r.Credits.Count+r.Debits.Count)
+	require.Equal(t, r.Entries, r.SameDay.Count+r.NextDay.Count)
+
+	require.Equal(t, []Group{
+		{Key: "R01", Description: "Insufficient Funds", Count: 1, Debits: 12354},
+		{Key: "R03", Description: "No Account/Unable to Locate Account", Count: 1, Credits: 4565},
+	}, r.Returns)
+	require.Len(t, r.NOCs, 1)
+	require.Equal(t, "C01", r.NOCs[0].Key)
+
+	var secCodes []string
+	for _, g := range r.SECCodes {
+		secCodes = append(secCodes, g.Key)
+	}
+	require.Equal(t, []string{"COR", "PPD", "WEB"}, secCodes)
+
+	require.Equal(t, "(Checking Credit)", r.TransactionCodes[1].Description)
+
+	// Companies are ordered by their total amount
+	require.Equal(t, "121042882", r.Companies[0].Key)
+
+	require.Len(t, r.Largest, 2)
+	require.Equal(t, 200000000, r.Largest[0].Amount)
+	require.Equal(t, "121042880000001", r.Largest[0].TraceNumber)
+	require.Equal(t, 100000000, r.Largest[1].Amount)
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, r, "", true))
+
+		out := buf.String()
+		require.Contains(t, out, "Files: 4  Batches: 7  Entries: 12")
+		require.Contains(t, out, "Return Code")
+		require.Contains(t, out, "Insufficient Funds")
+		require.Contains(t, out, "2,000,000.00")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, r, "json", false))
+
+		var decoded Report
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		require.Equal(t, *r, decoded)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, r, "CSV", false))
+
+		rows, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, []string{"section", "key", "description", "count", "amount", "credits", "debits"}, rows[0])
+		require.Contains(t, rows, []string{"Return Code", "R01", "Insufficient Funds", "1", "12354", "0", "12354"})
+		require.Equal(t, "Largest Entries", rows[len(rows)-1][0])
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		require.ErrorContains(t, Write(nil, r, "xml", false), `unknown report output format "xml"`)
+	})
+}
+
+func TestFiles__Errors(t *testing.T) {
+	paths := testdata("ppd-mixedDebitCredit.ach", "missing.ach")
+	r, err := Files(paths, Options{})
+	require.ErrorContains(t, err, "missing.ach")
+	require.Equal(t, 1, r.Files)
+	require.Equal(t, 3, r.Entries)
+}
+
+func TestIsSameDay(t *testing.T) {
+	fh := &ach.FileHeader{FileCreationDate: "261016"}
+
+	require.True(t, isSameDay(fh, &ach.BatchHeader{CompanyDescriptiveDate: "SD1300", EffectiveEntryDate: "261019"}))
+	require.True(t, isSameDay(fh, &ach.BatchHeader{EffectiveEntryDate: "261016"}))
+	require.False(t, isSameDay(fh, &ach.BatchHeader{EffectiveEntryDate: "261019"}))
+	require.False(t, isSameDay(nil, &ach.BatchHeader{}))
+}
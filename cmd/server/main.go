@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/fedach"
 	"github.com/moov-io/ach/server"
 	"github.com/moov-io/base/admin"
 	"github.com/moov-io/base/http/bind"
@@ -74,7 +75,17 @@ func main() {
 		}
 	}
 	r := server.NewRepositoryInMemory(achFileTTL, logger)
-	svc = server.NewService(r)
+	if path := os.Getenv("FEDACH_DIRECTORY_PATH"); path != "" {
+		dir, err := fedach.ReadFile(path)
+		if err != nil {
+			logger.Fatal().LogErrorf("problem reading FedACH directory: %v", err)
+			os.Exit(1)
+		}
+		logger.Logf("Validating RDFIs against %d FedACH participants from %s", len(dir.Participants), path)
+		svc = server.NewServiceWithDirectory(r, dir)
+	} else {
+		svc = server.NewService(r)
+	}
 
 	// Create HTTP server
 	handler = server.MakeHTTPHandler(svc, r, kitlog.With(kitlogger, "component", "HTTP"))
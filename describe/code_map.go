@@ -6,6 +6,7 @@ package describe
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/moov-io/ach"
 )
@@ -79,3 +80,18 @@ func prenote(s string, t transactionType) string {
 func remittance(s string, t transactionType) string {
 	return fmt.Sprintf("%-40.40s", fmt.Sprintf("(%s Zero Dollar Remittance %s)", s, t))
 }
+
+// TransactionCode returns a description of an EntryDetail TransactionCode (e.g. "(Checking Credit)")
+func TransactionCode(code int) string {
+	return strings.TrimSpace(transactionCodes[code])
+}
+
+// ServiceClassCode returns a description of a BatchHeader ServiceClassCode (e.g. "(Credits Only)")
+func ServiceClassCode(code int) string {
+	return strings.Join(strings.Fields(serviceClassCodes[code]), " ")
+}
+
+// FormatAmount returns amt in cents, or a human readable dollar amount when prettyAmounts is true.
+func FormatAmount(prettyAmounts bool, amt int) string {
+	return formatAmount(prettyAmounts, amt)
+}
@@ -11,7 +11,8 @@ import (
 	"text/tabwriter"
 
 	"github.com/moov-io/ach"
-	"github.com/moov-io/ach/cmd/achcli/describe/mask"
+	"github.com/moov-io/ach/describe/mask"
+	"github.com/moov-io/ach/fedach"
 
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
@@ -22,6 +23,20 @@ type Opts struct {
 	mask.Options
 
 	PrettyAmounts bool
+
+	// Enrich adds the reason and rules of each return and the decoded corrected data of each NOC
+	Enrich bool
+
+	// Directory is used to show the bank name of each RDFI
+	Directory *fedach.Directory
+}
+
+// rdfi returns value followed by the bank name of routingNumber from opts.Directory, if it's found.
+func (opts *Opts) rdfi(value, routingNumber string) string {
+	if p := opts.Directory.Get(routingNumber); p != nil {
+		return value + " " + p.CustomerName
+	}
+	return value
 }
 
 func File(ww io.Writer, file *ach.File, opts *Opts) {
@@ -72,7 +87,7 @@ func File(ww io.Writer, file *ach.File, opts *Opts) {
 
 			amount := formatAmount(opts.PrettyAmounts, e.Amount)
 
-			fmt.Fprintf(w, "    %d %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.TransactionCode, transactionCodes[e.TransactionCode], e.RDFIIdentificationField(), e.DFIAccountNumberField(), amount, e.IndividualNameField(), e.IdentificationNumberField(), e.TraceNumberField(), e.Category)
+			fmt.Fprintf(w, "    %d %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.TransactionCode, transactionCodes[e.TransactionCode], opts.rdfi(e.RDFIIdentificationField(), e.RDFIIdentificationField()+e.CheckDigit), e.DFIAccountNumberField(), amount, e.IndividualNameField(), e.IdentificationNumberField(), e.TraceNumberField(), e.Category)
 
 			dumpAddenda02(w, e.Addenda02)
 			for a := range e.Addenda05 {
@@ -82,7 +97,7 @@ func File(ww io.Writer, file *ach.File, opts *Opts) {
 				dumpAddenda05(w, file.Batches[i], e.Addenda05[a], opts)
 			}
 			dumpAddenda98(w, opts, e.Addenda98)
-			dumpAddenda99(w, e.Addenda99)
+			dumpAddenda99(w, opts, e.Addenda99)
 			dumpAddenda99Dishonored(w, e.Addenda99Dishonored)
 			dumpAddenda99Contested(w, e.Addenda99Contested)
 		}
@@ -134,7 +149,7 @@ func File(ww io.Writer, file *ach.File, opts *Opts) {
 
 			e := entries[j]
 			amount := formatAmount(opts.PrettyAmounts, e.Amount)
-			fmt.Fprintf(w, "    %d %s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.TransactionCode, transactionCodes[e.TransactionCode], e.RDFIIdentificationField(), e.DFIAccountNumberField(), amount, e.AddendaRecordsField(), e.TraceNumberField(), e.Category)
+			fmt.Fprintf(w, "    %d %s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.TransactionCode, transactionCodes[e.TransactionCode], opts.rdfi(e.RDFIIdentificationField(), e.RDFIIdentificationField()+e.CheckDigit), e.DFIAccountNumberField(), amount, e.AddendaRecordsField(), e.TraceNumberField(), e.Category)
 
 			dumpAddenda10(w, e.Addenda10)
 			dumpAddenda11(w, e.Addenda11)
@@ -152,7 +167,7 @@ func File(ww io.Writer, file *ach.File, opts *Opts) {
 			}
 
 			dumpAddenda98(w, opts, e.Addenda98)
-			dumpAddenda99(w, e.Addenda99)
+			dumpAddenda99(w, opts, e.Addenda99)
 		}
 
 		bc := iatBatch.GetControl()
@@ -248,9 +263,41 @@ func dumpAddenda98(w *tabwriter.Writer, opts *Opts, a *ach.Addenda98) {
 	}
 
 	fmt.Fprintf(w, "      %s\t%s\t%s\t%s\t%s\n", a.ChangeCode, a.OriginalTraceField(), a.OriginalDFIField(), data, a.TraceNumberField())
+
+	if !opts.Enrich {
+		return
+	}
+	details := ach.LookupChangeDetails(a)
+	if details == nil {
+		return
+	}
+	fmt.Fprintln(w, "\n      Reason\tAccountNumber\tRoutingNumber\tName\tTransactionCode\tIdentification")
+
+	corrected := details.CorrectedData
+	if corrected == nil {
+		corrected = &ach.CorrectedData{}
+	}
+	account, name, identification := corrected.AccountNumber, corrected.Name, corrected.Identification
+	if opts.MaskCorrectedData {
+		account = maskNonEmpty(account, mask.Number)
+		name = maskNonEmpty(name, mask.Name)
+		identification = maskNonEmpty(identification, mask.Number)
+	}
+	var transactionCode string
+	if corrected.TransactionCode > 0 {
+		transactionCode = fmt.Sprintf("%d %s", corrected.TransactionCode, TransactionCode(corrected.TransactionCode))
+	}
+	fmt.Fprintf(w, "      %s\t%s\t%s\t%s\t%s\t%s\n", details.Reason, account, corrected.RoutingNumber, name, transactionCode, identification)
+}
+
+func maskNonEmpty(s string, fn func(string) string) string {
+	if s == "" {
+		return ""
+	}
+	return fn(s)
 }
 
-func dumpAddenda99(w *tabwriter.Writer, a *ach.Addenda99) {
+func dumpAddenda99(w *tabwriter.Writer, opts *Opts, a *ach.Addenda99) {
 	if a == nil {
 		return
 	}
@@ -258,6 +305,16 @@ func dumpAddenda99(w *tabwriter.Writer, a *ach.Addenda99) {
 	fmt.Fprintln(w, "\n      Addenda99")
 	fmt.Fprintln(w, "      ReturnCode\tOriginalTrace\tDateOfDeath\tOriginalDFI\tAddendaInformation\tTraceNumber")
 	fmt.Fprintf(w, "      %s\t%s\t%s\t%s\t%s\t%s\n", a.ReturnCode, a.OriginalTraceField(), a.DateOfDeathField(), a.OriginalDFIField(), a.AddendaInformationField(), a.TraceNumberField())
+
+	if !opts.Enrich {
+		return
+	}
+	details := ach.LookupReturnDetails(a.ReturnCode)
+	if details == nil {
+		return
+	}
+	fmt.Fprintln(w, "\n      Reason\tUnauthorized\tAdministrative\tTimeframe\tDishonorable")
+	fmt.Fprintf(w, "      %s\t%t\t%t\t%s\t%t\n", details.Reason, details.Unauthorized, details.Administrative, details.Timeframe, details.Dishonorable)
 }
 
 func dumpAddenda10(w *tabwriter.Writer, a *ach.Addenda10) {
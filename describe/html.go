@@ -0,0 +1,84 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package describe
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/moov-io/ach"
+)
+
+// HTML writes a standalone, printable HTML report of file with its header, each batch's entries,
+// the control totals and validation status. Account numbers are always masked.
+func HTML(w io.Writer, file *ach.File, opts *Opts) error {
+	if file == nil {
+		return nil
+	}
+	return htmlTemplate.Execute(w, newReport(file, opts))
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+  body { font-family: Helvetica, Arial, sans-serif; font-size: 12px; color: #222; margin: 2em; }
+  h1 { font-size: 20px; }
+  h2 { font-size: 16px; margin-top: 2em; border-bottom: 1px solid #999; }
+  table { border-collapse: collapse; margin: 0.5em 0 1em; }
+  th, td { padding: 3px 8px; text-align: left; border: 1px solid #ccc; }
+  th { background: #eee; }
+  td.amount { text-align: right; font-family: "Courier New", monospace; }
+  table.fields th { width: 14em; }
+  .valid { color: #176f2c; font-weight: bold; }
+  .invalid { color: #b00020; font-weight: bold; }
+  @media print {
+    body { margin: 0; }
+    section.batch { page-break-inside: avoid; }
+  }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+{{ template "fields" .Header }}
+<h2>Validation</h2>
+{{ if .Valid -}}
+<p class="valid">Valid</p>
+{{- else -}}
+<p class="invalid">Invalid</p>
+<ul>
+{{- range .Errors }}
+  <li>{{ . }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{ range .Batches -}}
+<section class="batch">
+<h2>{{ .Title }}</h2>
+{{ template "fields" .Header }}
+<table class="entries">
+<tr>{{ range .Columns }}<th>{{ . }}</th>{{ end }}</tr>
+{{- range .Entries }}
+<tr>{{ range $i, $v := . }}<td{{ if eq $i 5 }} class="amount"{{ end }}>{{ $v }}</td>{{ end }}</tr>
+{{- end }}
+</table>
+{{ template "fields" .Control }}
+</section>
+{{ end -}}
+{{ if .Control -}}
+<h2>File Control</h2>
+{{ template "fields" .Control }}
+{{- end }}
+</body>
+</html>
+{{ define "fields" -}}
+<table class="fields">
+{{- range . }}
+<tr><th>{{ .Name }}</th><td>{{ .Value }}</td></tr>
+{{- end }}
+</table>
+{{- end }}`))
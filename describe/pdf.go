@@ -0,0 +1,162 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package describe
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/moov-io/ach"
+)
+
+// Pages are US Letter in landscape with half inch margins, text is set in the built-in Courier font
+// so no fonts need to be embedded.
+const (
+	pdfPageWidth  = 792
+	pdfPageHeight = 612
+	pdfMargin     = 36
+	pdfFontSize   = 7
+	pdfLeading    = 9
+
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+	pdfLineWidth    = (pdfPageWidth - 2*pdfMargin) * 10 / (pdfFontSize * 6) // Courier glyphs are 0.6em wide
+)
+
+// PDF writes a printable PDF report of file with the same content as HTML.
+// Account numbers are always masked.
+func PDF(w io.Writer, file *ach.File, opts *Opts) error {
+	if file == nil {
+		return nil
+	}
+	return writePDF(w, paginate(newReport(file, opts).lines()))
+}
+
+func paginate(lines []string) [][]string {
+	var pages [][]string
+	for len(lines) > pdfLinesPerPage {
+		pages = append(pages, lines[:pdfLinesPerPage])
+		lines = lines[pdfLinesPerPage:]
+	}
+	return append(pages, lines)
+}
+
+// lines lays out the report as plain text
+func (r *report) lines() []string {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	fields := func(indent string, fs []field) {
+		for _, f := range fs {
+			fmt.Fprintf(tw, "%s%s\t%s\n", indent, f.Name, f.Value)
+		}
+	}
+
+	fmt.Fprintf(tw, "%s\n\n", r.Title)
+	fields("", r.Header)
+
+	if r.Valid {
+		fmt.Fprintln(tw, "\nValidation: Valid")
+	} else {
+		fmt.Fprintln(tw, "\nValidation: Invalid")
+		for _, e := range r.Errors {
+			fmt.Fprintf(tw, "  - %s\n", e)
+		}
+	}
+
+	for _, b := range r.Batches {
+		fmt.Fprintf(tw, "\n%s\n", b.Title)
+		fields("  ", b.Header)
+		fmt.Fprintln(tw, "")
+		fmt.Fprintf(tw, "  %s\n", strings.Join(b.Columns, "\t"))
+		for _, e := range b.Entries {
+			fmt.Fprintf(tw, "  %s\n", strings.Join(e, "\t"))
+		}
+		fmt.Fprintln(tw, "")
+		fields("  ", b.Control)
+	}
+
+	if len(r.Control) > 0 {
+		fmt.Fprintln(tw, "\nFile Control")
+		fields("  ", r.Control)
+	}
+	tw.Flush()
+
+	var out []string
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " ")
+		if len(line) > pdfLineWidth {
+			line = line[:pdfLineWidth]
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// writePDF writes a minimal PDF 1.4 document with one page of text per element of pages.
+func writePDF(w io.Writer, pages [][]string) error {
+	var buf bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// Objects 1-3 are the catalog, page tree and font. Each page is followed by its contents.
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, lines := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 5+2*i))
+
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT /F1 %d Tf %d TL %d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, pdfPageHeight-pdfMargin-pdfFontSize)
+		for _, line := range lines {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", pdfEscape(line))
+		}
+		content.WriteString("ET")
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// pdfEscape escapes a string for use in a PDF literal string, replacing characters outside of printable ASCII.
+func pdfEscape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		case r < ' ' || r > '~':
+			sb.WriteRune('?')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
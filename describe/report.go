@@ -0,0 +1,224 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package describe
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/describe/mask"
+	"github.com/moov-io/base"
+)
+
+// report is the printable content of a file shared by the HTML and PDF renderers.
+type report struct {
+	Title string
+
+	Header []field
+
+	Valid  bool
+	Errors []string
+
+	Batches []reportBatch
+
+	Control []field
+}
+
+type field struct {
+	Name  string
+	Value string
+}
+
+type reportBatch struct {
+	Title   string
+	Header  []field
+	Columns []string
+	Entries [][]string
+	Control []field
+}
+
+var reportColumns = []string{"TraceNumber", "TransactionCode", "RDFI", "Account", "Name", "Amount", "Addenda"}
+
+// newReport collects the file header, batches, controls and validation status of file. Account numbers
+// are always masked, names and corrected data are masked according to opts.
+func newReport(file *ach.File, opts *Opts) *report {
+	if opts == nil {
+		opts = &Opts{}
+	}
+	amount := func(amt int) string {
+		return "$" + formatAmount(true, amt)
+	}
+	name := func(s string) string {
+		s = strings.TrimSpace(s)
+		if opts.MaskNames {
+			return mask.Name(s)
+		}
+		return s
+	}
+	rdfi := func(routingNumber string) string {
+		return opts.rdfi(routingNumber, routingNumber)
+	}
+
+	fh := file.Header
+	r := &report{
+		Title: "ACH File Report",
+		Header: []field{
+			{"Immediate Origin", strings.TrimSpace(fh.ImmediateOriginField())},
+			{"Origin Name", strings.TrimSpace(fh.ImmediateOriginName)},
+			{"Immediate Destination", strings.TrimSpace(fh.ImmediateDestinationField())},
+			{"Destination Name", strings.TrimSpace(fh.ImmediateDestinationName)},
+			{"File Creation", strings.TrimSpace(fh.FileCreationDateField() + " " + fh.FileCreationTimeField())},
+			{"File ID Modifier", fh.FileIDModifier},
+		},
+	}
+	if file.ID != "" {
+		r.Header = append([]field{{"File ID", file.ID}}, r.Header...)
+	}
+
+	if err := file.Validate(); err != nil {
+		var el base.ErrorList
+		if errors.As(err, &el) {
+			for i := range el {
+				r.Errors = append(r.Errors, el[i].Error())
+			}
+		} else {
+			r.Errors = append(r.Errors, err.Error())
+		}
+	} else {
+		r.Valid = true
+	}
+
+	for _, b := range file.Batches {
+		bh := b.GetHeader()
+		batch := reportBatch{
+			Title: fmt.Sprintf("Batch %s %s", bh.BatchNumberField(), bh.StandardEntryClassCode),
+			Header: []field{
+				{"Company Name", strings.TrimSpace(bh.CompanyName)},
+				{"Company Identification", strings.TrimSpace(bh.CompanyIdentification)},
+				{"Entry Description", strings.TrimSpace(bh.CompanyEntryDescription)},
+				{"Service Class Code", fmt.Sprintf("%d %s", bh.ServiceClassCode, ServiceClassCode(bh.ServiceClassCode))},
+				{"Effective Entry Date", bh.EffectiveEntryDateField()},
+				{"ODFI", bh.ODFIIdentificationField()},
+			},
+			Columns: reportColumns,
+		}
+		for _, e := range b.GetEntries() {
+			batch.Entries = append(batch.Entries, []string{
+				e.TraceNumberField(),
+				fmt.Sprintf("%d %s", e.TransactionCode, TransactionCode(e.TransactionCode)),
+				rdfi(e.RDFIIdentificationField() + e.CheckDigit),
+				mask.Number(strings.TrimSpace(e.DFIAccountNumber)),
+				name(e.IndividualName),
+				amount(e.Amount),
+				entryAddenda(e),
+			})
+		}
+		if bc := b.GetControl(); bc != nil {
+			batch.Control = batchControlFields(bc.EntryAddendaCount, bc.EntryHashField(), amount(bc.TotalDebitEntryDollarAmount), amount(bc.TotalCreditEntryDollarAmount))
+		}
+		r.Batches = append(r.Batches, batch)
+	}
+
+	for _, b := range file.IATBatches {
+		bh := b.GetHeader()
+		batch := reportBatch{
+			Title: fmt.Sprintf("Batch %s IAT", bh.BatchNumberField()),
+			Header: []field{
+				{"Originator Identification", strings.TrimSpace(bh.OriginatorIdentification)},
+				{"Entry Description", strings.TrimSpace(bh.CompanyEntryDescription)},
+				{"Service Class Code", fmt.Sprintf("%d %s", bh.ServiceClassCode, ServiceClassCode(bh.ServiceClassCode))},
+				{"Destination Country", bh.ISODestinationCountryCode},
+				{"Currency", bh.ISOOriginatingCurrencyCode + " to " + bh.ISODestinationCurrencyCode},
+				{"Effective Entry Date", bh.EffectiveEntryDateField()},
+				{"ODFI", bh.ODFIIdentificationField()},
+			},
+			Columns: reportColumns,
+		}
+		for _, e := range b.GetEntries() {
+			var receiver string
+			if e.Addenda10 != nil {
+				receiver = name(e.Addenda10.Name)
+			}
+			var addenda string
+			if e.Addenda99 != nil {
+				addenda = returnAddenda(e.Addenda99.ReturnCode)
+			} else if e.Addenda98 != nil {
+				addenda = changeAddenda(e.Addenda98.ChangeCode)
+			}
+			batch.Entries = append(batch.Entries, []string{
+				e.TraceNumberField(),
+				fmt.Sprintf("%d %s", e.TransactionCode, TransactionCode(e.TransactionCode)),
+				rdfi(e.RDFIIdentificationField() + e.CheckDigit),
+				mask.Number(strings.TrimSpace(e.DFIAccountNumber)),
+				receiver,
+				amount(e.Amount),
+				addenda,
+			})
+		}
+		if bc := b.GetControl(); bc != nil {
+			batch.Control = batchControlFields(bc.EntryAddendaCount, bc.EntryHashField(), amount(bc.TotalDebitEntryDollarAmount), amount(bc.TotalCreditEntryDollarAmount))
+		}
+		r.Batches = append(r.Batches, batch)
+	}
+
+	if fc := file.Control; !file.IsADV() {
+		r.Control = []field{
+			{"Batch Count", fmt.Sprintf("%d", fc.BatchCount)},
+			{"Block Count", fmt.Sprintf("%d", fc.BlockCount)},
+			{"Entry/Addenda Count", fmt.Sprintf("%d", fc.EntryAddendaCount)},
+			{"Entry Hash", fc.EntryHashField()},
+			{"Total Debits", amount(fc.TotalDebitEntryDollarAmountInFile)},
+			{"Total Credits", amount(fc.TotalCreditEntryDollarAmountInFile)},
+		}
+	}
+
+	return r
+}
+
+func batchControlFields(entryAddendaCount int, entryHash, debits, credits string) []field {
+	return []field{
+		{"Entry/Addenda Count", fmt.Sprintf("%d", entryAddendaCount)},
+		{"Entry Hash", entryHash},
+		{"Total Debits", debits},
+		{"Total Credits", credits},
+	}
+}
+
+// entryAddenda summarizes the addenda records of an entry, such as a return or NOC's reason
+func entryAddenda(e *ach.EntryDetail) string {
+	switch {
+	case e.Addenda99 != nil:
+		return returnAddenda(e.Addenda99.ReturnCode)
+	case e.Addenda99Dishonored != nil:
+		return returnAddenda(e.Addenda99Dishonored.DishonoredReturnReasonCode)
+	case e.Addenda99Contested != nil:
+		return returnAddenda(e.Addenda99Contested.ContestedReturnCode)
+	case e.Addenda98 != nil:
+		return changeAddenda(e.Addenda98.ChangeCode)
+	case e.Addenda98Refused != nil:
+		return changeAddenda(e.Addenda98Refused.RefusedChangeCode)
+	case e.Addenda02 != nil:
+		return strings.TrimSpace(e.Addenda02.TerminalLocation + " " + e.Addenda02.TerminalCity + " " + e.Addenda02.TerminalState)
+	case len(e.Addenda05) > 0:
+		return strings.TrimSpace(e.Addenda05[0].PaymentRelatedInformation)
+	}
+	return ""
+}
+
+func returnAddenda(code string) string {
+	if rc := ach.LookupReturnCode(code); rc != nil {
+		return code + " " + rc.Reason
+	}
+	return code
+}
+
+func changeAddenda(code string) string {
+	if cc := ach.LookupChangeCode(code); cc != nil {
+		return code + " " + cc.Reason
+	}
+	return code
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package describe
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/describe/mask"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTML(t *testing.T) {
+	file, err := ach.ReadFile(filepath.Join("..", "test", "testdata", "return-WEB.ach"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, HTML(&buf, file, &Opts{
+		Options: mask.Options{
+			MaskNames: true,
+		},
+	}))
+	if testing.Verbose() {
+		os.Stdout.Write(buf.Bytes())
+	}
+
+	out := buf.String()
+	require.True(t, strings.HasPrefix(out, "<!DOCTYPE html>"))
+	require.Contains(t, out, `<p class="valid">Valid</p>`)
+	require.Contains(t, out, "<h2>Batch 0000001 WEB</h2>")
+	require.Contains(t, out, "R01 Insufficient Funds")
+	require.Contains(t, out, `<td class="amount">$123.54</td>`)
+	require.Contains(t, out, "<td>*****6789</td>")
+	require.NotContains(t, out, "Paul")
+	require.Contains(t, out, "<th>Total Credits</th><td>$45.65</td>")
+}
+
+func TestHTML__Invalid(t *testing.T) {
+	file, err := ach.ReadFile(filepath.Join("..", "test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	file.Header.ImmediateOrigin = ""
+
+	var buf bytes.Buffer
+	require.NoError(t, HTML(&buf, file, nil))
+	require.Contains(t, buf.String(), `<p class="invalid">Invalid</p>`)
+	require.Contains(t, buf.String(), "<li>ImmediateOrigin")
+}
+
+func TestPDF(t *testing.T) {
+	file, err := ach.ReadFile(filepath.Join("..", "test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, PDF(&buf, file, nil))
+
+	out := buf.String()
+	require.True(t, strings.HasPrefix(out, "%PDF-1.4\n"))
+	require.True(t, strings.HasSuffix(out, "%%EOF\n"))
+	require.Contains(t, out, "/Count 1 >>")
+	require.Contains(t, out, "(Validation: Valid) Tj")
+	require.Contains(t, out, "$1,000,000.00")
+	require.NotContains(t, out, "12345678")
+
+	// Every xref offset points at the start of its object
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(out)
+	require.Len(t, match, 2)
+	xref, err := strconv.Atoi(match[1])
+	require.NoError(t, err)
+	entries := strings.Split(strings.TrimSpace(out[xref:strings.Index(out, "trailer")]), "\n")[3:]
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(entry[:10])
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(out[offset:], strconv.Itoa(i+1)+" 0 obj"), "object %d", i+1)
+	}
+}
+
+func TestPDF__Pages(t *testing.T) {
+	lines := make([]string, pdfLinesPerPage*2+1)
+	for i := range lines {
+		lines[i] = "line (" + strconv.Itoa(i) + ")"
+	}
+	pages := paginate(lines)
+	require.Len(t, pages, 3)
+	require.Len(t, pages[2], 1)
+
+	var buf bytes.Buffer
+	require.NoError(t, writePDF(&buf, pages))
+	require.Contains(t, buf.String(), "/Kids [4 0 R 6 0 R 8 0 R] /Count 3")
+	require.Contains(t, buf.String(), `(line \(0\)) Tj`)
+}
+
+func TestPDFEscape(t *testing.T) {
+	require.Equal(t, `a\(b\)c\\d?`, pdfEscape(`a(b)c\dé`))
+}
@@ -9,8 +9,8 @@ import (
 	"time"
 
 	"github.com/moov-io/ach"
-	"github.com/moov-io/ach/cmd/achcli/describe"
-	"github.com/moov-io/ach/cmd/achcli/describe/mask"
+	"github.com/moov-io/ach/describe"
+	"github.com/moov-io/ach/describe/mask"
 )
 
 func parseACH(input string) (string, error) {
@@ -143,15 +143,24 @@ func parseFile(input string) (*ach.File, error) {
 	return &file, nil
 }
 
+// reverseFile reverses every entry of a file, or only the entries whose trace numbers are given
+// as an optional third argument of comma or space separated trace numbers.
 func reverseFile() js.Func {
 	return js.FuncOf(func(_ js.Value, args []js.Value) interface{} {
-		if len(args) != 2 {
+		if len(args) != 2 && len(args) != 3 {
 			return fmt.Sprintf("Unexpected number of arguments, got %d", len(args))
 		}
 
 		input := args[0].String()
 		isodate := args[1].String()
 
+		var traceNumbers []string
+		if len(args) == 3 && args[2].Type() == js.TypeString {
+			traceNumbers = strings.FieldsFunc(args[2].String(), func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\n'
+			})
+		}
+
 		if len(isodate) > len(time.RFC3339) {
 			isodate = isodate[:len(time.RFC3339)]
 		}
@@ -166,7 +175,11 @@ func reverseFile() js.Func {
 			return err.Error()
 		}
 
-		err = file.Reversal(when)
+		if len(traceNumbers) > 0 {
+			file, err = file.ReverseEntries(traceNumbers, when)
+		} else {
+			err = file.Reversal(when)
+		}
 		if err != nil {
 			return fmt.Sprintf("reversing file failed: %v", err)
 		}
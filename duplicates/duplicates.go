@@ -0,0 +1,301 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package duplicates flags ACH files and entries which were previously originated.
+//
+// Entries are matched on their RDFI routing number, account number, amount and individual
+// identification number when their EffectiveEntryDate falls within a configurable window.
+// Exact duplicate files are found by hashing their normalized contents.
+package duplicates
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moov-io/ach"
+)
+
+// Options controls how a Detector matches entries.
+type Options struct {
+	// Window is how far apart the EffectiveEntryDate of matching entries can be.
+	// Zero requires entries to have the same EffectiveEntryDate.
+	Window time.Duration `json:"window"`
+
+	// Hash returns the hash of a File's normalized contents.
+	// The hex encoded SHA-256 hash is used when nil.
+	Hash func(data []byte) string `json:"-"`
+}
+
+// Key holds the fields used to match entries.
+type Key struct {
+	RDFIIdentification   string `json:"rdfiIdentification"`
+	DFIAccountNumber     string `json:"dfiAccountNumber"`
+	Amount               int    `json:"amount"`
+	IdentificationNumber string `json:"identificationNumber"`
+}
+
+// Entry is an entry recorded in a History.
+type Entry struct {
+	Key
+
+	FileID             string    `json:"fileID"`
+	TraceNumber        string    `json:"traceNumber"`
+	EffectiveEntryDate time.Time `json:"effectiveEntryDate"`
+}
+
+// Report describes the duplicates found for a File.
+type Report struct {
+	// FileHash is the hash of the File's normalized contents
+	FileHash string `json:"fileHash"`
+
+	// DuplicateFile is true when a previous File had identical normalized contents
+	DuplicateFile bool `json:"duplicateFile"`
+
+	// DuplicateFileID is the ID of the previous File with identical normalized contents
+	DuplicateFileID string `json:"duplicateFileID,omitempty"`
+
+	// Entries are the entries which match previous entries
+	Entries []DuplicateEntry `json:"entries,omitempty"`
+}
+
+// Found returns true when the File or any of its entries are duplicates.
+func (r *Report) Found() bool {
+	if r == nil {
+		return false
+	}
+	return r.DuplicateFile || len(r.Entries) > 0
+}
+
+// DuplicateEntry is an entry which matches previously recorded entries.
+type DuplicateEntry struct {
+	BatchNumber int    `json:"batchNumber"`
+	TraceNumber string `json:"traceNumber"`
+
+	Matches []Entry `json:"matches"`
+}
+
+// Detector finds files and entries which have been recorded in a History.
+type Detector struct {
+	history History
+	opts    Options
+}
+
+// NewDetector returns a Detector which checks files against history.
+func NewDetector(history History, opts Options) *Detector {
+	return &Detector{
+		history: history,
+		opts:    opts,
+	}
+}
+
+// Check returns a Report of the duplicates found for file. The file is not recorded into the History
+// and anything recorded under the file's ID is ignored.
+func (d *Detector) Check(file *ach.File) (*Report, error) {
+	if file == nil {
+		return nil, errors.New("nil ACH file provided")
+	}
+
+	hash, err := d.hash(file)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{
+		FileHash: hash,
+	}
+
+	fileIDs, err := d.history.FindFiles(hash)
+	if err != nil {
+		return nil, fmt.Errorf("finding file: %w", err)
+	}
+	for i := range fileIDs {
+		if !sameFile(file, fileIDs[i]) {
+			report.DuplicateFile = true
+			report.DuplicateFileID = fileIDs[i]
+			break
+		}
+	}
+
+	for _, entry := range entries(file) {
+		previous, err := d.history.FindEntries(entry.Key)
+		if err != nil {
+			return nil, fmt.Errorf("finding entries: %w", err)
+		}
+
+		var matches []Entry
+		for i := range previous {
+			if sameFile(file, previous[i].FileID) {
+				continue
+			}
+			if d.withinWindow(entry.EffectiveEntryDate, previous[i].EffectiveEntryDate) {
+				matches = append(matches, previous[i])
+			}
+		}
+		if len(matches) > 0 {
+			report.Entries = append(report.Entries, DuplicateEntry{
+				BatchNumber: entry.batchNumber,
+				TraceNumber: entry.TraceNumber,
+				Matches:     matches,
+			})
+		}
+	}
+	return report, nil
+}
+
+// Record saves file and its entries into the History so later files can be checked against it.
+// Anything previously recorded under the file's ID is replaced when the ID is set.
+func (d *Detector) Record(file *ach.File) error {
+	if file == nil {
+		return errors.New("nil ACH file provided")
+	}
+
+	hash, err := d.hash(file)
+	if err != nil {
+		return err
+	}
+
+	found := entries(file)
+	out := make([]Entry, len(found))
+	for i := range found {
+		out[i] = found[i].Entry
+	}
+	return d.history.Save(file.ID, hash, out)
+}
+
+func (d *Detector) withinWindow(a, b time.Time) bool {
+	diff := a.Sub(b)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= d.opts.Window
+}
+
+func (d *Detector) hash(file *ach.File) (string, error) {
+	if d.opts.Hash == nil {
+		return Hash(file)
+	}
+	data, err := normalize(file)
+	if err != nil {
+		return "", err
+	}
+	return d.opts.Hash(data), nil
+}
+
+func sameFile(file *ach.File, fileID string) bool {
+	return file.ID != "" && file.ID == fileID
+}
+
+// Hash returns the hex encoded SHA-256 hash of a File's normalized contents.
+//
+// Files are normalized by ignoring the FileHeader's creation date, time and FileIDModifier so a file
+// which is regenerated and sent again has the same hash.
+func Hash(file *ach.File) (string, error) {
+	data, err := normalize(file)
+	if err != nil {
+		return "", err
+	}
+	ss := sha256.New()
+	ss.Write(data)
+	return hex.EncodeToString(ss.Sum(nil)), nil
+}
+
+func normalize(file *ach.File) ([]byte, error) {
+	if file == nil {
+		return nil, errors.New("nil ACH file provided")
+	}
+
+	var buf bytes.Buffer
+	w := ach.NewWriter(&buf)
+	w.BypassValidation = true
+	if err := w.Write(file); err != nil {
+		return nil, fmt.Errorf("writing file for hashing: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.ReplaceAll(buf.String(), "\r\n", "\n"), "\n")
+	for i := len(file.Preamble); i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "1") {
+			// Replace the FileHeader with only its routing fields
+			lines[i] = strings.TrimSpace(file.Header.ImmediateDestination) + strings.TrimSpace(file.Header.ImmediateOrigin)
+			break
+		}
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+type fileEntry struct {
+	Entry
+
+	batchNumber int
+}
+
+func entries(file *ach.File) []fileEntry {
+	var out []fileEntry
+	for _, batch := range file.Batches {
+		bh := batch.GetHeader()
+		eed := parseEffectiveEntryDate(bh.EffectiveEntryDate)
+
+		for _, entry := range batch.GetEntries() {
+			out = append(out, fileEntry{
+				Entry: Entry{
+					Key: Key{
+						RDFIIdentification:   entry.RDFIIdentification,
+						DFIAccountNumber:     strings.TrimSpace(entry.DFIAccountNumber),
+						Amount:               entry.Amount,
+						IdentificationNumber: strings.TrimSpace(entry.IdentificationNumber),
+					},
+					FileID:             file.ID,
+					TraceNumber:        entry.TraceNumber,
+					EffectiveEntryDate: eed,
+				},
+				batchNumber: bh.BatchNumber,
+			})
+		}
+	}
+	for _, batch := range file.IATBatches {
+		bh := batch.GetHeader()
+		eed := parseEffectiveEntryDate(bh.EffectiveEntryDate)
+
+		for _, entry := range batch.GetEntries() {
+			out = append(out, fileEntry{
+				Entry: Entry{
+					Key: Key{
+						RDFIIdentification: entry.RDFIIdentification,
+						DFIAccountNumber:   strings.TrimSpace(entry.DFIAccountNumber),
+						Amount:             entry.Amount,
+					},
+					FileID:             file.ID,
+					TraceNumber:        entry.TraceNumber,
+					EffectiveEntryDate: eed,
+				},
+				batchNumber: bh.BatchNumber,
+			})
+		}
+	}
+	return out
+}
+
+func parseEffectiveEntryDate(value string) time.Time {
+	tt, _ := time.Parse("060102", value)
+	return tt
+}
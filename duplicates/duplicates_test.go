@@ -0,0 +1,193 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package duplicates
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readFile(t *testing.T, name string) *ach.File {
+	t.Helper()
+
+	file, err := ach.ReadFile(filepath.Join("..", "test", "testdata", name))
+	require.NoError(t, err)
+	return file
+}
+
+func TestDetector(t *testing.T) {
+	detector := NewDetector(NewMemoryHistory(), Options{})
+
+	first := readFile(t, "ppd-debit.ach")
+	first.ID = "first"
+
+	report, err := detector.Check(first)
+	require.NoError(t, err)
+	require.False(t, report.Found())
+	require.NotEmpty(t, report.FileHash)
+
+	require.NoError(t, detector.Record(first))
+
+	// The same file regenerated later is an exact duplicate
+	second := readFile(t, "ppd-debit.ach")
+	second.ID = "second"
+	second.Header.FileCreationDate = "261018"
+	second.Header.FileCreationTime = "1200"
+	second.Header.FileIDModifier = "B"
+
+	report, err = detector.Check(second)
+	require.NoError(t, err)
+	require.True(t, report.Found())
+	require.True(t, report.DuplicateFile)
+	require.Equal(t, "first", report.DuplicateFileID)
+
+	require.Len(t, report.Entries, 1)
+	require.Equal(t, second.Batches[0].GetEntries()[0].TraceNumber, report.Entries[0].TraceNumber)
+	require.Equal(t, 1, report.Entries[0].BatchNumber)
+	require.Len(t, report.Entries[0].Matches, 1)
+	require.Equal(t, "first", report.Entries[0].Matches[0].FileID)
+}
+
+func TestDetector__Window(t *testing.T) {
+	history := NewMemoryHistory()
+
+	first := readFile(t, "ppd-debit.ach")
+	first.ID = "first"
+	require.NoError(t, NewDetector(history, Options{}).Record(first))
+
+	second := readFile(t, "ppd-debit.ach")
+	eed, err := time.Parse("060102", second.Batches[0].GetHeader().EffectiveEntryDate)
+	require.NoError(t, err)
+	second.Batches[0].GetHeader().EffectiveEntryDate = eed.AddDate(0, 0, 2).Format("060102")
+
+	// Different EffectiveEntryDate
+	report, err := NewDetector(history, Options{}).Check(second)
+	require.NoError(t, err)
+	require.False(t, report.DuplicateFile)
+	require.Empty(t, report.Entries)
+
+	// Within the window
+	report, err = NewDetector(history, Options{Window: 72 * time.Hour}).Check(second)
+	require.NoError(t, err)
+	require.False(t, report.DuplicateFile)
+	require.Len(t, report.Entries, 1)
+
+	// Different amount
+	second.Batches[0].GetEntries()[0].Amount += 1
+	report, err = NewDetector(history, Options{Window: 72 * time.Hour}).Check(second)
+	require.NoError(t, err)
+	require.False(t, report.Found())
+}
+
+func TestDetector__IAT(t *testing.T) {
+	detector := NewDetector(NewMemoryHistory(), Options{})
+
+	file := readFile(t, "iat-debit.ach")
+	require.NoError(t, detector.Record(file))
+
+	report, err := detector.Check(file)
+	require.NoError(t, err)
+	require.True(t, report.DuplicateFile)
+	require.Len(t, report.Entries, len(file.IATBatches[0].Entries))
+}
+
+func TestDetector__Errors(t *testing.T) {
+	detector := NewDetector(NewMemoryHistory(), Options{})
+
+	_, err := detector.Check(nil)
+	require.Error(t, err)
+
+	require.Error(t, detector.Record(nil))
+
+	_, err = Hash(nil)
+	require.Error(t, err)
+}
+
+func TestDetector__Preamble(t *testing.T) {
+	first := readFile(t, "ppd-debit.ach")
+	expected, err := Hash(first)
+	require.NoError(t, err)
+
+	second := readFile(t, "ppd-debit.ach")
+	second.Preamble = []string{"$$ADD ID=USER1 BID='NWFACH1234'"}
+	second.Header.FileCreationDate = "261018"
+
+	hash, err := Hash(second)
+	require.NoError(t, err)
+	require.NotEqual(t, expected, hash)
+
+	// Only the FileHeader is normalized, not the preamble
+	second.Preamble = nil
+	hash, err = Hash(second)
+	require.NoError(t, err)
+	require.Equal(t, expected, hash)
+}
+
+func TestDetector__SameFileID(t *testing.T) {
+	history := NewMemoryHistory()
+	detector := NewDetector(history, Options{})
+
+	first := readFile(t, "ppd-debit.ach")
+	first.ID = "first"
+	require.NoError(t, detector.Record(first))
+
+	// A file isn't a duplicate of itself
+	report, err := detector.Check(first)
+	require.NoError(t, err)
+	require.False(t, report.Found())
+
+	second := readFile(t, "ppd-debit.ach")
+	second.ID = "second"
+	require.NoError(t, detector.Record(second))
+
+	report, err = detector.Check(first)
+	require.NoError(t, err)
+	require.Equal(t, "second", report.DuplicateFileID)
+	require.Len(t, report.Entries, 1)
+	require.Len(t, report.Entries[0].Matches, 1)
+
+	// Recording again replaces the previous entries
+	second.Batches[0].GetEntries()[0].Amount += 1
+	require.NoError(t, detector.Record(second))
+
+	report, err = detector.Check(first)
+	require.NoError(t, err)
+	require.False(t, report.Found())
+
+	require.NoError(t, history.Remove("first"))
+	fileIDs, err := history.FindFiles(report.FileHash)
+	require.NoError(t, err)
+	require.Empty(t, fileIDs)
+}
+
+func TestDetector__Hash(t *testing.T) {
+	detector := NewDetector(NewMemoryHistory(), Options{
+		Hash: func(data []byte) string {
+			return "custom"
+		},
+	})
+
+	report, err := detector.Check(readFile(t, "ppd-debit.ach"))
+	require.NoError(t, err)
+	require.Equal(t, "custom", report.FileHash)
+}
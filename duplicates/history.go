@@ -0,0 +1,121 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package duplicates
+
+import (
+	"slices"
+	"sync"
+)
+
+// History stores the hashes and entries of previously recorded files.
+type History interface {
+	// FindFiles returns the IDs of recorded files with hash in the order they were saved.
+	FindFiles(hash string) ([]string, error)
+
+	// FindEntries returns every recorded entry which matches key.
+	FindEntries(key Key) ([]Entry, error)
+
+	// Save records the hash and entries of a file, replacing anything previously saved for a non-empty fileID.
+	Save(fileID, hash string, entries []Entry) error
+
+	// Remove deletes the hash and entries saved for fileID.
+	Remove(fileID string) error
+}
+
+// NewMemoryHistory returns a History which is kept in memory.
+func NewMemoryHistory() History {
+	return &memoryHistory{
+		hashes:  make(map[string]string),
+		files:   make(map[string][]string),
+		entries: make(map[Key][]Entry),
+	}
+}
+
+type memoryHistory struct {
+	mu sync.RWMutex
+
+	hashes  map[string]string   // fileID to hash
+	files   map[string][]string // hash to fileIDs
+	entries map[Key][]Entry
+}
+
+func (h *memoryHistory) FindFiles(hash string) ([]string, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	found := h.files[hash]
+	out := make([]string, len(found))
+	copy(out, found)
+	return out, nil
+}
+
+func (h *memoryHistory) FindEntries(key Key) ([]Entry, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	found := h.entries[key]
+	out := make([]Entry, len(found))
+	copy(out, found)
+	return out, nil
+}
+
+func (h *memoryHistory) Save(fileID, hash string, entries []Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if fileID != "" {
+		h.remove(fileID)
+		h.hashes[fileID] = hash
+	}
+	h.files[hash] = append(h.files[hash], fileID)
+	for i := range entries {
+		h.entries[entries[i].Key] = append(h.entries[entries[i].Key], entries[i])
+	}
+	return nil
+}
+
+func (h *memoryHistory) Remove(fileID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(fileID)
+	return nil
+}
+
+func (h *memoryHistory) remove(fileID string) {
+	hash, exists := h.hashes[fileID]
+	if !exists {
+		return
+	}
+	delete(h.hashes, fileID)
+
+	h.files[hash] = slices.DeleteFunc(h.files[hash], func(id string) bool {
+		return id == fileID
+	})
+	if len(h.files[hash]) == 0 {
+		delete(h.files, hash)
+	}
+	for key := range h.entries {
+		h.entries[key] = slices.DeleteFunc(h.entries[key], func(e Entry) bool {
+			return e.FileID == fileID
+		})
+		if len(h.entries[key]) == 0 {
+			delete(h.entries, key)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ReturnDetails annotates a ReturnCode with how the Nacha Operating Rules treat returns using it.
+type ReturnDetails struct {
+	ReturnCode
+
+	// Unauthorized is true for returns where the Receiver claims the entry was not authorized (R05, R07, R10, R11, R29 and R51)
+	Unauthorized bool `json:"unauthorized"`
+	// Administrative is true for returns caused by account information (R02, R03 and R04)
+	Administrative bool `json:"administrative"`
+	// Timeframe is how long after the settlement date of the original entry the return must be received
+	Timeframe string `json:"timeframe"`
+	// Dishonorable is true when the ODFI may dishonor the return, which excludes dishonored and contested returns
+	Dishonorable bool `json:"dishonorable"`
+}
+
+// ChangeDetails annotates a ChangeCode with the decoded corrected data of a Notification of Change.
+type ChangeDetails struct {
+	ChangeCode
+
+	CorrectedData *CorrectedData `json:"correctedData,omitempty"`
+}
+
+const (
+	ReturnTimeframeTwoBankingDays    = "2 banking days"
+	ReturnTimeframeFiveBankingDays   = "5 banking days"
+	ReturnTimeframeSixtyCalendarDays = "60 calendar days"
+)
+
+var (
+	unauthorizedReturnCodes   = []string{"R05", "R07", "R10", "R11", "R29", "R51"}
+	administrativeReturnCodes = []string{"R02", "R03", "R04"}
+	extendedReturnCodes       = []string{"R05", "R07", "R10", "R11", "R33", "R37", "R38", "R51", "R52", "R53"}
+)
+
+// LookupReturnDetails returns the ReturnDetails for a return code or nil if the code is unknown.
+func LookupReturnDetails(code string) *ReturnDetails {
+	rc := LookupReturnCode(code)
+	if rc == nil {
+		return nil
+	}
+	details := &ReturnDetails{
+		ReturnCode:     *rc,
+		Unauthorized:   slices.Contains(unauthorizedReturnCodes, rc.Code),
+		Administrative: slices.Contains(administrativeReturnCodes, rc.Code),
+		Timeframe:      ReturnTimeframeTwoBankingDays,
+		Dishonorable:   true,
+	}
+	if slices.Contains(extendedReturnCodes, rc.Code) {
+		details.Timeframe = ReturnTimeframeSixtyCalendarDays
+	}
+	if n, err := strconv.Atoi(strings.TrimPrefix(rc.Code, "R")); err == nil && n >= 61 && n <= 77 {
+		// Dishonored (R61-R70) and contested (R71-R77) returns can't be dishonored again
+		details.Dishonorable = false
+		if n <= 70 {
+			details.Timeframe = ReturnTimeframeFiveBankingDays
+		}
+	}
+	return details
+}
+
+// LookupChangeDetails returns the ChangeDetails of an Addenda98 or nil if its ChangeCode is unknown.
+// CorrectedData is nil when it can't be decoded.
+func LookupChangeDetails(addenda98 *Addenda98) *ChangeDetails {
+	if addenda98 == nil {
+		return nil
+	}
+	cc := LookupChangeCode(addenda98.ChangeCode)
+	if cc == nil {
+		return nil
+	}
+	return &ChangeDetails{
+		ChangeCode:    *cc,
+		CorrectedData: addenda98.ParseCorrectedData(),
+	}
+}
+
+// Enrich sets ReturnDetails on each Addenda99 and ChangeDetails on each Addenda98 in the File
+// so they're included when the File is encoded as JSON. The Nacha formatted file is unchanged.
+func (f *File) Enrich() {
+	if f == nil {
+		return
+	}
+	for _, b := range f.Batches {
+		for _, entry := range b.GetEntries() {
+			entry.Addenda98.enrich()
+			entry.Addenda99.enrich()
+		}
+	}
+	for _, b := range f.IATBatches {
+		for _, entry := range b.GetEntries() {
+			entry.Addenda98.enrich()
+			entry.Addenda99.enrich()
+		}
+	}
+}
+
+func (addenda98 *Addenda98) enrich() {
+	if addenda98 != nil {
+		addenda98.ChangeDetails = LookupChangeDetails(addenda98)
+	}
+}
+
+func (addenda99 *Addenda99) enrich() {
+	if addenda99 != nil {
+		addenda99.ReturnDetails = LookupReturnDetails(addenda99.ReturnCode)
+	}
+}
@@ -0,0 +1,119 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupReturnDetails(t *testing.T) {
+	cases := []struct {
+		code           string
+		unauthorized   bool
+		administrative bool
+		timeframe      string
+		dishonorable   bool
+	}{
+		{"R01", false, false, ReturnTimeframeTwoBankingDays, true},
+		{"r03", false, true, ReturnTimeframeTwoBankingDays, true},
+		{"R10", true, false, ReturnTimeframeSixtyCalendarDays, true},
+		{"R29", true, false, ReturnTimeframeTwoBankingDays, true},
+		{"R37", false, false, ReturnTimeframeSixtyCalendarDays, true},
+		{"R61", false, false, ReturnTimeframeFiveBankingDays, false},
+		{"R71", false, false, ReturnTimeframeTwoBankingDays, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.code, func(t *testing.T) {
+			details := LookupReturnDetails(tc.code)
+			require.NotNil(t, details)
+			require.Equal(t, strings.ToUpper(tc.code), details.Code)
+			require.NotEmpty(t, details.Reason)
+			require.Equal(t, tc.unauthorized, details.Unauthorized)
+			require.Equal(t, tc.administrative, details.Administrative)
+			require.Equal(t, tc.timeframe, details.Timeframe)
+			require.Equal(t, tc.dishonorable, details.Dishonorable)
+		})
+	}
+
+	require.Nil(t, LookupReturnDetails("R99"))
+}
+
+func TestLookupChangeDetails(t *testing.T) {
+	addenda98 := NewAddenda98()
+	addenda98.ChangeCode = "C01"
+	addenda98.CorrectedData = "1918171614"
+
+	details := LookupChangeDetails(addenda98)
+	require.NotNil(t, details)
+	require.Equal(t, "Incorrect bank account number", details.Reason)
+	require.Equal(t, &CorrectedData{AccountNumber: "1918171614"}, details.CorrectedData)
+
+	addenda98.ChangeCode = "C99"
+	require.Nil(t, LookupChangeDetails(addenda98))
+	require.Nil(t, LookupChangeDetails(nil))
+}
+
+func TestFile__Enrich(t *testing.T) {
+	t.Run("returns", func(t *testing.T) {
+		file, err := readACHFilepath(filepath.Join("test", "testdata", "return-WEB.ach"))
+		require.NoError(t, err)
+		file.Enrich()
+
+		bs, err := json.Marshal(file)
+		require.NoError(t, err)
+		require.Contains(t, string(bs), `"returnDetails":{"code":"R01","reason":"Insufficient Funds"`)
+
+		entries := file.Batches[0].GetEntries()
+		require.Equal(t, "R01", entries[0].Addenda99.ReturnDetails.Code)
+		require.True(t, entries[0].Addenda99.ReturnDetails.Dishonorable)
+	})
+
+	t.Run("NOCs", func(t *testing.T) {
+		file, err := readACHFilepath(filepath.Join("test", "testdata", "cor-example.ach"))
+		require.NoError(t, err)
+		file.Enrich()
+
+		bs, err := json.Marshal(file)
+		require.NoError(t, err)
+		require.Contains(t, string(bs), `"correctedData":{"accountNumber":"1918171614"}`)
+	})
+
+	t.Run("IAT", func(t *testing.T) {
+		file, err := readACHFilepath(filepath.Join("test", "testdata", "iat-addenda99.ach"))
+		require.NoError(t, err)
+		file.Enrich()
+
+		details := file.IATBatches[0].GetEntries()[0].Addenda99.ReturnDetails
+		require.NotNil(t, details)
+		require.Equal(t, "R01", details.Code)
+	})
+
+	t.Run("not enriched", func(t *testing.T) {
+		file, err := readACHFilepath(filepath.Join("test", "testdata", "return-WEB.ach"))
+		require.NoError(t, err)
+
+		bs, err := json.Marshal(file)
+		require.NoError(t, err)
+		require.NotContains(t, string(bs), "returnDetails")
+	})
+}
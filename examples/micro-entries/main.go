@@ -7,7 +7,7 @@ import (
 	"time"
 
 	"github.com/moov-io/ach"
-	"github.com/moov-io/ach/cmd/achcli/describe"
+	"github.com/moov-io/ach/describe"
 )
 
 func main() {
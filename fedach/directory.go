@@ -0,0 +1,203 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package fedach reads the FedACH participant directory published by the Federal Reserve, which lists the
+// financial institutions that can receive ACH entries.
+//
+// The directory is read from the fixed-width FedACHdir.txt format, where each 155 character line describes
+// one routing number.
+package fedach
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var (
+	// ErrNotParticipant is returned for routing numbers which aren't in the directory.
+	ErrNotParticipant = errors.New("not a FedACH participant")
+
+	// ErrRoutingNumberChanged is returned for routing numbers whose institution receives entries at a new routing number.
+	ErrRoutingNumberChanged = errors.New("routing number has changed")
+)
+
+const (
+	// RecordTypeFederalReserveBank is the RecordTypeCode of a Federal Reserve Bank.
+	RecordTypeFederalReserveBank = "0"
+
+	// RecordTypeCustomer is the RecordTypeCode of institutions which receive entries at their RoutingNumber.
+	RecordTypeCustomer = "1"
+
+	// RecordTypeNewRoutingNumber is the RecordTypeCode of institutions which receive entries at their NewRoutingNumber.
+	RecordTypeNewRoutingNumber = "2"
+)
+
+// minLineLength is the length of a line through the InstitutionStatusCode, lines are often trimmed after it.
+const minLineLength = 149
+
+// Participant is a financial institution listed in the FedACH directory.
+type Participant struct {
+	// RoutingNumber is the nine digit routing number of the institution
+	RoutingNumber string `json:"routingNumber"`
+
+	// OfficeCode is O for a main office and B for a branch
+	OfficeCode string `json:"officeCode"`
+
+	// ServicingFRBNumber is the routing number of the Federal Reserve Bank servicing the institution
+	ServicingFRBNumber string `json:"servicingFRBNumber"`
+
+	// RecordTypeCode is one of RecordTypeFederalReserveBank, RecordTypeCustomer or RecordTypeNewRoutingNumber
+	RecordTypeCode string `json:"recordTypeCode"`
+
+	// ChangeDate is when the record was last changed, formatted MMDDYY
+	ChangeDate string `json:"changeDate"`
+
+	// NewRoutingNumber is where entries are sent when RecordTypeCode is RecordTypeNewRoutingNumber
+	NewRoutingNumber string `json:"newRoutingNumber,omitempty"`
+
+	CustomerName string `json:"customerName"`
+	Address      string `json:"address"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	PostalCode   string `json:"postalCode"`
+	PhoneNumber  string `json:"phoneNumber"`
+
+	// StatusCode is the institution status code, 1 for institutions receiving government and commercial entries
+	StatusCode string `json:"statusCode"`
+
+	// ViewCode is the data view code of the record
+	ViewCode string `json:"viewCode"`
+}
+
+// Directory is the set of FedACH participants, indexed by routing number.
+type Directory struct {
+	Participants []*Participant
+
+	byRoutingNumber map[string]*Participant
+}
+
+// ReadFile reads the FedACH directory file at path.
+func ReadFile(path string) (*Directory, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	dir, err := Read(fd)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return dir, nil
+}
+
+// Read parses a FedACH directory in the fixed-width FedACHdir.txt format. Blank lines are skipped.
+func Read(r io.Reader) (*Directory, error) {
+	dir := &Directory{
+		byRoutingNumber: make(map[string]*Participant),
+	}
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		participant, err := parseParticipant(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		dir.Participants = append(dir.Participants, participant)
+		dir.byRoutingNumber[participant.RoutingNumber] = participant
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return dir, nil
+}
+
+func parseParticipant(line string) (*Participant, error) {
+	if len(line) < minLineLength {
+		return nil, fmt.Errorf("line is %d characters, expected at least %d", len(line), minLineLength)
+	}
+	line += strings.Repeat(" ", max(0, 155-len(line)))
+
+	field := func(start, end int) string {
+		return strings.TrimSpace(line[start:end])
+	}
+	p := &Participant{
+		RoutingNumber:      field(0, 9),
+		OfficeCode:         field(9, 10),
+		ServicingFRBNumber: field(10, 19),
+		RecordTypeCode:     field(19, 20),
+		ChangeDate:         field(20, 26),
+		CustomerName:       field(35, 71),
+		Address:            field(71, 107),
+		City:               field(107, 127),
+		State:              field(127, 129),
+		PostalCode:         strings.TrimSuffix(field(129, 134)+"-"+field(134, 138), "-"),
+		PhoneNumber:        field(138, 141) + field(141, 144) + field(144, 148),
+		StatusCode:         field(148, 149),
+		ViewCode:           field(149, 150),
+	}
+	if newRoutingNumber := field(26, 35); strings.Trim(newRoutingNumber, "0") != "" {
+		p.NewRoutingNumber = newRoutingNumber // zeros when the routing number hasn't changed
+	}
+	if !isRoutingNumber(p.RoutingNumber) {
+		return nil, fmt.Errorf("invalid routing number %q", p.RoutingNumber)
+	}
+	return p, nil
+}
+
+func isRoutingNumber(s string) bool {
+	if len(s) != 9 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Get returns the participant with routingNumber, or nil if it's not in the directory.
+func (d *Directory) Get(routingNumber string) *Participant {
+	if d == nil {
+		return nil
+	}
+	return d.byRoutingNumber[routingNumber]
+}
+
+// CheckRDFI returns an error if routingNumber can't receive ACH entries. Routing numbers missing from the
+// directory return ErrNotParticipant and those replaced by a new routing number return ErrRoutingNumberChanged.
+//
+// CheckRDFI can be used as the CheckRDFI function of ach.ValidateOpts.
+func (d *Directory) CheckRDFI(routingNumber string) error {
+	p := d.Get(routingNumber)
+	if p == nil {
+		return ErrNotParticipant
+	}
+	if p.RecordTypeCode == RecordTypeNewRoutingNumber {
+		return fmt.Errorf("%w to %s", ErrRoutingNumberChanged, p.NewRoutingNumber)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fedach
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testDirectoryPath = filepath.Join("..", "test", "testdata", "fedach", "FedACHdir.txt")
+
+func TestReadFile(t *testing.T) {
+	dir, err := ReadFile(testDirectoryPath)
+	require.NoError(t, err)
+	require.Len(t, dir.Participants, 4)
+
+	p := dir.Get("231380104")
+	require.NotNil(t, p)
+	require.Equal(t, &Participant{
+		RoutingNumber:      "231380104",
+		OfficeCode:         "O",
+		ServicingFRBNumber: "031000040",
+		RecordTypeCode:     RecordTypeCustomer,
+		ChangeDate:         "120911",
+		CustomerName:       "CITADEL FCU",
+		Address:            "520 EAGLEVIEW BLVD",
+		City:               "EXTON",
+		State:              "PA",
+		PostalCode:         "19341-0000",
+		PhoneNumber:        "6103809200",
+		StatusCode:         "1",
+		ViewCode:           "1",
+	}, p)
+
+	require.Equal(t, "091000022", dir.Get("091400046").NewRoutingNumber)
+	require.Nil(t, dir.Get("123456780"))
+
+	var empty *Directory
+	require.Nil(t, empty.Get("231380104"))
+
+	_, err = ReadFile(filepath.Join("testdata", "missing.txt"))
+	require.Error(t, err)
+}
+
+func TestRead__Errors(t *testing.T) {
+	_, err := Read(strings.NewReader("231380104O031000040"))
+	require.ErrorContains(t, err, "line 1: line is 19 characters, expected at least 149")
+
+	line := "ABC380104" + strings.Repeat(" ", 146)
+	_, err = Read(strings.NewReader("\n" + line))
+	require.ErrorContains(t, err, `line 2: invalid routing number "ABC380104"`)
+}
+
+func TestCheckRDFI(t *testing.T) {
+	dir, err := ReadFile(testDirectoryPath)
+	require.NoError(t, err)
+
+	require.NoError(t, dir.CheckRDFI("121042882"))
+	require.NoError(t, dir.CheckRDFI("011000015"))
+	require.ErrorIs(t, dir.CheckRDFI("121042881"), ErrNotParticipant)
+
+	err = dir.CheckRDFI("091400046")
+	require.ErrorIs(t, err, ErrRoutingNumberChanged)
+	require.ErrorContains(t, err, "routing number has changed to 091000022")
+}
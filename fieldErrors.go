@@ -27,6 +27,8 @@ var (
 
 	//ErrNonAlphanumeric is given when a field has non-alphanumeric characters
 	ErrNonAlphanumeric = errors.New("has non alphanumeric characters")
+	// ErrNonNumeric is given when a numeric field has characters other than 0-9
+	ErrNonNumeric = errors.New("has non numeric characters")
 	//ErrUpperAlpha is given when a field is not in uppercase
 	ErrUpperAlpha = errors.New("is not uppercase A-Z or 0-9")
 	//ErrFieldInclusion is given when a field is mandatory and has a default value
@@ -104,12 +106,20 @@ var (
 	ErrForeignExchangeIndicator = errors.New("is an invalid Foreign Exchange Indicator")
 	// ErrForeignExchangeReferenceIndicator is given when there's an invalid foreign exchange reference indicator
 	ErrForeignExchangeReferenceIndicator = errors.New("is an invalid Foreign Exchange Reference Indicator")
+	// ErrForeignExchangeCurrency is given when the currency codes of an IAT batch don't agree with its foreign exchange indicator
+	ErrForeignExchangeCurrency = errors.New("does not agree with the Foreign Exchange Indicator")
+	// ErrForeignExchangeReference is given when the foreign exchange reference doesn't agree with its indicator
+	ErrForeignExchangeReference = errors.New("does not agree with the Foreign Exchange Reference Indicator")
+	// ErrExchangeRateNotFound is given when no foreign exchange rate exists for a currency pair
+	ErrExchangeRateNotFound = errors.New("no foreign exchange rate found")
 	// ErrTransactionTypeCode is given when there's an invalid transaction type code
 	ErrTransactionTypeCode = errors.New("is an invalid Addenda10 Transaction Type Code")
 	// ErrIDNumberQualifier is given when there's an invalid identification number qualifier
 	ErrIDNumberQualifier = errors.New("is an invalid Identification Number Qualifier")
 	// ErrIATBatchAddendaIndicator is given when there's an invalid addenda record for an IAT batch
 	ErrIATBatchAddendaIndicator = errors.New("is invalid for addenda record(s) found")
+	// ErrEnrolleeClassificationCode is given when an ENR addenda has an invalid enrollee classification code
+	ErrEnrolleeClassificationCode = errors.New("is an invalid Enrollee Classification Code")
 )
 
 // FieldError is returned for errors at a field level in a record
@@ -72,6 +72,14 @@ type File struct {
 	// ReturnEntries is a slice of references to file.Batches that contain return entries
 	ReturnEntries []Batcher `json:"ReturnEntries"`
 
+	// Preamble holds bank-specific lines before the FileHeader, such as a "$$ADD ID=" line.
+	// They're read when ValidateOpts.AllowPreambleLines is set and written before the FileHeader.
+	Preamble []string `json:"preamble,omitempty"`
+
+	// Trailer holds bank-specific lines after the FileControl. They're read when
+	// ValidateOpts.AllowTrailerLines is set and written after the FileControl and block padding.
+	Trailer []string `json:"trailer,omitempty"`
+
 	validateOpts *ValidateOpts
 }
 
@@ -85,6 +93,9 @@ func NewFile() *File {
 
 type file struct {
 	ID string `json:"id"`
+
+	Preamble []string `json:"preamble"`
+	Trailer  []string `json:"trailer"`
 }
 
 type fileHeader struct {
@@ -163,6 +174,7 @@ func FileFromJSONWith(bs []byte, opts *ValidateOpts) (*File, error) {
 		return nil, fmt.Errorf("problem reading File: %v", err)
 	}
 	out.ID = f.ID
+	out.Preamble, out.Trailer = f.Preamble, f.Trailer
 
 	// Read FileHeader
 	header := fileHeader{
@@ -853,6 +865,19 @@ func (f *File) AddIATBatch(iatBatch IATBatch) []IATBatch {
 	return f.IATBatches
 }
 
+// AllBatches returns the domestic and IAT batches of the file, in that order, as GenericBatch values.
+// IAT batches are returned as pointers into f.IATBatches so changes made through them are kept.
+func (f *File) AllBatches() []GenericBatch {
+	batches := make([]GenericBatch, 0, len(f.Batches)+len(f.IATBatches))
+	for i := range f.Batches {
+		batches = append(batches, f.Batches[i])
+	}
+	for i := range f.IATBatches {
+		batches = append(batches, &f.IATBatches[i])
+	}
+	return batches
+}
+
 // SetHeader allows for header to be built.
 func (f *File) SetHeader(h FileHeader) *File {
 	f.Header = h
@@ -917,6 +942,12 @@ type ValidateOpts struct {
 	// Note: Functions cannot be serialized into/from JSON, so this check cannot be used from config files.
 	CheckTransactionCode func(code int) error `json:"-"`
 
+	// CheckRDFI allows for custom validation of the routing number (RDFIIdentification and CheckDigit) of each
+	// entry, such as rejecting RDFIs which aren't FedACH participants with fedach.Directory's CheckRDFI.
+	//
+	// Note: Functions cannot be serialized into/from JSON, so this check cannot be used from config files.
+	CheckRDFI func(routingNumber string) error `json:"-"`
+
 	// CustomTraceNumbers disables Nacha specified checks of TraceNumbers:
 	// - Ascending order of trace numbers within batches
 	// - Trace numbers beginning with their ODFI's routing number
@@ -978,6 +1009,18 @@ type ValidateOpts struct {
 
 	// SkipBatchHeaderCompanyValidation will bypass validation of Company fields in a BatchHeader
 	SkipBatchHeaderCompanyValidation bool `json:"skipBatchHeaderCompanyValidation"`
+
+	// AllowPreambleLines keeps bank-specific lines which come before the FileHeader (or the first
+	// BatchHeader when AllowMissingFileHeader is set) in File.Preamble instead of rejecting them.
+	AllowPreambleLines bool `json:"allowPreambleLines"`
+
+	// AllowTrailerLines keeps bank-specific lines which come after the FileControl in File.Trailer
+	// instead of rejecting them. Lines of block padding are skipped.
+	AllowTrailerLines bool `json:"allowTrailerLines"`
+
+	// RequireDNEPaymentInformation checks the Addenda05 of DNE entries contains a date of death, nine digit SSN
+	// and an amount in dollars and cents rather than the placeholder text from the Nacha rules.
+	RequireDNEPaymentInformation bool `json:"requireDNEPaymentInformation"`
 }
 
 // merge will combine two ValidateOpts structs and keep any non-zero field values.
@@ -1013,6 +1056,9 @@ func (v *ValidateOpts) merge(other *ValidateOpts) *ValidateOpts {
 		BypassBatchValidation:            v.BypassBatchValidation || other.BypassBatchValidation,
 		SkipFileCreationValidation:       v.SkipFileCreationValidation || other.SkipFileCreationValidation,
 		SkipBatchHeaderCompanyValidation: v.SkipBatchHeaderCompanyValidation || other.SkipBatchHeaderCompanyValidation,
+		AllowPreambleLines:               v.AllowPreambleLines || other.AllowPreambleLines,
+		AllowTrailerLines:                v.AllowTrailerLines || other.AllowTrailerLines,
+		RequireDNEPaymentInformation:     v.RequireDNEPaymentInformation || other.RequireDNEPaymentInformation,
 	}
 
 	if v.CheckTransactionCode != nil {
@@ -1021,6 +1067,12 @@ func (v *ValidateOpts) merge(other *ValidateOpts) *ValidateOpts {
 	if other.CheckTransactionCode != nil {
 		out.CheckTransactionCode = other.CheckTransactionCode
 	}
+	if v.CheckRDFI != nil {
+		out.CheckRDFI = v.CheckRDFI
+	}
+	if other.CheckRDFI != nil {
+		out.CheckRDFI = other.CheckRDFI
+	}
 
 	return out
 }
@@ -1048,6 +1100,12 @@ func (f *File) ValidateWith(opts *ValidateOpts) error {
 		}
 	}
 
+	if opts.CheckRDFI != nil {
+		if err := f.checkRDFIs(opts.CheckRDFI); err != nil {
+			return err
+		}
+	}
+
 	if !f.IsADV() {
 		// The value of the Batch Count Field is equal to the number of Company/Batch/Header Records in the file.
 		if f.Control.BatchCount != (len(f.Batches) + len(f.IATBatches)) {
@@ -1089,6 +1147,33 @@ func (f *File) ValidateWith(opts *ValidateOpts) error {
 	return f.ValidateTotals()
 }
 
+// checkRDFIs calls check with the routing number of each entry, returning the first error.
+func (f *File) checkRDFIs(check func(routingNumber string) error) error {
+	for _, b := range f.Batches {
+		var routingNumbers []string
+		for _, entry := range b.GetEntries() {
+			routingNumbers = append(routingNumbers, entry.RDFIIdentificationField()+entry.CheckDigit)
+		}
+		for _, entry := range b.GetADVEntries() {
+			routingNumbers = append(routingNumbers, entry.RDFIIdentificationField()+entry.CheckDigit)
+		}
+		for _, routingNumber := range routingNumbers {
+			if err := check(routingNumber); err != nil {
+				return b.Error("RDFIIdentification", err, routingNumber)
+			}
+		}
+	}
+	for _, b := range f.IATBatches {
+		for _, entry := range b.GetEntries() {
+			routingNumber := entry.RDFIIdentificationField() + entry.CheckDigit
+			if err := check(routingNumber); err != nil {
+				return b.Error("RDFIIdentification", err, routingNumber)
+			}
+		}
+	}
+	return nil
+}
+
 // ValidateTotals performs checks on: 1.File entry addenda counts 2. File credit/debit totals 3. File entry hash 4. File batch count
 // ValidateTotals will also call the ValidateTotals function on all contained batches
 // ValidateTotals will never modify the File or contained Batches.
@@ -1331,11 +1416,44 @@ func (f *File) createFileADV() error {
 // SegmentFile takes a valid ACH File and returns 2 segmented ACH Files, one ACH File containing credit entries
 // and one ACH File containing debit entries.  The return is 2 Files a Credit File and Debit File, or an error.
 //
+// The SegmentFileConfiguration may only configure batch and trace numbering, use File.Segment to split
+// files by other fields or limits. Without PreserveBatchNumbers or RenumberTraceNumbers batches are split
+// as they always have been: credit and debit only batches are moved as they are and mixed batches are split
+// into new credit and debit batches.
+//
 // Callers should always check for a nil-error before using the returned file.
 //
 // The File returned may not be valid and callers should confirm with Validate. Invalid files may be rejected
 // by other Financial Institutions or ACH tools.
-func (f *File) SegmentFile(_ *SegmentFileConfiguration) (*File, *File, error) {
+func (f *File) SegmentFile(sfc *SegmentFileConfiguration) (*File, *File, error) {
+	if !sfc.CreditDebitOnly() {
+		return nil, nil, ErrSegmentFileConfiguration
+	}
+	if f.IsADV() || sfc == nil || (!sfc.PreserveBatchNumbers && !sfc.RenumberTraceNumbers) {
+		return f.segmentFileCreditDebit()
+	}
+
+	outputs, err := f.segment(sfc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	creditFile := NewFile()
+	debitFile := NewFile()
+	for _, out := range outputs {
+		switch out.direction {
+		case "C":
+			creditFile = out.file
+		case "D":
+			debitFile = out.file
+		}
+	}
+	return creditFile, debitFile, nil
+}
+
+// segmentFileCreditDebit splits batches into credit and debit Files, keeping the original batch numbers and
+// the TraceNumbers of domestic entries.
+func (f *File) segmentFileCreditDebit() (*File, *File, error) {
 	if err := f.Validate(); err != nil {
 		return nil, nil, err
 	}
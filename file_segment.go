@@ -0,0 +1,251 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/moov-io/base"
+)
+
+var (
+	// ErrSegmentADV is returned when File.Segment is called on a File containing ADV batches.
+	ErrSegmentADV = errors.New("ADV files can only be segmented with SegmentFile")
+
+	// ErrSegmentFileConfiguration is returned when File.SegmentFile is called with a SegmentFileConfiguration
+	// which produces more than a credit and debit File.
+	ErrSegmentFileConfiguration = errors.New("SegmentFileConfiguration produces more than two files, use File.Segment")
+)
+
+// Segment takes a valid ACH File and splits it into multiple Files according to the SegmentFileConfiguration.
+// Entries are grouped by credits and debits and by each configured field (SEC code, CompanyIdentification,
+// EffectiveEntryDate and ODFI). A new File is started in a group when MaxEntries or MaxAmount would be exceeded.
+//
+// Files are returned in the order their first entry appears in the original File, and batches keep
+// the order of the original batches. Each batch header is copied from the original batch and batch
+// and file controls are recalculated.
+//
+// Entries are shared with the original File unless RenumberTraceNumbers is set, which renumbers copies of them.
+//
+// Callers should always check for a nil-error before using the returned files. The Files returned may not
+// be valid and callers should confirm with Validate. Invalid files may be rejected by other Financial
+// Institutions or ACH tools.
+func (f *File) Segment(sfc *SegmentFileConfiguration) ([]*File, error) {
+	outputs, err := f.segment(sfc)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*File, len(outputs))
+	for i := range outputs {
+		out[i] = outputs[i].file
+	}
+	return out, nil
+}
+
+// segmentOutput is a File being assembled by File.segment
+type segmentOutput struct {
+	// direction is "C" for credits, "D" for debits or empty when credits and debits are mixed
+	direction string
+
+	file *File
+
+	batches    []*segmentBatch
+	bySourceID map[int]*segmentBatch
+
+	entryCount int
+	amount     int
+}
+
+// segmentBatch holds the entries of one original batch which were placed into a segmentOutput
+type segmentBatch struct {
+	header     *BatchHeader
+	entries    []*EntryDetail
+	iatHeader  *IATBatchHeader
+	iatEntries []*IATEntryDetail
+}
+
+// full returns true when adding an entry for amount would exceed the configured limits
+func (out *segmentOutput) full(sfc *SegmentFileConfiguration, amount int) bool {
+	if sfc.MaxEntries > 0 && out.entryCount+1 > sfc.MaxEntries {
+		return true
+	}
+	if sfc.MaxAmount > 0 && out.entryCount > 0 && out.amount+amount > sfc.MaxAmount {
+		return true
+	}
+	return false
+}
+
+func (out *segmentOutput) batch(sourceID int) *segmentBatch {
+	b, exists := out.bySourceID[sourceID]
+	if !exists {
+		b = &segmentBatch{}
+		out.bySourceID[sourceID] = b
+		out.batches = append(out.batches, b)
+	}
+	return b
+}
+
+func (f *File) segment(sfc *SegmentFileConfiguration) ([]*segmentOutput, error) {
+	if sfc == nil {
+		sfc = NewSegmentFileConfiguration()
+	}
+	if f.IsADV() {
+		return nil, ErrSegmentADV
+	}
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	var outputs []*segmentOutput
+	current := make(map[string]*segmentOutput)
+
+	pick := func(direction, key string, amount int) *segmentOutput {
+		if sfc.MixedCreditsAndDebits {
+			direction = ""
+		}
+		key = direction + "/" + key
+
+		out, exists := current[key]
+		if !exists || out.full(sfc, amount) {
+			out = &segmentOutput{
+				direction:  direction,
+				file:       NewFile(),
+				bySourceID: make(map[int]*segmentBatch),
+			}
+			current[key] = out
+			outputs = append(outputs, out)
+		}
+		out.entryCount++
+		out.amount += amount
+		return out
+	}
+
+	for i := range f.Batches {
+		bh := f.Batches[i].GetHeader()
+		key := sfc.segmentKey(bh.StandardEntryClassCode, bh.CompanyIdentification, bh.EffectiveEntryDate, bh.ODFIIdentification)
+
+		for _, entry := range f.Batches[i].GetEntries() {
+			b := pick(entry.CreditOrDebit(), key, entry.Amount).batch(i)
+			b.header = bh
+			b.entries = append(b.entries, entry)
+		}
+	}
+	for i := range f.IATBatches {
+		bh := f.IATBatches[i].GetHeader()
+		key := sfc.segmentKey(bh.StandardEntryClassCode, bh.OriginatorIdentification, bh.EffectiveEntryDate, bh.ODFIIdentification)
+
+		for _, entry := range f.IATBatches[i].GetEntries() {
+			b := pick(entry.CreditOrDebit(), key, entry.Amount).batch(len(f.Batches) + i)
+			b.iatHeader = bh
+			b.iatEntries = append(b.iatEntries, entry)
+		}
+	}
+
+	for _, out := range outputs {
+		if err := f.createSegmentOutput(sfc, out); err != nil {
+			return nil, err
+		}
+	}
+	return outputs, nil
+}
+
+// createSegmentOutput builds each batch of out and tabulates the File
+func (f *File) createSegmentOutput(sfc *SegmentFileConfiguration, out *segmentOutput) error {
+	if f.validateOpts != nil {
+		out.file.SetValidation(f.validateOpts)
+	}
+
+	for i, sb := range out.batches {
+		if sb.header != nil {
+			bh := createSegmentFileBatchHeader(segmentServiceClassCode(out.direction, sb.header.ServiceClassCode), sb.header)
+			bh.BatchNumber = i + 1
+			if sfc.PreserveBatchNumbers {
+				bh.BatchNumber = sb.header.BatchNumber
+			}
+
+			batch, err := NewBatch(bh)
+			if err != nil {
+				return err
+			}
+			batch.SetValidation(f.validateOpts)
+			for j, entry := range sb.entries {
+				if sfc.RenumberTraceNumbers {
+					// copy the entry so the original File keeps its TraceNumbers
+					copied, err := copyRecord(entry)
+					if err != nil {
+						return err
+					}
+					entry = copied
+					entry.SetValidation(f.validateOpts)
+					entry.SetTraceNumber(bh.ODFIIdentification, j+1)
+				}
+				batch.AddEntry(entry)
+			}
+			if err := batch.Create(); err != nil {
+				return fmt.Errorf("creating segmented batch %d: %w", bh.BatchNumber, err)
+			}
+			out.file.AddBatch(batch)
+		}
+
+		if sb.iatHeader != nil {
+			bh := *sb.iatHeader
+			bh.ID = base.ID()
+			bh.ServiceClassCode = segmentServiceClassCode(out.direction, bh.ServiceClassCode)
+			if !sfc.PreserveBatchNumbers {
+				bh.BatchNumber = i + 1
+			}
+
+			batch := NewIATBatch(&bh)
+			batch.SetValidation(f.validateOpts)
+			for j, entry := range sb.iatEntries {
+				if sfc.RenumberTraceNumbers {
+					copied, err := copyRecord(entry)
+					if err != nil {
+						return err
+					}
+					entry = copied
+					entry.SetValidation(f.validateOpts)
+					entry.SetTraceNumber(bh.ODFIIdentification, j+1)
+				}
+				batch.AddEntry(entry)
+			}
+			if err := batch.Create(); err != nil {
+				return fmt.Errorf("creating segmented IAT batch %d: %w", bh.BatchNumber, err)
+			}
+			out.file.AddIATBatch(batch)
+		}
+	}
+
+	f.addFileHeaderData(out.file)
+	if err := out.file.Create(); err != nil {
+		return err
+	}
+	return out.file.Validate()
+}
+
+// segmentServiceClassCode returns the ServiceClassCode of a segmented batch
+func segmentServiceClassCode(direction string, original int) int {
+	switch direction {
+	case "C":
+		return CreditsOnly
+	case "D":
+		return DebitsOnly
+	}
+	return original
+}
@@ -0,0 +1,135 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile__Segment(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	files, err := file.Segment(nil)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	// debit entry appears first
+	require.Equal(t, DebitsOnly, files[0].Batches[0].GetHeader().ServiceClassCode)
+	require.Equal(t, 200000000, files[0].Control.TotalDebitEntryDollarAmountInFile)
+	require.Equal(t, CreditsOnly, files[1].Batches[0].GetHeader().ServiceClassCode)
+	require.Equal(t, 200000000, files[1].Control.TotalCreditEntryDollarAmountInFile)
+}
+
+func TestFile__SegmentMaxEntries(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	files, err := file.Segment(&SegmentFileConfiguration{
+		MixedCreditsAndDebits: true,
+		MaxEntries:            2,
+		RenumberTraceNumbers:  true,
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	require.Len(t, files[0].Batches[0].GetEntries(), 2)
+	require.Equal(t, MixedDebitsAndCredits, files[0].Batches[0].GetHeader().ServiceClassCode)
+
+	entries := files[1].Batches[0].GetEntries()
+	require.Len(t, entries, 1)
+	require.Equal(t, "121042880000001", entries[0].TraceNumber)
+
+	// the original entries keep their TraceNumbers
+	require.Equal(t, "121042880000003", file.Batches[0].GetEntries()[2].TraceNumber)
+	require.NoError(t, file.Validate())
+}
+
+func TestFile__SegmentFileNumbering(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "web-debit.ach"))
+	require.NoError(t, err)
+
+	// Without numbering options batches keep their original BatchNumber
+	_, debitFile, err := file.SegmentFile(nil)
+	require.NoError(t, err)
+	require.Len(t, debitFile.Batches, 1)
+	require.Equal(t, 3, debitFile.Batches[0].GetHeader().BatchNumber)
+	require.Equal(t, "081000030000005", debitFile.Batches[0].GetEntries()[0].TraceNumber)
+
+	_, debitFile, err = file.SegmentFile(&SegmentFileConfiguration{RenumberTraceNumbers: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, debitFile.Batches[0].GetHeader().BatchNumber)
+	require.Equal(t, "081000030000001", debitFile.Batches[0].GetEntries()[0].TraceNumber)
+	require.Equal(t, "081000030000005", file.Batches[2].GetEntries()[0].TraceNumber)
+}
+
+func TestFile__SegmentMaxAmount(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	files, err := file.Segment(&SegmentFileConfiguration{
+		MaxAmount: 100000000,
+	})
+	require.NoError(t, err)
+
+	// A single entry larger than MaxAmount is kept in its own file
+	require.Len(t, files, 3)
+	for _, f := range files {
+		require.Len(t, f.Batches, 1)
+		require.Len(t, f.Batches[0].GetEntries(), 1)
+	}
+}
+
+func TestFile__SegmentBySECCode(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "two-micro-deposits.ach"))
+	require.NoError(t, err)
+
+	file.Batches[1].GetHeader().BatchNumber = 5
+	require.NoError(t, file.Batches[1].Create())
+	require.NoError(t, file.Create())
+
+	files, err := file.Segment(&SegmentFileConfiguration{
+		BySECCode:               true,
+		ByCompanyIdentification: true,
+		ByEffectiveEntryDate:    true,
+		ByODFI:                  true,
+		PreserveBatchNumbers:    true,
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	for _, f := range files {
+		require.NoError(t, f.Validate())
+		require.Len(t, f.Batches, 2)
+		require.Equal(t, 1, f.Batches[0].GetHeader().BatchNumber)
+		require.Equal(t, 5, f.Batches[1].GetHeader().BatchNumber)
+	}
+}
+
+func TestFile__SegmentFileConfigurationError(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	_, _, err = file.SegmentFile(&SegmentFileConfiguration{BySECCode: true})
+	require.ErrorIs(t, err, ErrSegmentFileConfiguration)
+
+	_, err = (&File{Batches: []Batcher{NewBatchADV(mockBatchADVHeader())}}).Segment(nil)
+	require.ErrorIs(t, err, ErrSegmentADV)
+}
@@ -20,6 +20,7 @@ package ach
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -2215,6 +2216,11 @@ func TestFile_ValidateOpts_Merge(t *testing.T) {
 	require.True(t, merged.CustomReturnCodes)
 	require.True(t, merged.PreserveSpaces)
 
+	second.CheckRDFI = func(routingNumber string) error {
+		return nil
+	}
+	require.NotNil(t, first.merge(second).CheckRDFI)
+
 	t.Run("empty", func(t *testing.T) {
 		var empty *ValidateOpts
 		full := &ValidateOpts{
@@ -2227,6 +2233,31 @@ func TestFile_ValidateOpts_Merge(t *testing.T) {
 	})
 }
 
+func TestFile_ValidateOpts_CheckRDFI(t *testing.T) {
+	errUnknown := errors.New("unknown RDFI")
+	var checked []string
+	opts := &ValidateOpts{
+		CheckRDFI: func(routingNumber string) error {
+			checked = append(checked, routingNumber)
+			if routingNumber != "231380104" {
+				return errUnknown
+			}
+			return nil
+		},
+	}
+
+	file := mockFilePPD(t)
+	require.NoError(t, file.ValidateWith(opts))
+	require.Equal(t, []string{"231380104"}, checked)
+
+	require.NoError(t, mockFileADV(t).ValidateWith(opts))
+
+	file.Batches[0].GetEntries()[0].SetRDFI("121042882")
+	err := file.ValidateWith(opts)
+	require.ErrorIs(t, err, errUnknown)
+	require.ErrorContains(t, err, "batch #1 (PPD) RDFIIdentification unknown RDFI: 121042882")
+}
+
 func TestFileJSON_ValidateOpts(t *testing.T) {
 	file := mockFilePPD(t)
 	file.SetValidation(&ValidateOpts{
@@ -2594,3 +2625,23 @@ func TestFileLineNumbers(t *testing.T) {
 	require.Equal(t, 14, file.IATBatches[0].GetControl().LineNumber)
 	require.Equal(t, 15, file.Control.LineNumber)
 }
+
+func TestFile_AllBatches(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	file.AddIATBatch(mockIATBatch(t))
+
+	batches := file.AllBatches()
+	require.Len(t, batches, 2)
+	require.IsType(t, &BatchPPD{}, batches[0])
+	require.IsType(t, &IATBatch{}, batches[1])
+
+	for _, b := range batches {
+		require.NoError(t, b.Validate())
+		require.NoError(t, b.ValidateTotals())
+	}
+
+	// IAT batches are pointers into the file
+	batches[1].SetControl(nil)
+	require.Nil(t, file.IATBatches[0].Control)
+}
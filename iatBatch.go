@@ -690,6 +690,45 @@ func (batch *IATBatch) ValidateTotals() error {
 	return nil
 }
 
+// Equal returns true only if two IATBatch objects are equal. Equality is determined by the
+// IATBatchHeader and many of the IATEntryDetail properties.
+func (iatBatch *IATBatch) Equal(other *IATBatch) bool {
+	if iatBatch == nil || other == nil || iatBatch.Header == nil || other.Header == nil {
+		return false
+	}
+	if !iatBatch.Header.Equal(other.Header) {
+		return false
+	}
+	if len(iatBatch.Entries) != len(other.Entries) {
+		return false
+	}
+	equalEntries := 0
+	for i := range iatBatch.Entries {
+		for j := range other.Entries {
+			if iatBatch.Entries[i].TransactionCode != other.Entries[j].TransactionCode {
+				continue // skip to next IATEntryDetail
+			}
+			if iatBatch.Entries[i].RDFIIdentification != other.Entries[j].RDFIIdentification {
+				continue // skip to next IATEntryDetail
+			}
+			if iatBatch.Entries[i].CheckDigit != other.Entries[j].CheckDigit {
+				continue // skip to next IATEntryDetail
+			}
+			if iatBatch.Entries[i].DFIAccountNumber != other.Entries[j].DFIAccountNumber {
+				continue // skip to next IATEntryDetail
+			}
+			if iatBatch.Entries[i].Amount != other.Entries[j].Amount {
+				continue // skip to next IATEntryDetail
+			}
+			if iatBatch.Entries[i].AddendaRecords != other.Entries[j].AddendaRecords {
+				continue // skip to next IATEntryDetail
+			}
+			equalEntries++
+		}
+	}
+	return len(iatBatch.Entries) == equalEntries && equalEntries != 0
+}
+
 // SetValidation stores ValidateOpts on the Batch which are to be used to override
 // the default NACHA validation rules.
 func (iatBatch *IATBatch) SetValidation(opts *ValidateOpts) {
@@ -2258,3 +2258,19 @@ func TestIATBatch_ValidateTotals(t *testing.T) {
 		require.Contains(t, err.Error(), "TotalCreditEntryDollarAmount")
 	})
 }
+
+func TestIATBatch__Equal(t *testing.T) {
+	b1 := mockIATBatch(t)
+	b2 := mockIATBatch(t)
+	require.True(t, b1.Equal(&b2))
+
+	b2.Entries[0].Amount += 1
+	require.False(t, b1.Equal(&b2))
+
+	b3 := mockIATBatch(t)
+	b3.Header.CompanyEntryDescription = "REFUND"
+	require.False(t, b1.Equal(&b3))
+
+	require.False(t, b1.Equal(nil))
+	require.False(t, (&IATBatch{}).Equal(&b1))
+}
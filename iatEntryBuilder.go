@@ -0,0 +1,274 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strings"
+
+	"github.com/moov-io/iso3166"
+)
+
+// IATAddress is the physical address of a party to an IAT entry.
+type IATAddress struct {
+	Street        string `json:"street"`
+	City          string `json:"city"`
+	StateProvince string `json:"stateProvince"`
+	// Country is an ISO 3166-1 alpha-2 country code
+	Country    string `json:"country"`
+	PostalCode string `json:"postalCode"`
+}
+
+// IATOriginator is the originator of an IAT entry, written to Addenda11 and Addenda12.
+type IATOriginator struct {
+	Name    string     `json:"name"`
+	Address IATAddress `json:"address"`
+}
+
+// IATReceiver is the receiver of an IAT entry, written to Addenda10, Addenda15 and Addenda16.
+type IATReceiver struct {
+	Name string `json:"name"`
+	// IdentificationNumber is the receiver's identification number assigned by the originator
+	IdentificationNumber string     `json:"identificationNumber,omitempty"`
+	Address              IATAddress `json:"address"`
+}
+
+// IATBank is a financial institution of an IAT entry. It's written to Addenda13 for the ODFI, Addenda14
+// for the RDFI and Addenda18 for each foreign correspondent bank.
+type IATBank struct {
+	Name string `json:"name"`
+	// IDNumberQualifier is how Identification is assigned: 01 for a national clearing system number,
+	// 02 for a BIC code and 03 for an IBAN code. Empty defaults to 01.
+	IDNumberQualifier string `json:"idNumberQualifier,omitempty"`
+	Identification    string `json:"identification"`
+	// BranchCountry is the ISO 3166-1 alpha-2 country code of the bank's branch
+	BranchCountry string `json:"branchCountry"`
+}
+
+// IATRemittance describes the payment of an IAT entry.
+type IATRemittance struct {
+	// TransactionTypeCode is the type of payment written to Addenda10, such as ANN, BUS or SAL
+	TransactionTypeCode  string `json:"transactionTypeCode"`
+	ForeignPaymentAmount int    `json:"foreignPaymentAmount,omitempty"`
+	ForeignTraceNumber   string `json:"foreignTraceNumber,omitempty"`
+	// PaymentRelatedInformation is split across up to two Addenda17 records of 80 characters each.
+	// Build returns an error when it's longer than 160 characters.
+	PaymentRelatedInformation string `json:"paymentRelatedInformation,omitempty"`
+}
+
+// IATEntryBuilder assembles an IATEntryDetail with its mandatory Addenda10 through Addenda16 records,
+// the Addenda17 records holding remittance information and an Addenda18 for each foreign correspondent bank.
+type IATEntryBuilder struct {
+	TransactionCode int `json:"transactionCode"`
+	// RDFIIdentification is the 9 digit routing number of the RDFI, or of the gateway for outbound entries
+	RDFIIdentification string `json:"RDFIIdentification"`
+	DFIAccountNumber   string `json:"DFIAccountNumber"`
+	Amount             int    `json:"amount"`
+	// TraceNumber is optional as IATBatch.Create assigns trace numbers.
+	TraceNumber string `json:"traceNumber,omitempty"`
+
+	Originator IATOriginator `json:"originator"`
+	Receiver   IATReceiver   `json:"receiver"`
+	ODFI       IATBank       `json:"ODFI"`
+	RDFI       IATBank       `json:"RDFI"`
+	// ForeignCorrespondentBanks are up to five banks the payment passes through
+	ForeignCorrespondentBanks []IATBank `json:"foreignCorrespondentBanks,omitempty"`
+
+	Remittance IATRemittance `json:"remittance"`
+}
+
+const (
+	iatAddendaFieldLength = 35
+	addenda17InfoLength   = 80
+)
+
+// Build returns the IATEntryDetail and addenda records described by b. Names and addresses are truncated
+// to fit their fields, country codes are checked against ISO 3166-1 and the AddendaRecords count and
+// addenda sequence numbers are assigned. Each addenda record is validated.
+func (b IATEntryBuilder) Build() (*IATEntryDetail, error) {
+	if err := b.validateCountries(); err != nil {
+		return nil, err
+	}
+	if n := len(b.ForeignCorrespondentBanks); n > 5 {
+		return nil, fieldError("ForeignCorrespondentBanks", NewErrBatchAddendaCount(n, 5))
+	}
+	info := []rune(strings.TrimSpace(b.Remittance.PaymentRelatedInformation))
+	if len(info) > 2*addenda17InfoLength {
+		return nil, fieldError("Remittance.PaymentRelatedInformation", ErrExceedsFieldLength, b.Remittance.PaymentRelatedInformation)
+	}
+
+	entry := NewIATEntryDetail()
+	entry.TransactionCode = b.TransactionCode
+	entry.SetRDFI(b.RDFIIdentification)
+	entry.DFIAccountNumber = b.DFIAccountNumber
+	entry.Amount = b.Amount
+	entry.TraceNumber = b.TraceNumber
+	entry.AddendaRecordIndicator = 1
+
+	entry.Addenda10 = NewAddenda10()
+	entry.Addenda10.TransactionTypeCode = strings.ToUpper(b.Remittance.TransactionTypeCode)
+	entry.Addenda10.ForeignPaymentAmount = b.Remittance.ForeignPaymentAmount
+	entry.Addenda10.ForeignTraceNumber = truncate(b.Remittance.ForeignTraceNumber, 22)
+	entry.Addenda10.Name = truncate(b.Receiver.Name, iatAddendaFieldLength)
+
+	entry.Addenda11 = NewAddenda11()
+	entry.Addenda11.OriginatorName = truncate(b.Originator.Name, iatAddendaFieldLength)
+	entry.Addenda11.OriginatorStreetAddress = truncate(b.Originator.Address.Street, iatAddendaFieldLength)
+
+	entry.Addenda12 = NewAddenda12()
+	entry.Addenda12.OriginatorCityStateProvince = b.Originator.Address.cityStateProvince()
+	entry.Addenda12.OriginatorCountryPostalCode = b.Originator.Address.countryPostalCode()
+
+	entry.Addenda13 = NewAddenda13()
+	entry.Addenda13.ODFIName = truncate(b.ODFI.Name, iatAddendaFieldLength)
+	entry.Addenda13.ODFIIDNumberQualifier = b.ODFI.idNumberQualifier()
+	entry.Addenda13.ODFIIdentification = truncate(b.ODFI.Identification, 34)
+	entry.Addenda13.ODFIBranchCountryCode = strings.ToUpper(b.ODFI.BranchCountry)
+
+	entry.Addenda14 = NewAddenda14()
+	entry.Addenda14.RDFIName = truncate(b.RDFI.Name, iatAddendaFieldLength)
+	entry.Addenda14.RDFIIDNumberQualifier = b.RDFI.idNumberQualifier()
+	entry.Addenda14.RDFIIdentification = truncate(b.RDFI.Identification, 34)
+	entry.Addenda14.RDFIBranchCountryCode = strings.ToUpper(b.RDFI.BranchCountry)
+
+	entry.Addenda15 = NewAddenda15()
+	entry.Addenda15.ReceiverIDNumber = truncate(b.Receiver.IdentificationNumber, 15)
+	entry.Addenda15.ReceiverStreetAddress = truncate(b.Receiver.Address.Street, iatAddendaFieldLength)
+
+	entry.Addenda16 = NewAddenda16()
+	entry.Addenda16.ReceiverCityStateProvince = b.Receiver.Address.cityStateProvince()
+	entry.Addenda16.ReceiverCountryPostalCode = b.Receiver.Address.countryPostalCode()
+
+	for i := 0; i < len(info); i += addenda17InfoLength {
+		addenda17 := NewAddenda17()
+		addenda17.PaymentRelatedInformation = strings.TrimSpace(string(info[i:min(len(info), i+addenda17InfoLength)]))
+		addenda17.SequenceNumber = len(entry.Addenda17) + 1
+		entry.AddAddenda17(addenda17)
+	}
+
+	for i, bank := range b.ForeignCorrespondentBanks {
+		addenda18 := NewAddenda18()
+		addenda18.ForeignCorrespondentBankName = truncate(bank.Name, iatAddendaFieldLength)
+		addenda18.ForeignCorrespondentBankIDNumberQualifier = bank.idNumberQualifier()
+		addenda18.ForeignCorrespondentBankIDNumber = truncate(bank.Identification, 34)
+		addenda18.ForeignCorrespondentBankBranchCountryCode = strings.ToUpper(bank.BranchCountry)
+		addenda18.SequenceNumber = i + 1
+		entry.AddAddenda18(addenda18)
+	}
+
+	entry.AddendaRecords = entry.addendaCount()
+	if entry.TraceNumber != "" {
+		setIATEntryDetailSequenceNumbers(entry)
+	}
+
+	if err := entry.validateAddenda(); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (b IATEntryBuilder) validateCountries() error {
+	countries := []struct {
+		field, code string
+	}{
+		{"Originator.Address.Country", b.Originator.Address.Country},
+		{"Receiver.Address.Country", b.Receiver.Address.Country},
+		{"ODFI.BranchCountry", b.ODFI.BranchCountry},
+		{"RDFI.BranchCountry", b.RDFI.BranchCountry},
+	}
+	for _, bank := range b.ForeignCorrespondentBanks {
+		countries = append(countries, struct{ field, code string }{"ForeignCorrespondentBanks.BranchCountry", bank.BranchCountry})
+	}
+	for _, c := range countries {
+		if !iso3166.Valid(strings.ToUpper(c.code)) {
+			return fieldError(c.field, ErrValidISO3166, c.code)
+		}
+	}
+	return nil
+}
+
+func (bank IATBank) idNumberQualifier() string {
+	if bank.IDNumberQualifier == "" {
+		return "01"
+	}
+	return bank.IDNumberQualifier
+}
+
+// cityStateProvince formats the city and state or province as "City*State\"
+func (addr IATAddress) cityStateProvince() string {
+	return delimitedAddendaField(addr.City, addr.StateProvince)
+}
+
+// countryPostalCode formats the country and postal code as "US*10036\"
+func (addr IATAddress) countryPostalCode() string {
+	return delimitedAddendaField(strings.ToUpper(addr.Country), addr.PostalCode)
+}
+
+// delimitedAddendaField joins first and second with the * and \ delimiters used by Addenda12 and Addenda16.
+// first is truncated before second so the field fits in 35 characters.
+func delimitedAddendaField(first, second string) string {
+	room := iatAddendaFieldLength - 2
+	second = truncate(second, room)
+	first = truncate(first, room-len([]rune(second)))
+	return first + "*" + second + `\`
+}
+
+// truncate trims the spaces around s and shortens it to n characters
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if r := []rune(s); len(r) > n {
+		return strings.TrimSpace(string(r[:n]))
+	}
+	return s
+}
+
+// setIATEntryDetailSequenceNumbers copies the sequence number of the entry's TraceNumber onto its addenda records
+func setIATEntryDetailSequenceNumbers(entry *IATEntryDetail) {
+	seq := entry.parseNumField(entry.TraceNumberField()[8:])
+	entry.Addenda10.EntryDetailSequenceNumber = seq
+	entry.Addenda11.EntryDetailSequenceNumber = seq
+	entry.Addenda12.EntryDetailSequenceNumber = seq
+	entry.Addenda13.EntryDetailSequenceNumber = seq
+	entry.Addenda14.EntryDetailSequenceNumber = seq
+	entry.Addenda15.EntryDetailSequenceNumber = seq
+	entry.Addenda16.EntryDetailSequenceNumber = seq
+	for _, addenda17 := range entry.Addenda17 {
+		addenda17.EntryDetailSequenceNumber = seq
+	}
+	for _, addenda18 := range entry.Addenda18 {
+		addenda18.EntryDetailSequenceNumber = seq
+	}
+}
+
+func (iatEd *IATEntryDetail) validateAddenda() error {
+	validators := []interface{ Validate() error }{
+		iatEd.Addenda10, iatEd.Addenda11, iatEd.Addenda12, iatEd.Addenda13,
+		iatEd.Addenda14, iatEd.Addenda15, iatEd.Addenda16,
+	}
+	for _, addenda17 := range iatEd.Addenda17 {
+		validators = append(validators, addenda17)
+	}
+	for _, addenda18 := range iatEd.Addenda18 {
+		validators = append(validators, addenda18)
+	}
+	for _, v := range validators {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mockIATEntryBuilder() IATEntryBuilder {
+	return IATEntryBuilder{
+		TransactionCode:    CheckingCredit,
+		RDFIIdentification: "121042882",
+		DFIAccountNumber:   "123456789",
+		Amount:             100000,
+		Originator: IATOriginator{
+			Name: "BEK Solutions",
+			Address: IATAddress{
+				Street:        "15 West Place Street",
+				City:          "JacobsTown",
+				StateProvince: "PA",
+				Country:       "US",
+				PostalCode:    "19305",
+			},
+		},
+		Receiver: IATReceiver{
+			Name:                 "BEK Enterprises",
+			IdentificationNumber: "987465493213987",
+			Address: IATAddress{
+				Street:        "2121 Front Street",
+				City:          "LetterTown",
+				StateProvince: "AB",
+				Country:       "ca",
+				PostalCode:    "80014",
+			},
+		},
+		ODFI: IATBank{
+			Name:           "Wells Fargo",
+			Identification: "231380104",
+			BranchCountry:  "US",
+		},
+		RDFI: IATBank{
+			Name:           "Citadel Bank",
+			Identification: "121042882",
+			BranchCountry:  "CA",
+		},
+		ForeignCorrespondentBanks: []IATBank{
+			{Name: "Bank of France", IDNumberQualifier: "02", Identification: "456456456987987", BranchCountry: "FR"},
+		},
+		Remittance: IATRemittance{
+			TransactionTypeCode:       "ann",
+			PaymentRelatedInformation: "This is an international payment",
+		},
+	}
+}
+
+func TestIATEntryBuilder(t *testing.T) {
+	entry, err := mockIATEntryBuilder().Build()
+	require.NoError(t, err)
+
+	require.Equal(t, "12104288", entry.RDFIIdentification)
+	require.Equal(t, "2", entry.CheckDigit)
+	require.Equal(t, 1, entry.AddendaRecordIndicator)
+	require.Equal(t, 9, entry.AddendaRecords)
+
+	require.Equal(t, "ANN", entry.Addenda10.TransactionTypeCode)
+	require.Equal(t, "BEK Enterprises", entry.Addenda10.Name)
+	require.Equal(t, `JacobsTown*PA\`, entry.Addenda12.OriginatorCityStateProvince)
+	require.Equal(t, `US*19305\`, entry.Addenda12.OriginatorCountryPostalCode)
+	require.Equal(t, "01", entry.Addenda13.ODFIIDNumberQualifier)
+	require.Equal(t, `LetterTown*AB\`, entry.Addenda16.ReceiverCityStateProvince)
+	require.Equal(t, `CA*80014\`, entry.Addenda16.ReceiverCountryPostalCode)
+	require.Len(t, entry.Addenda17, 1)
+	require.Equal(t, 1, entry.Addenda17[0].SequenceNumber)
+	require.Len(t, entry.Addenda18, 1)
+	require.Equal(t, "02", entry.Addenda18[0].ForeignCorrespondentBankIDNumberQualifier)
+
+	// the entry can be added to a batch and written
+	batch := NewIATBatch(mockIATBatchHeaderFF())
+	batch.AddEntry(entry)
+	require.NoError(t, batch.Create())
+	require.Equal(t, 1, entry.Addenda10.EntryDetailSequenceNumber)
+
+	file := NewFile()
+	file.SetHeader(mockFileHeader())
+	file.AddIATBatch(batch)
+	require.NoError(t, file.Create())
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+	read, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.Equal(t, 9, read.IATBatches[0].Entries[0].AddendaRecords)
+}
+
+func TestIATEntryBuilder__Truncate(t *testing.T) {
+	b := mockIATEntryBuilder()
+	b.TraceNumber = "231380100000042"
+	b.Receiver.Name = strings.Repeat("N", 40)
+	b.Receiver.Address.City = strings.Repeat("C", 40)
+	b.Receiver.Address.StateProvince = "British Columbia"
+	b.Originator.Address.PostalCode = strings.Repeat("9", 40)
+	b.Remittance.PaymentRelatedInformation = strings.Repeat("A", 80) + strings.Repeat("B", 80)
+	b.ForeignCorrespondentBanks = append(b.ForeignCorrespondentBanks, b.ForeignCorrespondentBanks[0])
+
+	entry, err := b.Build()
+	require.NoError(t, err)
+	require.Equal(t, 11, entry.AddendaRecords)
+
+	require.Equal(t, strings.Repeat("N", 35), entry.Addenda10.Name)
+	require.Equal(t, strings.Repeat("C", 17)+`*British Columbia\`, entry.Addenda16.ReceiverCityStateProvince)
+	require.Equal(t, `*`+strings.Repeat("9", 33)+`\`, entry.Addenda12.OriginatorCountryPostalCode)
+
+	require.Len(t, entry.Addenda17, 2)
+	require.Equal(t, strings.Repeat("B", 80), entry.Addenda17[1].PaymentRelatedInformation)
+	require.Equal(t, 2, entry.Addenda17[1].SequenceNumber)
+	require.Equal(t, 2, entry.Addenda18[1].SequenceNumber)
+
+	// addenda records share the sequence number of the TraceNumber
+	require.Equal(t, 42, entry.Addenda15.EntryDetailSequenceNumber)
+	require.Equal(t, 42, entry.Addenda18[1].EntryDetailSequenceNumber)
+
+	// Remittance information beyond two Addenda17 records isn't dropped
+	b.Remittance.PaymentRelatedInformation += "C"
+	_, err = b.Build()
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, "Remittance.PaymentRelatedInformation", fe.FieldName)
+	require.ErrorIs(t, err, ErrExceedsFieldLength)
+}
+
+func TestIATEntryBuilder__Errors(t *testing.T) {
+	b := mockIATEntryBuilder()
+	b.Receiver.Address.Country = "XX"
+	_, err := b.Build()
+	require.ErrorIs(t, err, ErrValidISO3166)
+	require.Contains(t, err.Error(), "Receiver.Address.Country")
+
+	b = mockIATEntryBuilder()
+	b.ForeignCorrespondentBanks[0].BranchCountry = ""
+	_, err = b.Build()
+	require.ErrorIs(t, err, ErrValidISO3166)
+
+	b = mockIATEntryBuilder()
+	for len(b.ForeignCorrespondentBanks) < 6 {
+		b.ForeignCorrespondentBanks = append(b.ForeignCorrespondentBanks, b.ForeignCorrespondentBanks[0])
+	}
+	_, err = b.Build()
+	require.ErrorContains(t, err, "ForeignCorrespondentBanks")
+
+	b = mockIATEntryBuilder()
+	b.Remittance.TransactionTypeCode = "XYZ"
+	_, err = b.Build()
+	require.ErrorIs(t, err, ErrTransactionTypeCode)
+
+	b = mockIATEntryBuilder()
+	b.RDFI.IDNumberQualifier = "09"
+	_, err = b.Build()
+	require.ErrorIs(t, err, ErrIDNumberQualifier)
+}
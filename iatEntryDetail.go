@@ -52,9 +52,11 @@ type IATEntryDetail struct {
 	// DFIAccountNumber is the receiver's bank account number you are crediting/debiting.
 	// It important to note that this is an alphanumeric field, so its space padded, no zero padded
 	DFIAccountNumber string `json:"DFIAccountNumber"`
-	// OFACScreeningIndicator - Leave blank
+	// OFACScreeningIndicator is set by the Gateway Operator, 1 when a party of the entry is an OFAC suspect
+	// and 0 or blank otherwise.
 	OFACScreeningIndicator string `json:"OFACScreeningIndicator"`
-	// SecondaryOFACScreeningIndicator - Leave blank
+	// SecondaryOFACScreeningIndicator may be set by the ODFI, 1 when a party of the entry is an OFAC suspect
+	// and 0 or blank otherwise.
 	SecondaryOFACScreeningIndicator string `json:"secondaryOFACScreeningIndicator"`
 	// AddendaRecordIndicator indicates the existence of an Addenda Record.
 	// A value of "1" indicates that one or more addenda records follow,
@@ -208,12 +210,10 @@ func (iatEd *IATEntryDetail) Parse(record string) {
 			reset()
 		case 77:
 			// 77 OFACScreeningIndicator
-			reset()
-			iatEd.OFACScreeningIndicator = " "
+			iatEd.OFACScreeningIndicator = reset()
 		case 78:
 			// 78-78 Secondary SecondaryOFACScreeningIndicator
-			reset()
-			iatEd.SecondaryOFACScreeningIndicator = " "
+			iatEd.SecondaryOFACScreeningIndicator = reset()
 		case 79:
 			// 79-79 1 if addenda exists 0 if it does not
 			iatEd.AddendaRecordIndicator = iatEd.parseNumField(reset())
@@ -366,6 +366,24 @@ func (iatEd *IATEntryDetail) TraceNumberField() string {
 	return iatEd.stringField(iatEd.TraceNumber, 15)
 }
 
+// CreditOrDebit returns a "C" for credit or "D" for debit based on the entry TransactionCode
+func (iatEd *IATEntryDetail) CreditOrDebit() string {
+	if iatEd.TransactionCode < 10 || iatEd.TransactionCode > 99 {
+		return ""
+	}
+	tc := strconv.Itoa(iatEd.TransactionCode)
+
+	// take the second number in the TransactionCode
+	switch tc[1:2] {
+	case "1", "2", "3", "4":
+		return "C"
+	case "5", "6", "7", "8", "9":
+		return "D"
+	default:
+	}
+	return ""
+}
+
 // AddAddenda17 appends an Addenda17 to the IATEntryDetail
 func (iatEd *IATEntryDetail) AddAddenda17(addenda17 *Addenda17) {
 	iatEd.Addenda17 = append(iatEd.Addenda17, addenda17)
@@ -0,0 +1,281 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/iso4217"
+)
+
+// ExchangeRates provides the foreign exchange rates used to convert IAT amounts.
+type ExchangeRates interface {
+	// Rate returns how many units of the to currency one unit of the from currency buys.
+	Rate(from, to string) (float64, error)
+}
+
+// RateTable is a fixed set of ExchangeRates keyed by currency pair, such as "USD/CAD".
+type RateTable map[string]float64
+
+// Rate returns the rate of from/to, or the inverse of to/from when only that pair is in the table.
+func (t RateTable) Rate(from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return 1, nil
+	}
+	if rate, ok := t[from+"/"+to]; ok && rate > 0 {
+		return rate, nil
+	}
+	if rate, ok := t[to+"/"+from]; ok && rate > 0 {
+		return 1 / rate, nil
+	}
+	return 0, fmt.Errorf("%w for %s/%s", ErrExchangeRateNotFound, from, to)
+}
+
+// ReadRateTable reads a RateTable from CSV rows of from currency, to currency and rate, such as
+// "USD,CAD,1.3725". Blank lines, lines starting with # and a header row are skipped.
+func ReadRateTable(r io.Reader) (RateTable, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	table := make(RateTable)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return table, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+		if err != nil && first {
+			continue // header
+		}
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("line %d: invalid rate %q", line, record[2])
+		}
+		from, to := strings.ToUpper(strings.TrimSpace(record[0])), strings.ToUpper(strings.TrimSpace(record[1]))
+		for _, code := range []string{from, to} {
+			if _, ok := iso4217.Lookup(code); !ok {
+				return nil, fmt.Errorf("line %d: %s %w", line, code, ErrValidISO4217)
+			}
+		}
+		table[from+"/"+to] = rate
+	}
+}
+
+// ReadRateTableFile reads a RateTable from the CSV file at path.
+func ReadRateTableFile(path string) (RateTable, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	return ReadRateTable(fd)
+}
+
+// ValidateForeignExchange checks the foreign exchange fields of the header agree with each other.
+// FF batches must originate and be received in the same currency without a rate, while FV and VF
+// batches convert between two different currencies. The Foreign Exchange Reference must hold a rate
+// when its indicator is 1, a reference number when 2 and be blank when 3.
+//
+// These checks are stricter than Validate, so files received from other parties may not pass them.
+func (iatBh *IATBatchHeader) ValidateForeignExchange() error {
+	if err := iatBh.isForeignExchangeIndicator(); err != nil {
+		return fieldError("ForeignExchangeIndicator", err, iatBh.ForeignExchangeIndicator)
+	}
+	if err := iatBh.isForeignExchangeReferenceIndicator(); err != nil {
+		return fieldError("ForeignExchangeReferenceIndicator", err, strconv.Itoa(iatBh.ForeignExchangeReferenceIndicator))
+	}
+	if _, exists := iso4217.Lookup(iatBh.ISOOriginatingCurrencyCode); !exists {
+		return fieldError("ISOOriginatingCurrencyCode", ErrValidISO4217, iatBh.ISOOriginatingCurrencyCode)
+	}
+	if _, exists := iso4217.Lookup(iatBh.ISODestinationCurrencyCode); !exists {
+		return fieldError("ISODestinationCurrencyCode", ErrValidISO4217, iatBh.ISODestinationCurrencyCode)
+	}
+
+	sameCurrency := strings.EqualFold(iatBh.ISOOriginatingCurrencyCode, iatBh.ISODestinationCurrencyCode)
+	switch iatBh.ForeignExchangeIndicator {
+	case "FF":
+		if !sameCurrency {
+			return fieldError("ISODestinationCurrencyCode", ErrForeignExchangeCurrency, iatBh.ISODestinationCurrencyCode)
+		}
+		if iatBh.ForeignExchangeReferenceIndicator == 1 || iatBh.ForeignExchangeReferenceIndicator == 2 {
+			return fieldError("ForeignExchangeReferenceIndicator", ErrForeignExchangeReference, strconv.Itoa(iatBh.ForeignExchangeReferenceIndicator))
+		}
+	case "FV", "VF":
+		if sameCurrency {
+			return fieldError("ISODestinationCurrencyCode", ErrForeignExchangeCurrency, iatBh.ISODestinationCurrencyCode)
+		}
+	}
+
+	reference := strings.TrimSpace(iatBh.ForeignExchangeReference)
+	switch iatBh.ForeignExchangeReferenceIndicator {
+	case 1:
+		if _, err := iatBh.foreignExchangeRate(); err != nil {
+			return fieldError("ForeignExchangeReference", ErrForeignExchangeReference, iatBh.ForeignExchangeReference)
+		}
+	case 2:
+		if reference == "" {
+			return fieldError("ForeignExchangeReference", ErrForeignExchangeReference, iatBh.ForeignExchangeReference)
+		}
+	default:
+		if reference != "" {
+			return fieldError("ForeignExchangeReference", ErrForeignExchangeReference, iatBh.ForeignExchangeReference)
+		}
+	}
+	return nil
+}
+
+// foreignExchangeRate parses the rate held in ForeignExchangeReference
+func (iatBh *IATBatchHeader) foreignExchangeRate() (float64, error) {
+	rate, err := strconv.ParseFloat(strings.TrimSpace(iatBh.ForeignExchangeReference), 64)
+	if err != nil || rate <= 0 {
+		return 0, ErrForeignExchangeReference
+	}
+	return rate, nil
+}
+
+// DestinationAmount returns the amount, in minor units of ISODestinationCurrencyCode, an entry of amount
+// (in minor units of ISOOriginatingCurrencyCode) is received as. FF amounts are not converted. FV and VF
+// amounts are converted with the rate in ForeignExchangeReference when its indicator is 1, otherwise
+// with rates. Results are rounded to the nearest minor unit.
+func (iatBh *IATBatchHeader) DestinationAmount(amount int, rates ExchangeRates) (int, error) {
+	if err := iatBh.ValidateForeignExchange(); err != nil {
+		return 0, err
+	}
+	if iatBh.ForeignExchangeIndicator == "FF" {
+		return amount, nil
+	}
+
+	var rate float64
+	var err error
+	if iatBh.ForeignExchangeReferenceIndicator == 1 {
+		rate, err = iatBh.foreignExchangeRate()
+	} else {
+		if rates == nil {
+			return 0, fmt.Errorf("%w for %s/%s", ErrExchangeRateNotFound, iatBh.ISOOriginatingCurrencyCode, iatBh.ISODestinationCurrencyCode)
+		}
+		rate, err = rates.Rate(iatBh.ISOOriginatingCurrencyCode, iatBh.ISODestinationCurrencyCode)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return convertAmount(amount, rate, iatBh.ISOOriginatingCurrencyCode, iatBh.ISODestinationCurrencyCode), nil
+}
+
+// convertAmount converts amount between the minor units of two currencies, which may have
+// different numbers of decimal places.
+func convertAmount(amount int, rate float64, from, to string) int {
+	fromCode, _ := iso4217.Lookup(from)
+	toCode, _ := iso4217.Lookup(to)
+	scale := math.Pow10(int(toCode.DecimalPlaces) - int(fromCode.DecimalPlaces))
+	return int(math.Round(float64(amount) * rate * scale))
+}
+
+// IATForeignExchange holds the foreign exchange fields of an IATBatchHeader.
+type IATForeignExchange struct {
+	// Indicator is FF, FV or VF
+	Indicator           string `json:"indicator"`
+	ReferenceIndicator  int    `json:"referenceIndicator"`
+	Reference           string `json:"reference,omitempty"`
+	OriginatingCurrency string `json:"originatingCurrency"`
+	DestinationCurrency string `json:"destinationCurrency"`
+}
+
+// IATForeignExchangeEntry is an IAT entry and the foreign exchange it's originated with.
+type IATForeignExchangeEntry struct {
+	Entry    *IATEntryDetail    `json:"entry"`
+	Exchange IATForeignExchange `json:"exchange"`
+}
+
+// IATForeignExchangeBatch is an IATBatch built by BuildIATBatches along with the amount each of its
+// entries is received as.
+type IATForeignExchangeBatch struct {
+	IATBatch
+
+	// DestinationAmounts holds the amount of each of Entries, in order, converted into minor units
+	// of the header's ISODestinationCurrencyCode.
+	DestinationAmounts []int
+}
+
+// BuildIATBatches groups entries into one IATBatch for each distinct foreign exchange indicator, reference
+// and currency pair. Every other field of each batch's header is copied from header. Batches are numbered
+// from header.BatchNumber in the order their foreign exchange first appears and each is created.
+//
+// The destination amount of each entry is computed as DestinationAmount does, so rates is only needed
+// for FV and VF entries whose rate isn't in their Reference.
+func BuildIATBatches(header *IATBatchHeader, entries []IATForeignExchangeEntry, rates ExchangeRates) ([]IATForeignExchangeBatch, error) {
+	if header == nil {
+		return nil, errors.New("nil IATBatchHeader")
+	}
+
+	var batches []IATForeignExchangeBatch
+	index := make(map[IATForeignExchange]int)
+	for _, e := range entries {
+		fx := e.Exchange
+		fx.Indicator = strings.ToUpper(fx.Indicator)
+		fx.OriginatingCurrency = strings.ToUpper(fx.OriginatingCurrency)
+		fx.DestinationCurrency = strings.ToUpper(fx.DestinationCurrency)
+		fx.Reference = strings.TrimSpace(fx.Reference)
+
+		i, ok := index[fx]
+		if !ok {
+			bh := *header
+			bh.BatchNumber = header.BatchNumber + len(batches)
+			bh.ForeignExchangeIndicator = fx.Indicator
+			bh.ForeignExchangeReferenceIndicator = fx.ReferenceIndicator
+			bh.ForeignExchangeReference = fx.Reference
+			bh.ISOOriginatingCurrencyCode = fx.OriginatingCurrency
+			bh.ISODestinationCurrencyCode = fx.DestinationCurrency
+			if err := bh.ValidateForeignExchange(); err != nil {
+				return nil, err
+			}
+
+			i = len(batches)
+			index[fx] = i
+			batches = append(batches, IATForeignExchangeBatch{IATBatch: NewIATBatch(&bh)})
+		}
+
+		amount, err := batches[i].Header.DestinationAmount(e.Entry.Amount, rates)
+		if err != nil {
+			return nil, err
+		}
+		batches[i].AddEntry(e.Entry)
+		batches[i].DestinationAmounts = append(batches[i].DestinationAmounts, amount)
+	}
+
+	for i := range batches {
+		if err := batches[i].Create(); err != nil {
+			return nil, err
+		}
+	}
+	return batches, nil
+}
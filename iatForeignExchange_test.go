@@ -0,0 +1,179 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateTable(t *testing.T) {
+	rates, err := ReadRateTableFile(filepath.Join("test", "testdata", "fx-rates.csv"))
+	require.NoError(t, err)
+	require.Len(t, rates, 3)
+
+	rate, err := rates.Rate("usd", "cad")
+	require.NoError(t, err)
+	require.Equal(t, 1.3725, rate)
+
+	rate, err = rates.Rate("EUR", "USD")
+	require.NoError(t, err)
+	require.InDelta(t, 1/0.9215, rate, 0.000001)
+
+	rate, err = rates.Rate("GBP", "GBP")
+	require.NoError(t, err)
+	require.Equal(t, 1.0, rate)
+
+	_, err = rates.Rate("USD", "GBP")
+	require.ErrorIs(t, err, ErrExchangeRateNotFound)
+
+	_, err = ReadRateTable(strings.NewReader("USD,XYZ,1.0\n"))
+	require.ErrorIs(t, err, ErrValidISO4217)
+
+	_, err = ReadRateTable(strings.NewReader("USD,CAD,1.0\nUSD,EUR,abc\n"))
+	require.ErrorContains(t, err, "line 2")
+
+	_, err = ReadRateTableFile(filepath.Join("test", "testdata", "missing.csv"))
+	require.Error(t, err)
+}
+
+func TestIATBatchHeader__ValidateForeignExchange(t *testing.T) {
+	bh := mockIATBatchHeaderFF()
+	require.ErrorIs(t, bh.ValidateForeignExchange(), ErrForeignExchangeCurrency)
+
+	bh.ISOOriginatingCurrencyCode = "USD"
+	require.NoError(t, bh.ValidateForeignExchange())
+
+	bh.ForeignExchangeReferenceIndicator = 1
+	bh.ForeignExchangeReference = "1.25"
+	require.ErrorIs(t, bh.ValidateForeignExchange(), ErrForeignExchangeReference)
+
+	bh.ForeignExchangeIndicator = "FV"
+	require.ErrorIs(t, bh.ValidateForeignExchange(), ErrForeignExchangeCurrency)
+
+	bh.ISODestinationCurrencyCode = "CAD"
+	require.NoError(t, bh.ValidateForeignExchange())
+
+	bh.ForeignExchangeReference = "rate"
+	require.ErrorIs(t, bh.ValidateForeignExchange(), ErrForeignExchangeReference)
+
+	bh.ForeignExchangeReferenceIndicator = 2
+	require.NoError(t, bh.ValidateForeignExchange())
+
+	bh.ForeignExchangeReferenceIndicator = 3
+	require.ErrorIs(t, bh.ValidateForeignExchange(), ErrForeignExchangeReference)
+
+	bh.ForeignExchangeReference = ""
+	bh.ISODestinationCurrencyCode = "ZZZ"
+	require.ErrorIs(t, bh.ValidateForeignExchange(), ErrValidISO4217)
+
+	bh.ForeignExchangeIndicator = "XX"
+	require.ErrorIs(t, bh.ValidateForeignExchange(), ErrForeignExchangeIndicator)
+}
+
+func TestIATBatchHeader__DestinationAmount(t *testing.T) {
+	rates := RateTable{"USD/CAD": 1.3725, "JPY/USD": 0.0067}
+
+	bh := mockIATBatchHeaderFF()
+	bh.ISOOriginatingCurrencyCode = "USD"
+	amount, err := bh.DestinationAmount(100000, rates)
+	require.NoError(t, err)
+	require.Equal(t, 100000, amount)
+
+	// FV converts with the rate table
+	bh.ForeignExchangeIndicator = "FV"
+	bh.ISODestinationCurrencyCode = "CAD"
+	amount, err = bh.DestinationAmount(100000, rates)
+	require.NoError(t, err)
+	require.Equal(t, 137250, amount)
+
+	// VF with the rate in the header
+	bh.ForeignExchangeIndicator = "VF"
+	bh.ForeignExchangeReferenceIndicator = 1
+	bh.ForeignExchangeReference = "1.4"
+	amount, err = bh.DestinationAmount(100000, nil)
+	require.NoError(t, err)
+	require.Equal(t, 140000, amount)
+
+	// JPY has no minor units
+	bh.ForeignExchangeReferenceIndicator = 3
+	bh.ForeignExchangeReference = ""
+	bh.ISOOriginatingCurrencyCode = "USD"
+	bh.ISODestinationCurrencyCode = "JPY"
+	amount, err = bh.DestinationAmount(10050, rates)
+	require.NoError(t, err)
+	require.Equal(t, 15000, amount)
+
+	_, err = bh.DestinationAmount(10050, nil)
+	require.ErrorIs(t, err, ErrExchangeRateNotFound)
+
+	bh.ISODestinationCurrencyCode = "GBP"
+	_, err = bh.DestinationAmount(10050, rates)
+	require.ErrorIs(t, err, ErrExchangeRateNotFound)
+}
+
+func TestBuildIATBatches(t *testing.T) {
+	header := mockIATBatchHeaderFF()
+	header.BatchNumber = 5
+
+	entry := func() *IATEntryDetail {
+		e, err := mockIATEntryBuilder().Build()
+		require.NoError(t, err)
+		return e
+	}
+	usdCAD := IATForeignExchange{Indicator: "fv", ReferenceIndicator: 3, OriginatingCurrency: "usd", DestinationCurrency: "CAD"}
+	usdUSD := IATForeignExchange{Indicator: "FF", ReferenceIndicator: 3, OriginatingCurrency: "USD", DestinationCurrency: "USD"}
+
+	batches, err := BuildIATBatches(header, []IATForeignExchangeEntry{
+		{Entry: entry(), Exchange: usdCAD},
+		{Entry: entry(), Exchange: usdUSD},
+		{Entry: entry(), Exchange: usdCAD},
+	}, RateTable{"USD/CAD": 1.3725})
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+
+	require.Equal(t, 5, batches[0].Header.BatchNumber)
+	require.Equal(t, "FV", batches[0].Header.ForeignExchangeIndicator)
+	require.Equal(t, "CAD", batches[0].Header.ISODestinationCurrencyCode)
+	require.Len(t, batches[0].Entries, 2)
+	require.Equal(t, 200000, batches[0].Control.TotalCreditEntryDollarAmount)
+	require.Equal(t, []int{137250, 137250}, batches[0].DestinationAmounts)
+
+	require.Equal(t, 6, batches[1].Header.BatchNumber)
+	require.Equal(t, "FF", batches[1].Header.ForeignExchangeIndicator)
+	require.Len(t, batches[1].Entries, 1)
+	require.Equal(t, []int{100000}, batches[1].DestinationAmounts)
+
+	// the template header is unchanged
+	require.Equal(t, "CAD", header.ISOOriginatingCurrencyCode)
+
+	_, err = BuildIATBatches(header, []IATForeignExchangeEntry{
+		{Entry: entry(), Exchange: IATForeignExchange{Indicator: "FF", ReferenceIndicator: 3, OriginatingCurrency: "USD", DestinationCurrency: "EUR"}},
+	}, nil)
+	require.ErrorIs(t, err, ErrForeignExchangeCurrency)
+
+	// FV entries without a rate in their reference need rates
+	_, err = BuildIATBatches(header, []IATForeignExchangeEntry{{Entry: entry(), Exchange: usdCAD}}, nil)
+	require.ErrorIs(t, err, ErrExchangeRateNotFound)
+
+	_, err = BuildIATBatches(nil, nil, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,221 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iso8583
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/internal/usabbrev"
+)
+
+var (
+	// ErrProcessingCode is returned for records which aren't a purchase, cash withdrawal or return.
+	ErrProcessingCode = errors.New("unsupported processing code")
+)
+
+// transaction returns the TransactionCode and card transaction type for a record from its processing
+// code (DE 3). Positions 1-2 are the transaction type, 3-4 the account debited and 5-6 the account credited.
+func transaction(r Record) (int, string, error) {
+	if len(r.ProcessingCode) != 6 {
+		return 0, "", fmt.Errorf("%w: %q", ErrProcessingCode, r.ProcessingCode)
+	}
+
+	var debit bool
+	var cardType string
+	switch r.ProcessingCode[:2] {
+	case "00": // purchase
+		debit, cardType = true, "01"
+		if r.IsReversal() {
+			cardType = "11"
+		}
+	case "01": // cash withdrawal
+		debit, cardType = true, "02"
+		if r.IsReversal() {
+			cardType = "12"
+		}
+	case "20": // return
+		debit, cardType = false, "13"
+		if r.IsReversal() {
+			cardType = "03"
+		}
+	default:
+		return 0, "", fmt.Errorf("%w: %q", ErrProcessingCode, r.ProcessingCode)
+	}
+	if r.IsReversal() {
+		debit = !debit
+	}
+
+	// Savings accounts have a type of 10, everything else posts to checking
+	if debit {
+		if r.ProcessingCode[2:4] == "10" {
+			return ach.SavingsDebit, cardType, nil
+		}
+		return ach.CheckingDebit, cardType, nil
+	}
+	if r.ProcessingCode[4:6] == "10" {
+		return ach.SavingsCredit, cardType, nil
+	}
+	return ach.CheckingCredit, cardType, nil
+}
+
+// Addenda02 returns the terminal record of r with the terminal ID, transaction serial number (STAN),
+// transaction date, authorization code and the card acceptor's location, city and state. The MCC is
+// written as ReferenceInformationOne. Terminal IDs longer than six characters keep their last six.
+func Addenda02(r Record) (*ach.Addenda02, error) {
+	acceptor := ParseCardAcceptor(r.CardAcceptorNameLocation)
+	if !usabbrev.Valid(acceptor.State) {
+		return nil, &ach.FieldError{FieldName: "TerminalState", Value: acceptor.State, Err: ach.ErrValidState}
+	}
+
+	addenda02 := ach.NewAddenda02()
+	addenda02.ReferenceInformationOne = r.MCC
+	addenda02.TerminalIdentificationCode = last(r.TerminalID, 6)
+	addenda02.TransactionSerialNumber = r.STAN
+	if date := cmp.Or(r.LocalTransactionDate, r.TransmissionDateTime); !date.IsZero() {
+		addenda02.TransactionDate = date.Format("0102")
+	}
+	addenda02.AuthorizationCodeOrExpireDate = r.AuthorizationCode
+	addenda02.TerminalLocation = acceptor.Name
+	addenda02.TerminalCity = acceptor.City
+	addenda02.TerminalState = acceptor.State
+
+	if err := addenda02.Validate(); err != nil {
+		return nil, err
+	}
+	return addenda02, nil
+}
+
+// Entry converts r into an entry of a POS, SHR or MTE batch along with its Addenda02.
+//
+// Purchases and cash withdrawals debit the cardholder and returns credit them, reversal messages
+// do the opposite. POS entries identify the transaction by its retrieval reference number, SHR entries
+// carry the card number, expiration date and retrieval reference number, and MTE entries carry the card number.
+//
+// The returned entry has no trace number, which is set when it's added to a batch.
+func Entry(secCode string, r Record) (*ach.EntryDetail, error) {
+	if err := ach.CheckRoutingNumber(r.ReceivingInstitutionID); err != nil {
+		return nil, fmt.Errorf("DE %d receiving institution: %w", ElementReceivingInstitutionID, err)
+	}
+	if r.AccountIdentification == "" {
+		return nil, &ach.FieldError{FieldName: "DFIAccountNumber", Err: ach.ErrFieldRequired}
+	}
+	if r.Amount <= 0 {
+		return nil, &ach.FieldError{FieldName: "Amount", Value: r.Amount, Err: ach.ErrBatchAmountZero}
+	}
+
+	transactionCode, cardType, err := transaction(r)
+	if err != nil {
+		return nil, err
+	}
+	addenda02, err := Addenda02(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ed := ach.NewEntryDetail()
+	ed.TransactionCode = transactionCode
+	ed.SetRDFI(r.ReceivingInstitutionID)
+	ed.DFIAccountNumber = r.AccountIdentification
+	ed.Amount = r.Amount
+
+	switch secCode {
+	case ach.POS:
+		ed.IdentificationNumber = r.RetrievalReferenceNumber
+		ed.IndividualName = r.CardholderName
+		ed.DiscretionaryData = cardType
+
+	case ach.SHR:
+		if len(r.ExpirationDate) != 4 {
+			return nil, &ach.FieldError{FieldName: "CardExpirationDate", Value: r.ExpirationDate, Err: ach.ErrFieldRequired}
+		}
+		ed.SetSHRCardExpirationDate(r.ExpirationDate[2:] + r.ExpirationDate[:2]) // YYMM to MMYY
+		ed.SetSHRDocumentReferenceNumber(last(r.RetrievalReferenceNumber, 11))
+		ed.SetSHRIndividualCardAccountNumber(r.PAN)
+		ed.DiscretionaryData = cardType
+
+	case ach.MTE:
+		ed.IdentificationNumber = r.PAN
+		ed.IndividualName = r.CardholderName
+
+	default:
+		return nil, &ach.FieldError{FieldName: "StandardEntryClassCode", Value: secCode, Err: ach.ErrSECCode}
+	}
+
+	ed.Addenda02 = addenda02
+	ed.AddendaRecordIndicator = 1
+	return ed, nil
+}
+
+// Batches converts records into a batch of header's SEC code (POS, SHR or MTE) for each settlement date.
+// Batches are ordered by settlement date, which is used as their EffectiveEntryDate, and numbered from
+// header.BatchNumber. Trace numbers are assigned from header.ODFIIdentification across the batches in order.
+func Batches(header *ach.BatchHeader, records []Record) ([]ach.Batcher, error) {
+	bySettlementDate := make(map[string][]int)
+	var dates []string
+	for i := range records {
+		if records[i].SettlementDate.IsZero() {
+			return nil, fmt.Errorf("record %d: missing DE %d settlement date", i, ElementSettlementDate)
+		}
+		date := records[i].SettlementDate.Format("060102")
+		if _, exists := bySettlementDate[date]; !exists {
+			dates = append(dates, date)
+		}
+		bySettlementDate[date] = append(bySettlementDate[date], i)
+	}
+	slices.Sort(dates)
+
+	var out []ach.Batcher
+	var sequence int
+	for i, date := range dates {
+		bh := *header
+		bh.EffectiveEntryDate = date
+		bh.BatchNumber = header.BatchNumber + i
+
+		batch, err := ach.NewBatch(&bh)
+		if err != nil {
+			return nil, err
+		}
+		for _, idx := range bySettlementDate[date] {
+			ed, err := Entry(header.StandardEntryClassCode, records[idx])
+			if err != nil {
+				return nil, fmt.Errorf("record %d: %w", idx, err)
+			}
+			sequence++
+			ed.SetTraceNumber(header.ODFIIdentification, sequence)
+			batch.AddEntry(ed)
+		}
+		if err := batch.Create(); err != nil {
+			return nil, fmt.Errorf("settlement date %s: %w", date, err)
+		}
+		out = append(out, batch)
+	}
+	return out, nil
+}
+
+// last returns the last n characters of s
+func last(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[len(runes)-n:])
+}
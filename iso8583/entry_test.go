@@ -0,0 +1,207 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iso8583
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moov-io/ach"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mockRecord() Record {
+	return Record{
+		MessageType:              "0200",
+		PAN:                      "4000123412341234",
+		ProcessingCode:           "002000",
+		Amount:                   2500,
+		TransmissionDateTime:     time.Date(2026, time.October, 16, 14, 30, 0, 0, time.UTC),
+		STAN:                     "123456",
+		ExpirationDate:           "2807",
+		SettlementDate:           time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+		MCC:                      "5411",
+		RetrievalReferenceNumber: "628914123456",
+		AuthorizationCode:        "A1B2C3",
+		TerminalID:               "TERM0042",
+		CardAcceptorNameLocation: "CORNER GROCERY         ANYTOWN      VAUS",
+		ReceivingInstitutionID:   "231380104",
+		AccountIdentification:    "744567899",
+		CardholderName:           "Jane Doe",
+	}
+}
+
+func mockHeader(secCode string) *ach.BatchHeader {
+	bh := ach.NewBatchHeader()
+	bh.ServiceClassCode = ach.MixedDebitsAndCredits
+	bh.StandardEntryClassCode = secCode
+	bh.CompanyName = "Card Network"
+	bh.CompanyIdentification = "121042882"
+	bh.CompanyEntryDescription = "SETTLEMENT"
+	bh.ODFIIdentification = "12104288"
+	bh.BatchNumber = 1
+	return bh
+}
+
+func TestAddenda02(t *testing.T) {
+	addenda02, err := Addenda02(mockRecord())
+	require.NoError(t, err)
+	require.Equal(t, "5411", addenda02.ReferenceInformationOne)
+	require.Equal(t, "RM0042", addenda02.TerminalIdentificationCode)
+	require.Equal(t, "123456", addenda02.TransactionSerialNumber)
+	require.Equal(t, "1016", addenda02.TransactionDate)
+	require.Equal(t, "A1B2C3", addenda02.AuthorizationCodeOrExpireDate)
+	require.Equal(t, "CORNER GROCERY", addenda02.TerminalLocation)
+	require.Equal(t, "ANYTOWN", addenda02.TerminalCity)
+	require.Equal(t, "VA", addenda02.TerminalState)
+
+	r := mockRecord()
+	r.CardAcceptorNameLocation = "CORNER GROCERY         TORONTO      ONCA"
+	_, err = Addenda02(r)
+	require.ErrorIs(t, err, ach.ErrValidState)
+
+	r = mockRecord()
+	r.STAN = ""
+	_, err = Addenda02(r)
+	require.ErrorIs(t, err, ach.ErrFieldRequired)
+}
+
+func TestEntry(t *testing.T) {
+	t.Run("POS", func(t *testing.T) {
+		ed, err := Entry(ach.POS, mockRecord())
+		require.NoError(t, err)
+		require.Equal(t, ach.CheckingDebit, ed.TransactionCode)
+		require.Equal(t, "23138010", ed.RDFIIdentification)
+		require.Equal(t, "744567899", ed.DFIAccountNumber)
+		require.Equal(t, 2500, ed.Amount)
+		require.Equal(t, "628914123456", ed.IdentificationNumber)
+		require.Equal(t, "01", ed.DiscretionaryData)
+		require.Equal(t, 1, ed.AddendaRecordIndicator)
+		require.NotNil(t, ed.Addenda02)
+	})
+
+	t.Run("SHR", func(t *testing.T) {
+		ed, err := Entry(ach.SHR, mockRecord())
+		require.NoError(t, err)
+		require.Equal(t, "0728", ed.SHRCardExpirationDateField())
+		require.Equal(t, "28914123456", ed.SHRDocumentReferenceNumberField())
+		require.Equal(t, "0000004000123412341234", ed.SHRIndividualCardAccountNumberField())
+
+		r := mockRecord()
+		r.ExpirationDate = ""
+		_, err = Entry(ach.SHR, r)
+		require.ErrorIs(t, err, ach.ErrFieldRequired)
+	})
+
+	t.Run("MTE", func(t *testing.T) {
+		r := mockRecord()
+		r.ProcessingCode = "011000"
+		ed, err := Entry(ach.MTE, r)
+		require.NoError(t, err)
+		require.Equal(t, ach.SavingsDebit, ed.TransactionCode)
+		require.Equal(t, "4000123412341234", ed.IdentificationNumber)
+		require.Equal(t, "Jane Doe", ed.IndividualName)
+	})
+
+	t.Run("transaction codes", func(t *testing.T) {
+		cases := []struct {
+			messageType, processingCode string
+			transactionCode             int
+			cardType                    string
+		}{
+			{"0200", "002000", ach.CheckingDebit, "01"},
+			{"0200", "001000", ach.SavingsDebit, "01"},
+			{"0420", "002000", ach.CheckingCredit, "11"},
+			{"0200", "012000", ach.CheckingDebit, "02"},
+			{"0400", "012000", ach.CheckingCredit, "12"},
+			{"0200", "200010", ach.SavingsCredit, "13"},
+			{"0420", "200020", ach.CheckingDebit, "03"},
+		}
+		for _, tc := range cases {
+			r := mockRecord()
+			r.MessageType, r.ProcessingCode = tc.messageType, tc.processingCode
+			ed, err := Entry(ach.POS, r)
+			require.NoError(t, err, tc.processingCode)
+			require.Equal(t, tc.transactionCode, ed.TransactionCode, tc.processingCode)
+			require.Equal(t, tc.cardType, ed.DiscretionaryData, tc.processingCode)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		r := mockRecord()
+		r.ProcessingCode = "310000"
+		_, err := Entry(ach.POS, r)
+		require.ErrorIs(t, err, ErrProcessingCode)
+
+		r = mockRecord()
+		r.ReceivingInstitutionID = "231380105"
+		_, err = Entry(ach.POS, r)
+		require.ErrorContains(t, err, "checksum mismatch")
+
+		r = mockRecord()
+		r.Amount = 0
+		_, err = Entry(ach.POS, r)
+		require.ErrorIs(t, err, ach.ErrBatchAmountZero)
+
+		_, err = Entry(ach.PPD, mockRecord())
+		require.ErrorIs(t, err, ach.ErrSECCode)
+	})
+}
+
+func TestBatches(t *testing.T) {
+	first, second, third := mockRecord(), mockRecord(), mockRecord()
+	second.SettlementDate = second.SettlementDate.AddDate(0, 0, 1)
+	second.ProcessingCode = "200000"
+	third.STAN = "123457"
+
+	for _, secCode := range []string{ach.POS, ach.SHR, ach.MTE} {
+		t.Run(secCode, func(t *testing.T) {
+			batches, err := Batches(mockHeader(secCode), []Record{second, first, third})
+			require.NoError(t, err)
+			require.Len(t, batches, 2)
+
+			require.Equal(t, "261019", batches[0].GetHeader().EffectiveEntryDate)
+			require.Equal(t, 1, batches[0].GetHeader().BatchNumber)
+			require.Len(t, batches[0].GetEntries(), 2)
+			require.Equal(t, 5000, batches[0].GetControl().TotalDebitEntryDollarAmount)
+
+			require.Equal(t, "261020", batches[1].GetHeader().EffectiveEntryDate)
+			require.Equal(t, 2, batches[1].GetHeader().BatchNumber)
+			require.Len(t, batches[1].GetEntries(), 1)
+			require.Equal(t, 2500, batches[1].GetControl().TotalCreditEntryDollarAmount)
+
+			// Trace numbers continue across batches
+			entry := batches[1].GetEntries()[0]
+			require.Equal(t, "121042880000003", entry.TraceNumber)
+			require.Equal(t, entry.TraceNumber, entry.Addenda02.TraceNumber)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		r := mockRecord()
+		r.SettlementDate = time.Time{}
+		_, err := Batches(mockHeader(ach.POS), []Record{first, r})
+		require.ErrorContains(t, err, "record 1: missing DE 15 settlement date")
+
+		r = mockRecord()
+		r.AccountIdentification = ""
+		_, err = Batches(mockHeader(ach.POS), []Record{first, r})
+		require.ErrorContains(t, err, "record 1: DFIAccountNumber")
+	})
+}
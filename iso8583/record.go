@@ -0,0 +1,202 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package iso8583 converts card network authorization and settlement records into POS, SHR and MTE entries.
+//
+// Records hold the ISO 8583 data elements used by ACH: the card number, processing code, amount,
+// dates, audit number, terminal and card acceptor. Each entry is created with an Addenda02 record
+// describing the terminal, and entries are grouped into a batch for each settlement date.
+package iso8583
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Data element numbers read by ParseElements
+const (
+	ElementPAN                      = 2
+	ElementProcessingCode           = 3
+	ElementAmount                   = 4
+	ElementTransmissionDateTime     = 7
+	ElementSTAN                     = 11
+	ElementLocalTransactionDate     = 13
+	ElementExpirationDate           = 14
+	ElementSettlementDate           = 15
+	ElementMCC                      = 18
+	ElementRetrievalReferenceNumber = 37
+	ElementAuthorizationCode        = 38
+	ElementTerminalID               = 41
+	ElementCardAcceptorID           = 42
+	ElementCardAcceptorNameLocation = 43
+	ElementReceivingInstitutionID   = 100
+	ElementAccountIdentification    = 102
+)
+
+// Record is an ISO 8583 financial or settlement message reduced to the data elements used in ACH entries.
+type Record struct {
+	// MessageType is the message type indicator, such as 0200 (financial request) or 0420 (reversal advice).
+	MessageType string
+
+	// PAN is the primary account number of the card (DE 2).
+	PAN string
+
+	// ProcessingCode holds the transaction type and account types (DE 3), such as 002000 for a purchase from checking.
+	ProcessingCode string
+
+	// Amount of the transaction in cents (DE 4).
+	Amount int
+
+	// TransmissionDateTime is when the message was sent (DE 7).
+	TransmissionDateTime time.Time
+
+	// STAN is the system trace audit number assigned by the terminal (DE 11).
+	STAN string
+
+	// LocalTransactionDate is the date at the terminal (DE 13). TransmissionDateTime is used when it's zero.
+	LocalTransactionDate time.Time
+
+	// ExpirationDate of the card as YYMM (DE 14).
+	ExpirationDate string
+
+	// SettlementDate is when the network settles the transaction (DE 15).
+	SettlementDate time.Time
+
+	// MCC is the merchant category code (DE 18).
+	MCC string
+
+	// RetrievalReferenceNumber identifies the transaction to the acquirer (DE 37).
+	RetrievalReferenceNumber string
+
+	// AuthorizationCode was returned by the card issuer (DE 38).
+	AuthorizationCode string
+
+	// TerminalID identifies the card acceptor's terminal (DE 41).
+	TerminalID string
+
+	// CardAcceptorID identifies the merchant (DE 42).
+	CardAcceptorID string
+
+	// CardAcceptorNameLocation is the merchant's name, city, state and country (DE 43). See ParseCardAcceptor.
+	CardAcceptorNameLocation string
+
+	// ReceivingInstitutionID is the routing number of the cardholder's financial institution (DE 100).
+	ReceivingInstitutionID string
+
+	// AccountIdentification is the cardholder's deposit account (DE 102).
+	AccountIdentification string
+
+	// CardholderName is optional and not an ISO 8583 data element, it's often read from track 1 data.
+	CardholderName string
+}
+
+// IsReversal returns true for reversal (04xx) messages
+func (r Record) IsReversal() bool {
+	return strings.HasPrefix(r.MessageType, "04")
+}
+
+// ParseElements returns a Record from data elements keyed by their number. The month and day of
+// date elements are resolved to the year closest to received, which is typically when the records
+// were received from the network.
+func ParseElements(messageType string, elements map[int]string, received time.Time) (*Record, error) {
+	r := &Record{
+		MessageType:              messageType,
+		PAN:                      elements[ElementPAN],
+		ProcessingCode:           elements[ElementProcessingCode],
+		STAN:                     elements[ElementSTAN],
+		ExpirationDate:           elements[ElementExpirationDate],
+		MCC:                      elements[ElementMCC],
+		RetrievalReferenceNumber: elements[ElementRetrievalReferenceNumber],
+		AuthorizationCode:        elements[ElementAuthorizationCode],
+		TerminalID:               elements[ElementTerminalID],
+		CardAcceptorID:           elements[ElementCardAcceptorID],
+		CardAcceptorNameLocation: elements[ElementCardAcceptorNameLocation],
+		ReceivingInstitutionID:   elements[ElementReceivingInstitutionID],
+		AccountIdentification:    elements[ElementAccountIdentification],
+	}
+
+	var err error
+	if v := elements[ElementAmount]; v != "" {
+		r.Amount, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("DE %d amount %q: %w", ElementAmount, v, err)
+		}
+	}
+	if v := elements[ElementTransmissionDateTime]; v != "" {
+		r.TransmissionDateTime, err = resolveDate("0102150405", v, received)
+		if err != nil {
+			return nil, fmt.Errorf("DE %d transmission date and time: %w", ElementTransmissionDateTime, err)
+		}
+	}
+	if v := elements[ElementLocalTransactionDate]; v != "" {
+		r.LocalTransactionDate, err = resolveDate("0102", v, received)
+		if err != nil {
+			return nil, fmt.Errorf("DE %d local transaction date: %w", ElementLocalTransactionDate, err)
+		}
+	}
+	if v := elements[ElementSettlementDate]; v != "" {
+		r.SettlementDate, err = resolveDate("0102", v, received)
+		if err != nil {
+			return nil, fmt.Errorf("DE %d settlement date: %w", ElementSettlementDate, err)
+		}
+	}
+	return r, nil
+}
+
+// resolveDate parses value, which has no year, in the year placing it closest to received
+func resolveDate(layout, value string, received time.Time) (time.Time, error) {
+	t, err := time.ParseInLocation("2006"+layout, fmt.Sprintf("%04d%s", received.Year(), value), received.Location())
+	if err != nil {
+		return t, err
+	}
+	switch {
+	case t.After(received.AddDate(0, 6, 0)):
+		t = t.AddDate(-1, 0, 0)
+	case t.Before(received.AddDate(0, -6, 0)):
+		t = t.AddDate(1, 0, 0)
+	}
+	return t, nil
+}
+
+// CardAcceptor is the merchant name and location of DE 43
+type CardAcceptor struct {
+	Name    string
+	City    string
+	State   string
+	Country string
+}
+
+// ParseCardAcceptor splits the 40 character card acceptor name/location (DE 43) into the name
+// (positions 1-23), city (24-36), state (37-38) and country (39-40).
+func ParseCardAcceptor(nameLocation string) CardAcceptor {
+	runes := []rune(nameLocation)
+	field := func(start, length int) string {
+		if start >= len(runes) {
+			return ""
+		}
+		end := min(start+length, len(runes))
+		return strings.TrimSpace(string(runes[start:end]))
+	}
+	return CardAcceptor{
+		Name:    field(0, 23),
+		City:    field(23, 13),
+		State:   field(36, 2),
+		Country: field(38, 2),
+	}
+}
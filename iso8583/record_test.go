@@ -0,0 +1,73 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iso8583
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseElements(t *testing.T) {
+	received := time.Date(2026, time.January, 3, 9, 0, 0, 0, time.UTC)
+	r, err := ParseElements("0200", map[int]string{
+		ElementPAN:                      "4000123412341234",
+		ElementProcessingCode:           "002000",
+		ElementAmount:                   "000000002500",
+		ElementTransmissionDateTime:     "1231235959",
+		ElementSTAN:                     "123456",
+		ElementLocalTransactionDate:     "1231",
+		ElementExpirationDate:           "2807",
+		ElementSettlementDate:           "0102",
+		ElementMCC:                      "5411",
+		ElementRetrievalReferenceNumber: "536512345678",
+		ElementAuthorizationCode:        "A1B2C3",
+		ElementTerminalID:               "TERM0042",
+		ElementCardAcceptorNameLocation: "CORNER GROCERY         ANYTOWN      VAUS",
+		ElementReceivingInstitutionID:   "231380104",
+		ElementAccountIdentification:    "744567899",
+	}, received)
+	require.NoError(t, err)
+
+	require.Equal(t, 2500, r.Amount)
+	require.False(t, r.IsReversal())
+
+	// December dates resolve to the year before the records were received
+	require.Equal(t, time.Date(2025, time.December, 31, 23, 59, 59, 0, time.UTC), r.TransmissionDateTime)
+	require.Equal(t, time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC), r.LocalTransactionDate)
+	require.Equal(t, time.Date(2026, time.January, 2, 0, 0, 0, 0, time.UTC), r.SettlementDate)
+
+	_, err = ParseElements("0200", map[int]string{ElementAmount: "25.00"}, received)
+	require.ErrorContains(t, err, "DE 4 amount")
+
+	_, err = ParseElements("0200", map[int]string{ElementSettlementDate: "1340"}, received)
+	require.ErrorContains(t, err, "DE 15 settlement date")
+}
+
+func TestParseCardAcceptor(t *testing.T) {
+	require.Equal(t, CardAcceptor{
+		Name:    "CORNER GROCERY",
+		City:    "ANYTOWN",
+		State:   "VA",
+		Country: "US",
+	}, ParseCardAcceptor("CORNER GROCERY         ANYTOWN      VAUS"))
+
+	require.Equal(t, CardAcceptor{Name: "CORNER GROCERY", City: "ANY"}, ParseCardAcceptor("CORNER GROCERY         ANY"))
+	require.Equal(t, CardAcceptor{}, ParseCardAcceptor(""))
+}
@@ -32,6 +32,23 @@ type Iterator struct {
 	reader     *Reader
 	scanner    *bufio.Scanner
 	cachedLine string
+
+	// pending is the most recent entry read, which is returned once all of its addenda records are read
+	pending *IteratorEntry
+
+	// header and control are kept from the most recent file read
+	header  *FileHeader
+	control *FileControl
+}
+
+// IteratorEntry is a domestic or IAT entry returned by Iterator.Next along with the header of its batch.
+// Either Entry and BatchHeader or IATEntry and IATBatchHeader are set.
+type IteratorEntry struct {
+	BatchHeader *BatchHeader
+	Entry       *EntryDetail
+
+	IATBatchHeader *IATBatchHeader
+	IATEntry       *IATEntryDetail
 }
 
 // NewIterator returns an Iterator
@@ -55,6 +72,9 @@ func (i *Iterator) SetValidation(opts *ValidateOpts) {
 // GetHeader will return the FileHeader once encountered by the iterator.
 // Call NextEntry() at least once to populate the header.
 func (i *Iterator) GetHeader() *FileHeader {
+	if i.header != nil {
+		return i.header
+	}
 	if i.reader != nil {
 		return &i.reader.File.Header
 	}
@@ -64,6 +84,9 @@ func (i *Iterator) GetHeader() *FileHeader {
 // GetControl will return the FileControl once encountered by the iterator.
 // Call NextEntry() at least once to populate the control.
 func (i *Iterator) GetControl() *FileControl {
+	if i.control != nil {
+		return i.control
+	}
 	if i.reader != nil {
 		return &i.reader.File.Control
 	}
@@ -71,111 +94,146 @@ func (i *Iterator) GetControl() *FileControl {
 }
 
 // NextEntry will return the next available EntryDetail record and the BatchHeader the entry belongs to.
+// Once every entry is read nil values are returned.
 //
-// IAT entries are not currently supported.
+// IAT entries are skipped, use Next to read them.
 func (i *Iterator) NextEntry() (*BatchHeader, *EntryDetail, error) {
-	// Clear the reader's File
-	defer func() {
-		i.reader.File = File{}
-	}()
+	for {
+		entry, err := i.Next()
+		if err != nil || entry == nil {
+			return nil, nil, err
+		}
+		if entry.Entry != nil {
+			return entry.BatchHeader, entry.Entry, nil
+		}
+	}
+}
 
-	// Read the file one line at a time
-	line := i.cachedLine
-	if line != "" {
-		i.cachedLine = "" // clear cache
-	} else {
-		// Consume lines until we reach a non-empty line
-		for i.scanner.Scan() {
-			line = i.scanner.Text()
-			i.reader.lineNum++
-			if allSpaces(line) {
-				continue
+// Next will return the next available domestic or IAT entry along with the header of the batch it
+// belongs to. Every addenda record of the entry is read before it's returned. Once every entry is read
+// a nil IteratorEntry is returned.
+func (i *Iterator) Next() (*IteratorEntry, error) {
+	for {
+		line, ok := i.nextLine()
+		if !ok {
+			if err := i.scanner.Err(); err != nil {
+				return nil, fmt.Errorf("reading line %d failed: %w", i.reader.lineNum, err)
 			}
-			if line != "" {
-				break
+			// Return the last entry of the file
+			entry := i.pending
+			i.pending = nil
+			return entry, nil
+		}
+
+		// Addenda records belong to the pending entry
+		if i.pending != nil && strings.HasPrefix(line, entryAddendaPos) {
+			i.reader.line = line
+			if err := i.reader.parseEDAddenda(); err != nil {
+				return nil, fmt.Errorf("reading addenda on line %d failed: %w", i.reader.lineNum, err)
 			}
+			continue
+		}
+		if i.pending != nil {
+			i.cachedLine = line
+			entry := i.pending
+			i.pending = nil
+			return entry, nil
+		}
+
+		if err := i.readLine(line); err != nil {
+			return nil, err
 		}
-		// If we've exhausted all lines in the reader then quit
+	}
+}
+
+// nextLine returns the cached line or the next line which isn't blank
+func (i *Iterator) nextLine() (string, bool) {
+	if line := i.cachedLine; line != "" {
+		i.cachedLine = ""
+		return line, true
+	}
+	for i.scanner.Scan() {
+		line := i.scanner.Text()
+		i.reader.lineNum++
 		if line == "" || allSpaces(line) {
-			return nil, nil, nil
+			continue
 		}
+		return line, true
 	}
+	return "", false
+}
 
-	if err := i.reader.readLine(line); err != nil {
-		if base.Match(err, ErrFileEntryOutsideBatch) {
-			// Fake a Batch so we can parse entries
-			bh := NewBatchHeader()
-			bh.StandardEntryClassCode = PPD
-			i.reader.currentBatch, err = NewBatch(bh)
-			if err != nil {
-				return nil, nil, fmt.Errorf("faking batch for line %d failed: %w", i.reader.lineNum, err)
-			}
-			if i.reader.currentBatch == nil {
-				return nil, nil, fmt.Errorf("failed to create %s batch: %v", bh.StandardEntryClassCode, err)
-			}
-			if err := i.reader.readLine(line); err != nil {
-				return nil, nil, fmt.Errorf("reading line %d with fake BatchHeader failed: %w", i.reader.lineNum, err)
-			}
-		} else {
-			return nil, nil, fmt.Errorf("reading line %d failed: %w", i.reader.lineNum, err)
-		}
-	}
-
-	if i.reader.currentBatch != nil {
-		bh := i.reader.currentBatch.GetHeader()
-		entries := i.reader.currentBatch.GetEntries()
-		if len(entries) > 0 {
-			// Find the next entry to return and consume the file until we run out of
-			// addenda records or encounter a batch control/header record.
-			returnableEntry := entries[len(entries)-1]
-
-			// Read lines so long as we encounter an addenda or batch control record
-			for {
-				if i.scanner.Scan() {
-					foundLine := i.scanner.Text()
-					i.reader.lineNum++
-					if foundLine == "" {
-						break
-					}
-					switch {
-					case strings.HasPrefix(foundLine, entryDetailPos):
-						i.cachedLine = foundLine
-						return bh, returnableEntry, nil
-
-					case strings.HasPrefix(foundLine, entryAddendaPos):
-						i.reader.line = foundLine
-						if err := i.reader.parseEDAddenda(); err != nil {
-							return nil, nil, fmt.Errorf("reading addenda on line %d failed: %w", i.reader.lineNum, err)
-						}
-
-						entries := i.reader.currentBatch.GetEntries()
-						ed := entries[len(entries)-1]
-
-						return bh, ed, nil
-
-					case strings.HasPrefix(foundLine, batchControlPos):
-						// Do nothing with the Batch Control record
-						return bh, returnableEntry, nil
-
-					default:
-						if !strings.HasPrefix(foundLine, "9") {
-							i.cachedLine = foundLine
-						}
-						return bh, nil, nil
-					}
-				} else {
-					break // quit processing if we can't read another line
-				}
-			}
+// readLine parses a record which isn't an addenda of the pending entry. Entries are kept as pending
+// until their addenda records are read.
+func (i *Iterator) readLine(line string) error {
+	switch {
+	case strings.HasPrefix(line, batchHeaderPos):
+		// IAT entries have been returned, so start the next batch without them
+		i.reader.IATCurrentBatch = IATBatch{}
+
+	case strings.HasPrefix(line, batchControlPos):
+		// Do nothing with the Batch Control record, but close the batch
+		i.reader.currentBatch = nil
+		i.reader.IATCurrentBatch = IATBatch{}
+		return nil
+	}
 
-			return bh, returnableEntry, nil
-		} else {
-			// We processed the BatchHeader, but need to find an Entry Detail record
-			return i.NextEntry()
+	// Clear the reader's File once each record is read, but keep its header, control and validation options
+	defer func() {
+		if i.reader.File.Header.LineNumber > 0 {
+			header := i.reader.File.Header
+			i.header = &header
+			i.control = nil
+		}
+		if i.reader.File.Control.LineNumber > 0 {
+			control := i.reader.File.Control
+			i.control = &control
+			i.reader.currentBatch = nil
+			i.reader.IATCurrentBatch = IATBatch{}
+		}
+		opts := i.reader.File.validateOpts
+		i.reader.File = File{}
+		i.reader.File.SetValidation(opts)
+	}()
+
+	if err := i.reader.readLine(line); err != nil {
+		if !base.Match(err, ErrFileEntryOutsideBatch) {
+			return fmt.Errorf("reading line %d failed: %w", i.reader.lineNum, err)
+		}
+		// Fake a Batch so we can parse entries
+		bh := NewBatchHeader()
+		bh.StandardEntryClassCode = PPD
+		i.reader.currentBatch, err = NewBatch(bh)
+		if err != nil {
+			return fmt.Errorf("faking batch for line %d failed: %w", i.reader.lineNum, err)
+		}
+		if i.reader.currentBatch == nil {
+			return fmt.Errorf("failed to create %s batch: %v", bh.StandardEntryClassCode, err)
+		}
+		if err := i.reader.readLine(line); err != nil {
+			return fmt.Errorf("reading line %d with fake BatchHeader failed: %w", i.reader.lineNum, err)
 		}
 	}
 
-	return i.NextEntry()
+	if !strings.HasPrefix(line, entryDetailPos) {
+		return nil
+	}
+	if batch := i.reader.currentBatch; batch != nil {
+		if entries := batch.GetEntries(); len(entries) > 0 {
+			i.pending = &IteratorEntry{
+				BatchHeader: batch.GetHeader(),
+				Entry:       entries[len(entries)-1],
+			}
+		}
+		return nil
+	}
+	if batch := i.reader.IATCurrentBatch; batch.Header != nil && len(batch.Entries) > 0 {
+		i.pending = &IteratorEntry{
+			IATBatchHeader: batch.Header,
+			IATEntry:       batch.Entries[len(batch.Entries)-1],
+		}
+	}
+	return nil
 }
 
 func allSpaces(input string) bool {
@@ -53,6 +53,77 @@ func TestIterator(t *testing.T) {
 		}
 	})
 
+	t.Run("each entry once", func(t *testing.T) {
+		paths := []string{
+			filepath.Join("test", "testdata", "web-debit.ach"),
+			filepath.Join("test", "testdata", "rck.ach"),
+			filepath.Join("test", "testdata", "nonascii-utf8.ach"), // multiple Addenda05
+		}
+		for i := range paths {
+			t.Logf("checking %s", paths[i])
+
+			file := openFile(t, paths[i], nil)
+			iter := iteratorFromFile(t, paths[i], nil)
+
+			var expected []*EntryDetail
+			for j := range file.Batches {
+				expected = append(expected, file.Batches[j].GetEntries()...)
+			}
+			require.Equal(t, expected, collectEntries(t, iter))
+		}
+	})
+
+	t.Run("header and control", func(t *testing.T) {
+		where := filepath.Join("test", "testdata", "ppd-debit.ach")
+
+		file := openFile(t, where, nil)
+		iter := iteratorFromFile(t, where, nil)
+
+		entries := collectEntries(t, iter)
+		require.Len(t, entries, 1)
+
+		require.Equal(t, file.Header.ImmediateOrigin, iter.GetHeader().ImmediateOrigin)
+		require.Equal(t, file.Control.TotalDebitEntryDollarAmountInFile, iter.GetControl().TotalDebitEntryDollarAmountInFile)
+	})
+
+	t.Run("blank lines between addenda", func(t *testing.T) {
+		where := filepath.Join("test", "testdata", "nonascii-utf8.ach")
+		file := openFile(t, where, nil)
+
+		bs, err := os.ReadFile(where)
+		require.NoError(t, err)
+		data := strings.ReplaceAll(string(bs), "\n7", "\n\n   \n7")
+
+		entries := collectEntries(t, NewIterator(strings.NewReader(data)))
+		require.Len(t, entries, len(file.Batches[0].GetEntries()))
+		expected := file.Batches[0].GetEntries()[0].Addenda05
+		require.Len(t, entries[0].Addenda05, len(expected))
+		for i := range expected {
+			require.Equal(t, expected[i].String(), entries[0].Addenda05[i].String())
+		}
+	})
+
+	t.Run("IAT entries", func(t *testing.T) {
+		where := filepath.Join("test", "testdata", "iat-debit.ach")
+
+		file := openFile(t, where, nil)
+		iter := iteratorFromFile(t, where, nil)
+
+		for _, batch := range file.IATBatches {
+			for _, ed := range batch.Entries {
+				entry, err := iter.Next()
+				require.NoError(t, err)
+				require.Nil(t, entry.Entry)
+				require.Equal(t, batch.Header.String(), entry.IATBatchHeader.String())
+				require.Equal(t, ed, entry.IATEntry)
+			}
+		}
+		entry, err := iter.Next()
+		require.NoError(t, err)
+		require.Nil(t, entry)
+		require.Equal(t, file.Header.ImmediateOrigin, iter.GetHeader().ImmediateOrigin)
+	})
+
 	t.Run("bh-ed-ad-bh-ed-ad-ed-ad", func(t *testing.T) {
 		paths := []string{
 			filepath.Join("test", "testdata", "bh-ed-ad-bh-ed-ad-ed-ad.ach"),
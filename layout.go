@@ -0,0 +1,647 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/moov-io/base"
+)
+
+// FieldType describes the characters a field in a fixed-width record holds.
+type FieldType int
+
+const (
+	// Alphanumeric fields hold any printable characters
+	Alphanumeric FieldType = iota
+	// Numeric fields only hold the digits 0-9
+	Numeric
+)
+
+func (t FieldType) String() string {
+	if t == Numeric {
+		return "Numeric"
+	}
+	return "Alphanumeric"
+}
+
+// Justification describes how a value shorter than its field is padded. Numeric fields are padded
+// with zeros and Alphanumeric fields with spaces.
+type Justification int
+
+const (
+	LeftJustified Justification = iota
+	RightJustified
+)
+
+// Field describes one field of a fixed-width record.
+type Field struct {
+	// Name is the struct field holding the value, which must be a string or int. Fields without a
+	// Name are written as their Constant, such as a record type code or reserved blanks.
+	Name string
+
+	// Start is the 1-based position of the field's first character, as in the Nacha rules
+	Start  int
+	Length int
+
+	Type          FieldType
+	Justification Justification
+
+	// Constant is the value of fields without a Name, blanks when empty
+	Constant string
+
+	// Validator checks the field's value as read from a record, it's optional
+	Validator func(value string) error
+}
+
+// End is the 1-based position of the field's last character
+func (f Field) End() int {
+	return f.Start + f.Length - 1
+}
+
+// Layout describes each field of a fixed-width record. Layouts are used to inspect the Nacha
+// format and to Parse and Format records, including bank-specific variants of a record.
+//
+// The record types in this package have their own optimized Parse and String methods,
+// which their Layouts are tested to match.
+type Layout struct {
+	// Record is the name of the record type, such as "EntryDetail"
+	Record string
+	Fields []Field
+}
+
+// LookupLayout returns a copy of the Layout for a record type, such as "BatchHeader", or nil if
+// the record type is unknown.
+func LookupLayout(record string) *Layout {
+	for _, l := range layouts {
+		if strings.EqualFold(l.Record, record) {
+			out := l.clone()
+			return &out
+		}
+	}
+	return nil
+}
+
+// Layouts returns a copy of the Layout of every record type.
+func Layouts() []Layout {
+	out := make([]Layout, len(layouts))
+	for i := range layouts {
+		out[i] = layouts[i].clone()
+	}
+	return out
+}
+
+func (l Layout) clone() Layout {
+	l.Fields = append([]Field(nil), l.Fields...)
+	return l
+}
+
+// Field returns the Field with a Name, or false if none exists.
+func (l *Layout) Field(name string) (Field, bool) {
+	for _, f := range l.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Validate checks the Layout's fields are in order, don't overlap and cover every position of a record.
+func (l *Layout) Validate() error {
+	next := 1
+	for _, f := range l.Fields {
+		if f.Length <= 0 {
+			return fmt.Errorf("%s field at position %d has invalid length %d", l.Record, f.Start, f.Length)
+		}
+		if f.Start != next {
+			return fmt.Errorf("%s field at position %d expected at position %d", l.Record, f.Start, next)
+		}
+		if f.Name == "" && utf8.RuneCountInString(f.Constant) > f.Length {
+			return fmt.Errorf("%s constant %q at position %d is longer than %d", l.Record, f.Constant, f.Start, f.Length)
+		}
+		next = f.End() + 1
+	}
+	if next != lineLength+1 {
+		return fmt.Errorf("%s fields end at position %d instead of %d", l.Record, next-1, lineLength)
+	}
+	return nil
+}
+
+// Parse reads each field of record into the struct v points to. String values have their padding
+// trimmed and int values are parsed from their digits.
+func (l *Layout) Parse(record string, v interface{}) error {
+	runes := []rune(record)
+	if len(runes) != lineLength {
+		return NewRecordWrongLengthErr(len(runes))
+	}
+	rv, err := structValue(v)
+	if err != nil {
+		return err
+	}
+
+	var c converters
+	for _, f := range l.Fields {
+		if f.Name == "" {
+			continue
+		}
+		field, err := l.structField(rv, f)
+		if err != nil {
+			return err
+		}
+		value := l.value(runes, f)
+		if field.Kind() == reflect.String {
+			field.SetString(c.parseStringField(value))
+		} else {
+			field.SetInt(int64(c.parseNumField(value)))
+		}
+	}
+	return nil
+}
+
+// Format writes the fields of the struct v points to as a fixed-width record.
+func (l *Layout) Format(v interface{}) (string, error) {
+	rv, err := structValue(v)
+	if err != nil {
+		return "", err
+	}
+
+	buf := getBuffer()
+	defer saveBuffer(buf)
+
+	var c converters
+	for _, f := range l.Fields {
+		if f.Name == "" {
+			buf.WriteString(c.alphaField(f.Constant, uint(f.Length))) //nolint:gosec
+			continue
+		}
+		field, err := l.structField(rv, f)
+		if err != nil {
+			return "", err
+		}
+		if field.Kind() == reflect.String {
+			buf.WriteString(f.format(&c, field.String()))
+		} else {
+			buf.WriteString(c.numericField(int(field.Int()), uint(f.Length))) //nolint:gosec
+		}
+	}
+	return buf.String(), nil
+}
+
+// ValidateRecord checks record has the Layout's constants and runs each Field's Validator.
+func (l *Layout) ValidateRecord(record string) error {
+	runes := []rune(record)
+	if len(runes) != lineLength {
+		return NewRecordWrongLengthErr(len(runes))
+	}
+
+	var el base.ErrorList
+	for _, f := range l.Fields {
+		value := l.value(runes, f)
+		if f.Name == "" {
+			if strings.TrimSpace(value) != strings.TrimSpace(f.Constant) {
+				el.Add(fmt.Errorf("%s position %d: found %q instead of %q", l.Record, f.Start, value, f.Constant))
+			}
+			continue
+		}
+		if f.Validator != nil {
+			if err := f.Validator(value); err != nil {
+				el.Add(fieldError(f.Name, err, value))
+			}
+		}
+	}
+	if el.Empty() {
+		return nil
+	}
+	return el
+}
+
+func (l *Layout) value(runes []rune, f Field) string {
+	start, end := f.Start-1, f.End()
+	if start < 0 || end > len(runes) {
+		return ""
+	}
+	return string(runes[start:end])
+}
+
+func (l *Layout) structField(rv reflect.Value, f Field) (reflect.Value, error) {
+	field := rv.FieldByName(f.Name)
+	if !field.IsValid() || !field.CanSet() {
+		return reflect.Value{}, fmt.Errorf("%s field %s not found on %s", l.Record, f.Name, rv.Type())
+	}
+	switch field.Kind() {
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field, nil
+	}
+	return reflect.Value{}, fmt.Errorf("%s field %s is an unsupported %s", l.Record, f.Name, field.Kind())
+}
+
+// format pads value to the field's length like the record types' String methods. Values which
+// are too long are truncated, keeping the least significant digits of ints.
+func (f Field) format(c *converters, value string) string {
+	length := uint(f.Length) //nolint:gosec
+	switch {
+	case f.Justification == RightJustified && f.Type == Numeric:
+		return c.stringField(value, length)
+	case f.Justification == RightJustified:
+		if count := utf8.RuneCountInString(value); count < f.Length {
+			return strings.Repeat(" ", f.Length-count) + value
+		}
+	}
+	return c.alphaField(value, length)
+}
+
+func structValue(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, errors.New("layout requires a non-nil pointer to a struct")
+	}
+	return rv.Elem(), nil
+}
+
+func validateNumeric(value string) error {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return ErrNonNumeric
+		}
+	}
+	return nil
+}
+
+func validateAlphanumeric(value string) error {
+	var v validator
+	return v.isAlphanumeric(value)
+}
+
+func alpha(name string, start, length int) Field {
+	return Field{Name: name, Start: start, Length: length, Type: Alphanumeric, Validator: validateAlphanumeric}
+}
+
+func numeric(name string, start, length int) Field {
+	return Field{Name: name, Start: start, Length: length, Type: Numeric, Justification: RightJustified, Validator: validateNumeric}
+}
+
+func constant(value string, start int) Field {
+	return Field{Start: start, Length: len(value), Type: Numeric, Constant: value}
+}
+
+func blank(start, end int) Field {
+	return Field{Start: start, Length: end - start + 1, Type: Alphanumeric}
+}
+
+func addendaLayout(record, typeCode string, fields ...Field) Layout {
+	head := []Field{constant(entryAddendaPos, 1), {Name: "TypeCode", Start: 2, Length: 2, Type: Alphanumeric, Validator: func(value string) error {
+		if value != typeCode {
+			return ErrAddendaTypeCode
+		}
+		return nil
+	}}}
+	return Layout{Record: record, Fields: append(head, fields...)}
+}
+
+// entryDetailSequenceNumber is the last field of IAT addenda records
+var entryDetailSequenceNumber = numeric("EntryDetailSequenceNumber", 88, 7)
+
+var layouts = []Layout{
+	{
+		Record: "FileHeader",
+		Fields: []Field{
+			constant(fileHeaderPos, 1),
+			constant("01", 2),
+			blank(4, 4),
+			numeric("ImmediateDestination", 5, 9),
+			blank(14, 14),
+			numeric("ImmediateOrigin", 15, 9),
+			numeric("FileCreationDate", 24, 6),
+			numeric("FileCreationTime", 30, 4),
+			alpha("FileIDModifier", 34, 1),
+			constant("094", 35),
+			constant("10", 38),
+			alpha("FormatCode", 40, 1),
+			alpha("ImmediateDestinationName", 41, 23),
+			alpha("ImmediateOriginName", 64, 23),
+			alpha("ReferenceCode", 87, 8),
+		},
+	},
+	{
+		Record: "BatchHeader",
+		Fields: []Field{
+			constant(batchHeaderPos, 1),
+			numeric("ServiceClassCode", 2, 3),
+			alpha("CompanyName", 5, 16),
+			alpha("CompanyDiscretionaryData", 21, 20),
+			alpha("CompanyIdentification", 41, 10),
+			alpha("StandardEntryClassCode", 51, 3),
+			alpha("CompanyEntryDescription", 54, 10),
+			alpha("CompanyDescriptiveDate", 64, 6),
+			numeric("EffectiveEntryDate", 70, 6),
+			alpha("SettlementDate", 76, 3),
+			numeric("OriginatorStatusCode", 79, 1),
+			numeric("ODFIIdentification", 80, 8),
+			numeric("BatchNumber", 88, 7),
+		},
+	},
+	{
+		Record: "EntryDetail",
+		Fields: []Field{
+			constant(entryDetailPos, 1),
+			numeric("TransactionCode", 2, 2),
+			numeric("RDFIIdentification", 4, 8),
+			numeric("CheckDigit", 12, 1),
+			alpha("DFIAccountNumber", 13, 17),
+			numeric("Amount", 30, 10),
+			alpha("IdentificationNumber", 40, 15),
+			alpha("IndividualName", 55, 22),
+			alpha("DiscretionaryData", 77, 2),
+			numeric("AddendaRecordIndicator", 79, 1),
+			numeric("TraceNumber", 80, 15),
+		},
+	},
+	{
+		// CIE entries swap the positions of the receiver's name and identification
+		Record: "EntryDetailCIE",
+		Fields: []Field{
+			constant(entryDetailPos, 1),
+			numeric("TransactionCode", 2, 2),
+			numeric("RDFIIdentification", 4, 8),
+			numeric("CheckDigit", 12, 1),
+			alpha("DFIAccountNumber", 13, 17),
+			numeric("Amount", 30, 10),
+			alpha("IndividualName", 40, 15),
+			alpha("IdentificationNumber", 55, 22),
+			alpha("DiscretionaryData", 77, 2),
+			numeric("AddendaRecordIndicator", 79, 1),
+			numeric("TraceNumber", 80, 15),
+		},
+	},
+	{
+		Record: "BatchControl",
+		Fields: []Field{
+			constant(batchControlPos, 1),
+			numeric("ServiceClassCode", 2, 3),
+			numeric("EntryAddendaCount", 5, 6),
+			numeric("EntryHash", 11, 10),
+			numeric("TotalDebitEntryDollarAmount", 21, 12),
+			numeric("TotalCreditEntryDollarAmount", 33, 12),
+			alpha("CompanyIdentification", 45, 10),
+			alpha("MessageAuthenticationCode", 55, 19),
+			blank(74, 79),
+			numeric("ODFIIdentification", 80, 8),
+			numeric("BatchNumber", 88, 7),
+		},
+	},
+	{
+		Record: "FileControl",
+		Fields: []Field{
+			constant(fileControlPos, 1),
+			numeric("BatchCount", 2, 6),
+			numeric("BlockCount", 8, 6),
+			numeric("EntryAddendaCount", 14, 8),
+			numeric("EntryHash", 22, 10),
+			numeric("TotalDebitEntryDollarAmountInFile", 32, 12),
+			numeric("TotalCreditEntryDollarAmountInFile", 44, 12),
+			blank(56, 94),
+		},
+	},
+	addendaLayout("Addenda02", "02",
+		alpha("ReferenceInformationOne", 4, 7),
+		alpha("ReferenceInformationTwo", 11, 3),
+		alpha("TerminalIdentificationCode", 14, 6),
+		alpha("TransactionSerialNumber", 20, 6),
+		alpha("TransactionDate", 26, 4),
+		alpha("AuthorizationCodeOrExpireDate", 30, 6),
+		alpha("TerminalLocation", 36, 27),
+		alpha("TerminalCity", 63, 15),
+		alpha("TerminalState", 78, 2),
+		numeric("TraceNumber", 80, 15),
+	),
+	addendaLayout("Addenda05", "05",
+		alpha("PaymentRelatedInformation", 4, 80),
+		numeric("SequenceNumber", 84, 4),
+		numeric("EntryDetailSequenceNumber", 88, 7),
+	),
+	addendaLayout("Addenda98", "98",
+		alpha("ChangeCode", 4, 3),
+		numeric("OriginalTrace", 7, 15),
+		blank(22, 27),
+		numeric("OriginalDFI", 28, 8),
+		alpha("CorrectedData", 36, 29),
+		blank(65, 79),
+		numeric("TraceNumber", 80, 15),
+	),
+	addendaLayout("Addenda98Refused", "98",
+		alpha("RefusedChangeCode", 4, 3),
+		numeric("OriginalTrace", 7, 15),
+		blank(22, 27),
+		numeric("OriginalDFI", 28, 8),
+		alpha("CorrectedData", 36, 29),
+		alpha("ChangeCode", 65, 3),
+		numeric("TraceSequenceNumber", 68, 7),
+		blank(75, 79),
+		numeric("TraceNumber", 80, 15),
+	),
+	addendaLayout("Addenda99", "99",
+		alpha("ReturnCode", 4, 3),
+		numeric("OriginalTrace", 7, 15),
+		Field{Name: "DateOfDeath", Start: 22, Length: 6, Type: Alphanumeric},
+		numeric("OriginalDFI", 28, 8),
+		alpha("AddendaInformation", 36, 44),
+		numeric("TraceNumber", 80, 15),
+	),
+	addendaLayout("Addenda99Dishonored", "99",
+		alpha("DishonoredReturnReasonCode", 4, 3),
+		numeric("OriginalEntryTraceNumber", 7, 15),
+		blank(22, 27),
+		numeric("OriginalReceivingDFIIdentification", 28, 8),
+		blank(36, 38),
+		numeric("ReturnTraceNumber", 39, 15),
+		numeric("ReturnSettlementDate", 54, 3),
+		numeric("ReturnReasonCode", 57, 2),
+		alpha("AddendaInformation", 59, 21),
+		numeric("TraceNumber", 80, 15),
+	),
+	addendaLayout("Addenda99Contested", "99",
+		alpha("ContestedReturnCode", 4, 3),
+		numeric("OriginalEntryTraceNumber", 7, 15),
+		numeric("DateOriginalEntryReturned", 22, 6),
+		numeric("OriginalReceivingDFIIdentification", 28, 8),
+		numeric("OriginalSettlementDate", 36, 3),
+		numeric("ReturnTraceNumber", 39, 15),
+		numeric("ReturnSettlementDate", 54, 3),
+		numeric("ReturnReasonCode", 57, 2),
+		numeric("DishonoredReturnTraceNumber", 59, 15),
+		numeric("DishonoredReturnSettlementDate", 74, 3),
+		numeric("DishonoredReturnReasonCode", 77, 2),
+		blank(79, 79),
+		numeric("TraceNumber", 80, 15),
+	),
+	{
+		Record: "IATBatchHeader",
+		Fields: []Field{
+			constant(batchHeaderPos, 1),
+			numeric("ServiceClassCode", 2, 3),
+			alpha("IATIndicator", 5, 16),
+			alpha("ForeignExchangeIndicator", 21, 2),
+			numeric("ForeignExchangeReferenceIndicator", 23, 1),
+			alpha("ForeignExchangeReference", 24, 15),
+			alpha("ISODestinationCountryCode", 39, 2),
+			alpha("OriginatorIdentification", 41, 10),
+			alpha("StandardEntryClassCode", 51, 3),
+			alpha("CompanyEntryDescription", 54, 10),
+			alpha("ISOOriginatingCurrencyCode", 64, 3),
+			alpha("ISODestinationCurrencyCode", 67, 3),
+			numeric("EffectiveEntryDate", 70, 6),
+			alpha("SettlementDate", 76, 3),
+			numeric("OriginatorStatusCode", 79, 1),
+			numeric("ODFIIdentification", 80, 8),
+			numeric("BatchNumber", 88, 7),
+		},
+	},
+	{
+		Record: "IATEntryDetail",
+		Fields: []Field{
+			constant(entryDetailPos, 1),
+			numeric("TransactionCode", 2, 2),
+			numeric("RDFIIdentification", 4, 8),
+			numeric("CheckDigit", 12, 1),
+			numeric("AddendaRecords", 13, 4),
+			blank(17, 29),
+			numeric("Amount", 30, 10),
+			alpha("DFIAccountNumber", 40, 35),
+			blank(75, 76),
+			alpha("OFACScreeningIndicator", 77, 1),
+			alpha("SecondaryOFACScreeningIndicator", 78, 1),
+			numeric("AddendaRecordIndicator", 79, 1),
+			numeric("TraceNumber", 80, 15),
+		},
+	},
+	addendaLayout("Addenda10", "10",
+		alpha("TransactionTypeCode", 4, 3),
+		numeric("ForeignPaymentAmount", 7, 18),
+		alpha("ForeignTraceNumber", 25, 22),
+		alpha("Name", 47, 35),
+		blank(82, 87),
+		entryDetailSequenceNumber,
+	),
+	addendaLayout("Addenda11", "11",
+		alpha("OriginatorName", 4, 35),
+		alpha("OriginatorStreetAddress", 39, 35),
+		blank(74, 87),
+		entryDetailSequenceNumber,
+	),
+	addendaLayout("Addenda12", "12",
+		alpha("OriginatorCityStateProvince", 4, 35),
+		alpha("OriginatorCountryPostalCode", 39, 35),
+		alpha("OriginatorDateOfBirth", 74, 10),
+		blank(84, 87),
+		entryDetailSequenceNumber,
+	),
+	addendaLayout("Addenda13", "13",
+		alpha("ODFIName", 4, 35),
+		alpha("ODFIIDNumberQualifier", 39, 2),
+		alpha("ODFIIdentification", 41, 34),
+		alpha("ODFIBranchCountryCode", 75, 3),
+		blank(78, 87),
+		entryDetailSequenceNumber,
+	),
+	addendaLayout("Addenda14", "14",
+		alpha("RDFIName", 4, 35),
+		alpha("RDFIIDNumberQualifier", 39, 2),
+		alpha("RDFIIdentification", 41, 34),
+		alpha("RDFIBranchCountryCode", 75, 3),
+		blank(78, 87),
+		entryDetailSequenceNumber,
+	),
+	addendaLayout("Addenda15", "15",
+		alpha("ReceiverIDNumber", 4, 15),
+		alpha("ReceiverStreetAddress", 19, 35),
+		blank(54, 87),
+		entryDetailSequenceNumber,
+	),
+	addendaLayout("Addenda16", "16",
+		alpha("ReceiverCityStateProvince", 4, 35),
+		alpha("ReceiverCountryPostalCode", 39, 35),
+		alpha("ReceiverDateOfBirth", 74, 10),
+		blank(84, 87),
+		entryDetailSequenceNumber,
+	),
+	addendaLayout("Addenda17", "17",
+		alpha("PaymentRelatedInformation", 4, 80),
+		numeric("SequenceNumber", 84, 4),
+		entryDetailSequenceNumber,
+	),
+	addendaLayout("Addenda18", "18",
+		alpha("ForeignCorrespondentBankName", 4, 35),
+		alpha("ForeignCorrespondentBankIDNumberQualifier", 39, 2),
+		alpha("ForeignCorrespondentBankIDNumber", 41, 34),
+		alpha("ForeignCorrespondentBankBranchCountryCode", 75, 3),
+		blank(78, 83),
+		numeric("SequenceNumber", 84, 4),
+		entryDetailSequenceNumber,
+	),
+	{
+		Record: "ADVEntryDetail",
+		Fields: []Field{
+			constant(entryDetailPos, 1),
+			numeric("TransactionCode", 2, 2),
+			numeric("RDFIIdentification", 4, 8),
+			numeric("CheckDigit", 12, 1),
+			alpha("DFIAccountNumber", 13, 15),
+			numeric("Amount", 28, 12),
+			numeric("AdviceRoutingNumber", 40, 9),
+			alpha("FileIdentification", 49, 5),
+			alpha("ACHOperatorData", 54, 1),
+			alpha("IndividualName", 55, 22),
+			alpha("DiscretionaryData", 77, 2),
+			numeric("AddendaRecordIndicator", 79, 1),
+			alpha("ACHOperatorRoutingNumber", 80, 8),
+			numeric("JulianDay", 88, 3),
+			numeric("SequenceNumber", 91, 4),
+		},
+	},
+	{
+		Record: "ADVBatchControl",
+		Fields: []Field{
+			constant(batchControlPos, 1),
+			numeric("ServiceClassCode", 2, 3),
+			numeric("EntryAddendaCount", 5, 6),
+			numeric("EntryHash", 11, 10),
+			numeric("TotalDebitEntryDollarAmount", 21, 20),
+			numeric("TotalCreditEntryDollarAmount", 41, 20),
+			alpha("ACHOperatorData", 61, 19),
+			numeric("ODFIIdentification", 80, 8),
+			numeric("BatchNumber", 88, 7),
+		},
+	},
+	{
+		Record: "ADVFileControl",
+		Fields: []Field{
+			constant(fileControlPos, 1),
+			numeric("BatchCount", 2, 6),
+			numeric("BlockCount", 8, 6),
+			numeric("EntryAddendaCount", 14, 8),
+			numeric("EntryHash", 22, 10),
+			numeric("TotalDebitEntryDollarAmountInFile", 32, 20),
+			numeric("TotalCreditEntryDollarAmountInFile", 52, 20),
+			blank(72, 94),
+		},
+	},
+}
@@ -0,0 +1,245 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stringer interface {
+	String() string
+}
+
+// layoutRecords returns each record of file keyed by the name of its Layout
+func layoutRecords(file *File) map[string][]stringer {
+	records := make(map[string][]stringer)
+	add := func(name string, r stringer) {
+		if !reflect.ValueOf(r).IsNil() {
+			records[name] = append(records[name], r)
+		}
+	}
+	add("FileHeader", &file.Header)
+	if file.IsADV() {
+		add("ADVFileControl", &file.ADVControl)
+	} else {
+		add("FileControl", &file.Control)
+	}
+	for _, b := range file.Batches {
+		add("BatchHeader", b.GetHeader())
+		if b.GetHeader().StandardEntryClassCode == ADV {
+			add("ADVBatchControl", b.GetADVControl())
+			for _, e := range b.GetADVEntries() {
+				add("ADVEntryDetail", e)
+			}
+			continue
+		}
+		add("BatchControl", b.GetControl())
+		for _, e := range b.GetEntries() {
+			if b.GetHeader().StandardEntryClassCode == CIE {
+				add("EntryDetailCIE", e)
+			} else {
+				add("EntryDetail", e)
+			}
+			add("Addenda02", e.Addenda02)
+			for _, a := range e.Addenda05 {
+				add("Addenda05", a)
+			}
+			add("Addenda98", e.Addenda98)
+			add("Addenda98Refused", e.Addenda98Refused)
+			add("Addenda99", e.Addenda99)
+			add("Addenda99Dishonored", e.Addenda99Dishonored)
+			add("Addenda99Contested", e.Addenda99Contested)
+		}
+	}
+	for _, b := range file.IATBatches {
+		add("IATBatchHeader", b.GetHeader())
+		add("BatchControl", b.GetControl())
+		for _, e := range b.GetEntries() {
+			add("IATEntryDetail", e)
+			add("Addenda10", e.Addenda10)
+			add("Addenda11", e.Addenda11)
+			add("Addenda12", e.Addenda12)
+			add("Addenda13", e.Addenda13)
+			add("Addenda14", e.Addenda14)
+			add("Addenda15", e.Addenda15)
+			add("Addenda16", e.Addenda16)
+			for _, a := range e.Addenda17 {
+				add("Addenda17", a)
+			}
+			for _, a := range e.Addenda18 {
+				add("Addenda18", a)
+			}
+			add("Addenda98", e.Addenda98)
+			add("Addenda99", e.Addenda99)
+		}
+	}
+	return records
+}
+
+func TestLayouts(t *testing.T) {
+	names := make(map[string]bool)
+	for _, l := range Layouts() {
+		require.NoError(t, l.Validate(), l.Record)
+		require.False(t, names[l.Record], "duplicate layout %s", l.Record)
+		names[l.Record] = true
+	}
+
+	layout := LookupLayout("entrydetail")
+	require.NotNil(t, layout)
+	require.Equal(t, "EntryDetail", layout.Record)
+	require.Nil(t, LookupLayout("missing"))
+
+	// Lookups return a copy
+	layout.Fields[1].Length = 4
+	require.Error(t, layout.Validate())
+	require.NoError(t, LookupLayout("EntryDetail").Validate())
+
+	field, ok := LookupLayout("BatchHeader").Field("CompanyIdentification")
+	require.True(t, ok)
+	require.Equal(t, 41, field.Start)
+	require.Equal(t, 50, field.End())
+	require.Equal(t, Alphanumeric, field.Type)
+}
+
+func TestLayout__RoundTrip(t *testing.T) {
+	paths := []string{
+		filepath.Join("test", "testdata", "ppd-debit.ach"),
+		filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"),
+		filepath.Join("test", "testdata", "cor-example.ach"),
+		filepath.Join("test", "testdata", "return-WEB.ach"),
+		filepath.Join("test", "testdata", "20180716-IAT-A17-A18.ach"),
+		filepath.Join("test", "testdata", "iat-addenda98.ach"),
+		filepath.Join("test", "testdata", "iat-addenda99.ach"),
+		filepath.Join("test", "testdata", "adv.ach"),
+		filepath.Join("examples", "testdata", "cie-credit.ach"),
+	}
+
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		file, err := ReadFile(path)
+		require.NoError(t, err, path)
+
+		records := layoutRecords(file)
+		for name, rs := range records {
+			for _, r := range rs {
+				checkLayoutRoundTrip(t, name, r)
+			}
+			seen[name] = true
+		}
+	}
+
+	// Records missing from the testdata files
+	checkLayoutRoundTrip(t, "Addenda02", mockAddenda02())
+	checkLayoutRoundTrip(t, "Addenda98Refused", mockAddenda98Refused())
+	checkLayoutRoundTrip(t, "Addenda99Dishonored", mockAddenda99Dishonored())
+	checkLayoutRoundTrip(t, "Addenda99Contested", mockAddenda99Contested())
+	for _, name := range []string{"Addenda02", "Addenda98Refused", "Addenda99Dishonored", "Addenda99Contested"} {
+		seen[name] = true
+	}
+
+	for _, l := range Layouts() {
+		require.True(t, seen[l.Record], "%s layout is untested", l.Record)
+	}
+}
+
+func checkLayoutRoundTrip(t *testing.T, name string, record stringer) {
+	t.Helper()
+
+	layout := LookupLayout(name)
+	require.NotNil(t, layout, name)
+
+	line := record.String()
+	require.NoError(t, layout.ValidateRecord(line), name)
+
+	formatted, err := layout.Format(record)
+	require.NoError(t, err, name)
+	require.Equal(t, line, formatted, name)
+
+	// Parsing the line and formatting it again reproduces the line
+	parsed := reflect.New(reflect.TypeOf(record).Elem()).Interface()
+	require.NoError(t, layout.Parse(line, parsed), name)
+	formatted, err = layout.Format(parsed)
+	require.NoError(t, err, name)
+	require.Equal(t, line, formatted, name)
+
+	// Formatting what the record's own Parse method reads reproduces the line. Variants of a record,
+	// such as EntryDetailCIE, are rearranged by their batch instead.
+	if typ := reflect.TypeOf(record).Elem(); typ.Name() == name {
+		own := reflect.New(typ).Interface()
+		own.(interface{ Parse(string) }).Parse(line)
+		formatted, err = layout.Format(own)
+		require.NoError(t, err, name)
+		require.Equal(t, line, formatted, name)
+	}
+}
+
+func TestLayout__ValidateRecord(t *testing.T) {
+	layout := LookupLayout("EntryDetail")
+	line := mockPPDEntryDetail().String()
+	require.NoError(t, layout.ValidateRecord(line))
+
+	err := layout.ValidateRecord(line[:90])
+	require.ErrorContains(t, err, NewRecordWrongLengthErr(90).Error())
+
+	line = "5" + line[1:3] + "A" + line[4:]
+	err = layout.ValidateRecord(line)
+	require.ErrorContains(t, err, `found "5" instead of "6"`)
+	require.ErrorContains(t, err, "RDFIIdentification")
+	require.ErrorContains(t, err, ErrNonNumeric.Error())
+}
+
+func TestLayout__CustomRecord(t *testing.T) {
+	// A bank-specific variant of Addenda05 with its own fields
+	type invoice struct {
+		Invoice  string
+		Amount   int
+		Sequence int
+	}
+	layout := Layout{
+		Record: "Invoice",
+		Fields: []Field{
+			{Start: 1, Length: 3, Constant: "705"},
+			{Name: "Invoice", Start: 4, Length: 20, Type: Alphanumeric, Justification: RightJustified},
+			{Name: "Amount", Start: 24, Length: 10, Type: Numeric, Justification: RightJustified},
+			{Start: 34, Length: 54},
+			{Name: "Sequence", Start: 88, Length: 7, Type: Numeric, Justification: RightJustified},
+		},
+	}
+	require.NoError(t, layout.Validate())
+
+	line, err := layout.Format(&invoice{Invoice: "INV-42", Amount: 12345, Sequence: 1})
+	require.NoError(t, err)
+	require.Len(t, line, RecordLength)
+	require.Equal(t, "705              INV-420000012345", strings.TrimRight(line[:34], " "))
+	require.True(t, strings.HasSuffix(line, "0000001"))
+
+	var out invoice
+	require.NoError(t, layout.Parse(line, &out))
+	require.Equal(t, invoice{Invoice: "INV-42", Amount: 12345, Sequence: 1}, out)
+
+	require.ErrorContains(t, layout.Parse(line, out), "pointer to a struct")
+
+	layout.Fields[1].Name = "Missing"
+	_, err = layout.Format(&out)
+	require.ErrorContains(t, err, "Invoice field Missing not found")
+}
@@ -27,6 +27,8 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 
@@ -88,6 +90,115 @@ type Conditions struct {
 
 	// MaxDollarAmount will limit each merged file's total dollar amount.
 	MaxDollarAmount int64 `json:"maxDollarAmount"`
+
+	// MaxCreditAmount will limit each merged file's total credit amount.
+	MaxCreditAmount int64 `json:"maxCreditAmount"`
+
+	// MaxDebitAmount will limit each merged file's total debit amount.
+	MaxDebitAmount int64 `json:"maxDebitAmount"`
+
+	// MaxBatches will limit the number of batches in each merged file.
+	MaxBatches int `json:"maxBatches"`
+
+	// MaxEntriesPerBatch will limit the number of entries in each merged batch.
+	// Entries over the limit are placed into another batch with the same BatchHeader.
+	MaxEntriesPerBatch int `json:"maxEntriesPerBatch"`
+
+	// GroupBy will keep entries with different values for each field in separate merged files.
+	GroupBy []MergeGroupKey `json:"groupBy"`
+
+	// Separate will keep entries matching each MergeSeparation in their own merged files.
+	// Entries are placed with the first MergeSeparation they match.
+	Separate []MergeSeparation `json:"separate"`
+
+	// SortOutput will order merged files by ImmediateDestination, ImmediateOrigin and their
+	// GroupBy / Separate values rather than the order files were merged in. This produces the
+	// same output from MergeDir regardless of the order files are read.
+	SortOutput bool `json:"sortOutput"`
+}
+
+// MergeGroupKey is a field used to keep entries in separate merged files.
+type MergeGroupKey string
+
+const (
+	GroupBySECCode                  MergeGroupKey = "secCode"
+	GroupByCompanyIdentification    MergeGroupKey = "companyIdentification"
+	GroupByEffectiveEntryDate       MergeGroupKey = "effectiveEntryDate"
+	GroupByODFIIdentification       MergeGroupKey = "odfiIdentification"
+	GroupByImmediateOriginName      MergeGroupKey = "immediateOriginName"
+	GroupByImmediateDestinationName MergeGroupKey = "immediateDestinationName"
+	GroupByReferenceCode            MergeGroupKey = "referenceCode"
+)
+
+// MergeSeparation matches entries which are merged into their own files.
+//
+// Every non-empty field must match for an entry to be separated. For example,
+// SECCodes of WEB and Debits will separate WEB debits from every other entry.
+type MergeSeparation struct {
+	// SECCodes matches the StandardEntryClassCode of an entry's batch
+	SECCodes []string `json:"secCodes"`
+
+	// CompanyIdentifications matches the CompanyIdentification (or OriginatorIdentification
+	// for IAT batches) of an entry's batch
+	CompanyIdentifications []string `json:"companyIdentifications"`
+
+	// Credits and Debits limit the separation to credit or debit entries.
+	// When both are false entries of either type match.
+	Credits bool `json:"credits"`
+	Debits  bool `json:"debits"`
+}
+
+func (sep MergeSeparation) matches(secCode, companyIdentification, creditOrDebit string) bool {
+	if len(sep.SECCodes) > 0 && !slices.Contains(sep.SECCodes, secCode) {
+		return false
+	}
+	if len(sep.CompanyIdentifications) > 0 && !slices.Contains(sep.CompanyIdentifications, companyIdentification) {
+		return false
+	}
+	if sep.Credits != sep.Debits {
+		if sep.Credits && creditOrDebit != "C" {
+			return false
+		}
+		if sep.Debits && creditOrDebit != "D" {
+			return false
+		}
+	}
+	return true
+}
+
+// groupKey returns the value which keeps entries in separate merged files according to
+// GroupBy and Separate. Entries with equal keys can be merged together.
+func (c Conditions) groupKey(fh FileHeader, secCode, companyIdentification, effectiveEntryDate, odfi, creditOrDebit string) string {
+	if len(c.GroupBy) == 0 && len(c.Separate) == 0 {
+		return ""
+	}
+
+	var parts []string
+	for i := range c.Separate {
+		if c.Separate[i].matches(secCode, companyIdentification, creditOrDebit) {
+			parts = append(parts, fmt.Sprintf("separate-%d", i))
+			break
+		}
+	}
+	for _, key := range c.GroupBy {
+		switch key {
+		case GroupBySECCode:
+			parts = append(parts, secCode)
+		case GroupByCompanyIdentification:
+			parts = append(parts, companyIdentification)
+		case GroupByEffectiveEntryDate:
+			parts = append(parts, effectiveEntryDate)
+		case GroupByODFIIdentification:
+			parts = append(parts, odfi)
+		case GroupByImmediateOriginName:
+			parts = append(parts, fh.ImmediateOriginName)
+		case GroupByImmediateDestinationName:
+			parts = append(parts, fh.ImmediateDestinationName)
+		case GroupByReferenceCode:
+			parts = append(parts, fh.ReferenceCode)
+		}
+	}
+	return strings.Join(parts, "|")
 }
 
 // MergeFilesWith is a function for consolidating an array of ACH Files into a few files as possible.
@@ -101,10 +212,25 @@ type Conditions struct {
 //
 // ADV Batches and Entries are currently not merged together.
 //
-// Conditions allows for capping the maximum line length or dollar amount of merged files.
+// Conditions allows for capping the maximum line length or dollar amount of merged files, capping
+// batches and entries, and keeping entries of certain SEC codes or companies in separate files.
 //
 // File Batches can only be merged if they are unique and routed to and from the same ABA routing numbers.
 func MergeFilesWith(incoming []*File, conditions Conditions) ([]*File, error) {
+	result, err := mergeFiles(incoming, conditions, false)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return result.Files, nil
+}
+
+// MergeFilesWithManifest merges files like MergeFilesWith and also returns a Manifest describing
+// which input File, batch and TraceNumber each merged entry came from.
+func MergeFilesWithManifest(incoming []*File, conditions Conditions) (*MergeResult, error) {
+	return mergeFiles(incoming, conditions, true)
+}
+
+func mergeFiles(incoming []*File, conditions Conditions, trackSources bool) (*MergeResult, error) {
 	if len(incoming) == 0 {
 		return nil, nil
 	}
@@ -115,13 +241,17 @@ func MergeFilesWith(incoming []*File, conditions Conditions) ([]*File, error) {
 	}
 
 	for i := range incoming {
-		err := sorted.add(incoming[i])
+		var source *mergeSource
+		if trackSources {
+			source = &mergeSource{index: i, fileID: incoming[i].ID}
+		}
+		err := sorted.add(incoming[i], conditions, source)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return convertToFiles(sorted, conditions)
+	return convertToFiles(sorted, conditions, trackSources)
 }
 
 type FileAcceptance string
@@ -188,6 +318,20 @@ func DefaultFileAcceptor(path string) FileAcceptance {
 //
 // File Batches can only be merged if they are unique and routed to and from the same ABA routing numbers.
 func MergeDir(dir string, conditions Conditions, opts *MergeDirOptions) ([]*File, error) {
+	result, err := mergeDir(dir, conditions, opts, false)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return result.Files, nil
+}
+
+// MergeDirWithManifest merges a directory like MergeDir and also returns a Manifest describing
+// which file path, batch and TraceNumber each merged entry came from.
+func MergeDirWithManifest(dir string, conditions Conditions, opts *MergeDirOptions) (*MergeResult, error) {
+	return mergeDir(dir, conditions, opts, true)
+}
+
+func mergeDir(dir string, conditions Conditions, opts *MergeDirOptions, trackSources bool) (*MergeResult, error) {
 	if opts == nil {
 		opts = &MergeDirOptions{}
 	}
@@ -228,7 +372,7 @@ func MergeDir(dir string, conditions Conditions, opts *MergeDirOptions) ([]*File
 	}
 
 	discoveredPaths := make(chan string)
-	mergableFiles := make(chan *File)
+	mergableFiles := make(chan mergableFile)
 
 	// We are going to scan the directory for files to parse and merge.
 	pathsCtx, pathsCancelFunc := context.WithCancel(context.Background())
@@ -276,12 +420,17 @@ func MergeDir(dir string, conditions Conditions, opts *MergeDirOptions) ([]*File
 		for {
 			select {
 			case file := <-mergableFiles:
-				if file == nil {
+				if file.file == nil {
 					continue
 				}
 
+				var source *mergeSource
+				if trackSources {
+					source = &mergeSource{fileID: file.file.ID, path: file.path}
+				}
+
 				// accumulate the file into our merged set
-				err := sorted.add(file)
+				err := sorted.add(file.file, conditions, source)
 				if err != nil {
 					// Cancel all goroutines to avoid deadlock on unbuffered channels
 					pathsCancelFunc()
@@ -301,7 +450,13 @@ func MergeDir(dir string, conditions Conditions, opts *MergeDirOptions) ([]*File
 		return nil, fmt.Errorf("merging %s failed: %w", dir, err)
 	}
 
-	return convertToFiles(sorted, conditions)
+	return convertToFiles(sorted, conditions, trackSources)
+}
+
+// mergableFile is a parsed File along with the path it was read from
+type mergableFile struct {
+	file *File
+	path string
 }
 
 func walkDir(ctx context.Context, fsys fs.FS, dir string, opts *MergeDirOptions, discoveredPaths chan string) error {
@@ -349,7 +504,7 @@ func walkDir(ctx context.Context, fsys fs.FS, dir string, opts *MergeDirOptions,
 	return nil
 }
 
-func queueFileForMerging(pathsCtx, parsingCtx context.Context, discoveredPaths chan string, setup *sync.Once, sorted *outFile, mergableFiles chan *File, opts *MergeDirOptions) error {
+func queueFileForMerging(pathsCtx, parsingCtx context.Context, discoveredPaths chan string, setup *sync.Once, sorted *outFile, mergableFiles chan mergableFile, opts *MergeDirOptions) error {
 	for {
 		select {
 		case path := <-discoveredPaths:
@@ -393,7 +548,7 @@ func queueFileForMerging(pathsCtx, parsingCtx context.Context, discoveredPaths c
 			// Only send non-nil files, once this channel receives a nil file we stop merging
 			if file != nil {
 				select {
-				case mergableFiles <- file:
+				case mergableFiles <- mergableFile{file: file, path: path}:
 				case <-parsingCtx.Done():
 					return nil
 				}
@@ -478,6 +633,9 @@ type outFile struct {
 	header  FileHeader
 	batches []*batch
 
+	// key is the Conditions.groupKey of every entry in this file
+	key string
+
 	iatBatches []*iatBatch
 
 	validateOpts *ValidateOpts
@@ -485,12 +643,23 @@ type outFile struct {
 	next *outFile
 }
 
-func (outf *outFile) add(incoming *File) error {
-	outFile := pickOutFile(incoming.Header, outf)
-	if outFile == nil {
-		return fmt.Errorf("found no outfile: %w", ErrPleaseReportBug)
+func (outf *outFile) add(incoming *File, conditions Conditions, source *mergeSource) error {
+	fh := incoming.Header
+	validateOpts := incoming.GetValidation()
+
+	// Remember the outFile for each group key to avoid searching for every entry
+	picked := make(map[string]*outFile)
+	pick := func(key string) *outFile {
+		if out, exists := picked[key]; exists {
+			return out
+		}
+		out := pickGroupedOutFile(fh, key, outf)
+		if out != nil {
+			out.validateOpts = out.validateOpts.merge(validateOpts)
+			picked[key] = out
+		}
+		return out
 	}
-	outFile.validateOpts = outFile.validateOpts.merge(incoming.GetValidation())
 
 	for j := range incoming.Batches {
 		if len(incoming.Batches[j].GetADVEntries()) > 0 {
@@ -504,6 +673,12 @@ func (outf *outFile) add(incoming *File) error {
 
 		entries := incoming.Batches[j].GetEntries()
 		for m := range entries {
+			key := conditions.groupKey(fh, bh.StandardEntryClassCode, bh.CompanyIdentification, bh.EffectiveEntryDate, bh.ODFIIdentification, entries[m].CreditOrDebit())
+			outFile := pick(key)
+			if outFile == nil {
+				return fmt.Errorf("found no outfile: %w", ErrPleaseReportBug)
+			}
+
 			// Find a batch where this entry can fit
 			b := findOutBatch(bh, outFile.batches, entries[m])
 
@@ -512,12 +687,15 @@ func (outf *outFile) add(incoming *File) error {
 				b = &batch{
 					header:       *bh,
 					entries:      treemap.New[string, *EntryDetail](),
-					validateOpts: incoming.GetValidation(),
+					validateOpts: validateOpts,
 				}
 				outFile.batches = append(outFile.batches, b)
 			}
 
 			b.entries.Set(entries[m].TraceNumber, entries[m])
+			if source != nil {
+				b.setSource(entries[m].TraceNumber, source, bh.BatchNumber)
+			}
 		}
 	}
 
@@ -529,284 +707,415 @@ func (outf *outFile) add(incoming *File) error {
 
 		entries := incoming.IATBatches[j].GetEntries()
 		for m := range entries {
+			key := conditions.groupKey(fh, ibh.StandardEntryClassCode, ibh.OriginatorIdentification, ibh.EffectiveEntryDate, ibh.ODFIIdentification, entries[m].CreditOrDebit())
+			outFile := pick(key)
+			if outFile == nil {
+				return fmt.Errorf("found no outfile: %w", ErrPleaseReportBug)
+			}
+
 			b := findOutIATBatch(ibh, outFile.iatBatches, entries[m])
 
 			if b == nil {
 				b = &iatBatch{
 					header:       *ibh,
 					entries:      treemap.New[string, *IATEntryDetail](),
-					validateOpts: incoming.GetValidation(),
+					validateOpts: validateOpts,
 				}
 				outFile.iatBatches = append(outFile.iatBatches, b)
 			}
 
 			b.entries.Set(entries[m].TraceNumber, entries[m])
+			if source != nil {
+				b.setSource(entries[m].TraceNumber, source, ibh.BatchNumber)
+			}
 		}
 	}
 
 	return nil
 }
 
-func convertToFiles(sorted *outFile, conditions Conditions) ([]*File, error) {
+func convertToFiles(sorted *outFile, conditions Conditions, trackSources bool) (*MergeResult, error) {
 	// Force the MaxDollarAmount to within what the Nacha format allows
 	if conditions.MaxDollarAmount == 0 || conditions.MaxDollarAmount > NachaFileDebitCreditLimit {
 		conditions.MaxDollarAmount = NachaFileDebitCreditLimit
 	}
+	if conditions.SortOutput {
+		sorted = sortOutFiles(sorted)
+	}
 
-	var batchNumber int
-
-	var out []*File
-	for {
+	m := &mergedFiles{
+		conditions:   conditions,
+		trackSources: trackSources,
+	}
+	for ; sorted != nil; sorted = sorted.next {
 		// Run through the linked list (sorted.next) until we terminate
-		if sorted == nil {
-			break
+		m.start(sorted)
+
+		for i := range sorted.batches {
+			if err := m.addBatch(sorted.batches[i]); err != nil {
+				return nil, err
+			}
+		}
+		for i := range sorted.iatBatches {
+			if err := m.addIATBatch(sorted.iatBatches[i]); err != nil {
+				return nil, err
+			}
 		}
 
-		file := NewFile()
-		file.Header = sorted.header
+		if err := m.closeFile(); err != nil {
+			return nil, fmt.Errorf("problem creating outfile: %w", err)
+		}
+	}
+
+	result := &MergeResult{
+		Files: m.out,
+	}
+	if trackSources {
+		result.Manifest = m.manifest
+	}
+	return result, nil
+}
+
+// mergedFiles accumulates the Files created from an outFile while enforcing the merge Conditions.
+type mergedFiles struct {
+	conditions Conditions
+	out        []*File
+
+	source *outFile
+	file   *File
+
+	// batchNumber is incremented across every merged file
+	batchNumber int
+
+	lineCount    int
+	dollarAmount int64
+	creditAmount int64
+	debitAmount  int64
+
+	// trackSources will record a MergedFile for each output File
+	trackSources bool
+	manifest     []MergedFile
+	batches      []MergedBatch
+	entries      []MergedEntry
+}
+
+// start begins merging source into a new File
+func (m *mergedFiles) start(source *outFile) {
+	m.source = source
+	m.newFile()
+}
+
+func (m *mergedFiles) newFile() {
+	m.file = NewFile()
+	m.file.Header = m.source.header
+	if m.source.validateOpts != nil {
+		m.file.SetValidation(m.source.validateOpts)
+	}
+
+	m.batches = nil
 
-		if sorted.validateOpts != nil {
-			file.SetValidation(sorted.validateOpts)
+	m.lineCount = 2 // FileHeader, FileControl
+	m.dollarAmount = 0
+	m.creditAmount = 0
+	m.debitAmount = 0
+}
+
+// closeFile tabulates the current File and adds it to the output if it has any batches
+func (m *mergedFiles) closeFile() error {
+	if len(m.file.Batches) == 0 && len(m.file.IATBatches) == 0 {
+		return nil
+	}
+	if err := m.file.Create(); err != nil {
+		return err
+	}
+	m.out = append(m.out, m.file)
+
+	if m.trackSources {
+		m.manifest = append(m.manifest, MergedFile{
+			FileID:  m.file.ID,
+			Batches: m.batches,
+		})
+	}
+	return nil
+}
+
+// trackEntry records the source of an entry added to the current batch
+func (m *mergedFiles) trackEntry(sources map[string]mergedEntrySource, traceNumber string) {
+	if m.trackSources {
+		m.entries = append(m.entries, sources[traceNumber].entry(traceNumber))
+	}
+}
+
+// trackBatch records the sources of entries in a closed batch. TraceNumbers are read after
+// the batch is created as they can be reassigned.
+func (m *mergedFiles) trackBatch(batchNumber int, traceNumbers []string) {
+	if !m.trackSources {
+		return
+	}
+	for i := range m.entries {
+		if i < len(traceNumbers) {
+			m.entries[i].TraceNumber = traceNumbers[i]
 		}
+	}
+	m.batches = append(m.batches, MergedBatch{
+		BatchNumber: batchNumber,
+		Entries:     m.entries,
+	})
+	m.entries = nil
+}
 
-		currentFileLineCount := 2 // FileHeader, FileControl
-		var currentFileDollarAmount int
+// nextBatchNumber reserves room for another batch in the current File, starting a new File
+// when MaxBatches has been reached.
+func (m *mergedFiles) nextBatchNumber() (int, error) {
+	if m.conditions.MaxBatches > 0 && len(m.file.Batches)+len(m.file.IATBatches) >= m.conditions.MaxBatches {
+		if err := m.closeFile(); err != nil {
+			return 0, fmt.Errorf("problem creating file for new batch: %w", err)
+		}
+		m.newFile()
+	}
 
-		for i := range sorted.batches {
-			nextBatch := sorted.batches[i]
-
-			batchNumber += 1
-			batch, err := NewBatch(&BatchHeader{ // don't let BatchHeader escape and mutate
-				ServiceClassCode:         nextBatch.header.ServiceClassCode,
-				CompanyName:              nextBatch.header.CompanyName,
-				CompanyDiscretionaryData: nextBatch.header.CompanyDiscretionaryData,
-				CompanyIdentification:    nextBatch.header.CompanyIdentification,
-				StandardEntryClassCode:   nextBatch.header.StandardEntryClassCode,
-				CompanyEntryDescription:  nextBatch.header.CompanyEntryDescription,
-				CompanyDescriptiveDate:   nextBatch.header.CompanyDescriptiveDate,
-				EffectiveEntryDate:       nextBatch.header.EffectiveEntryDate,
-				SettlementDate:           nextBatch.header.SettlementDate,
-				OriginatorStatusCode:     nextBatch.header.OriginatorStatusCode,
-				ODFIIdentification:       nextBatch.header.ODFIIdentification,
-				BatchNumber:              batchNumber,
-			})
-			if err != nil {
-				return nil, fmt.Errorf("creating batch from sorted.batches[%d] failed: %w", i, err)
-			}
-			batch.SetValidation(nextBatch.validateOpts)
+	m.batchNumber += 1
+	m.lineCount += 2 // BatchHeader, BatchControl
 
-			currentFileLineCount += 2 // BatchHeader, BatchControl
+	return m.batchNumber, nil
+}
 
-			// add each entry detail
-			for it := nextBatch.entries.Iterator(); it.Valid(); it.Next() {
-				nextEntry := it.Value()
+// exceeds returns true when adding an entry would exceed the merge Conditions for the current File
+func (m *mergedFiles) exceeds(lines, amount int, creditOrDebit string) bool {
+	// File will be too large
+	if m.conditions.MaxLines > 0 && m.lineCount+lines > m.conditions.MaxLines {
+		return true
+	}
 
-				// Check if we're going to exceed the merge conditions before adding the entry
-				entryLineCount := 1 + nextEntry.addendaCount()
-				if conditions.MaxLines > 0 {
-					// File will be too large, so make a new file and batch
-					if currentFileLineCount+entryLineCount > conditions.MaxLines {
-						goto overflow
-					}
-				}
+	// File would exceed the dollar amount we're limited to
+	if m.conditions.MaxDollarAmount > 0 && m.dollarAmount+int64(amount) > m.conditions.MaxDollarAmount {
+		return true
+	}
+	switch creditOrDebit {
+	case "C":
+		return m.conditions.MaxCreditAmount > 0 && m.creditAmount+int64(amount) > m.conditions.MaxCreditAmount
+	case "D":
+		return m.conditions.MaxDebitAmount > 0 && m.debitAmount+int64(amount) > m.conditions.MaxDebitAmount
+	}
+	return false
+}
 
-				// File would exceed the dollar amount we're limited to
-				if conditions.MaxDollarAmount > 0 {
-					if int64(currentFileDollarAmount)+int64(nextEntry.Amount) > conditions.MaxDollarAmount {
-						goto overflow
-					}
-				}
+// track records an entry added to the current File
+func (m *mergedFiles) track(lines, amount int, creditOrDebit string) {
+	m.lineCount += lines
+	m.dollarAmount += int64(amount)
 
-				// Without a condition being exceeded jump into adding the entry in the current batch
-				goto merge
-
-			overflow:
-				// Close out the current batch and file since we exceeded some limit
-				if len(batch.GetEntries()) > 0 {
-					err = batch.Create()
-					if err != nil {
-						return nil, fmt.Errorf("problem creating batch for new file/batch: %w", err)
-					}
-					file.AddBatch(batch)
-				}
-				if len(file.Batches) > 0 || len(file.IATBatches) > 0 {
-					err = file.Create()
-					if err != nil {
-						return nil, fmt.Errorf("problem creating file for new file/batch: %w", err)
-					}
-					out = append(out, file)
-				}
+	switch creditOrDebit {
+	case "C":
+		m.creditAmount += int64(amount)
+	case "D":
+		m.debitAmount += int64(amount)
+	}
+}
 
-				// Reset counters
-				currentFileLineCount = 4 // FileHeader, FileControl, BatchHeader, BatchControl
-				currentFileDollarAmount = 0
-
-				// Create the new file and batch
-				file = NewFile()
-				file.Header = sorted.header
-
-				batchNumber += 1
-				batch, err = NewBatch(&BatchHeader{ // don't let BatchHeader escape and mutate
-					ServiceClassCode:         nextBatch.header.ServiceClassCode,
-					CompanyName:              nextBatch.header.CompanyName,
-					CompanyDiscretionaryData: nextBatch.header.CompanyDiscretionaryData,
-					CompanyIdentification:    nextBatch.header.CompanyIdentification,
-					StandardEntryClassCode:   nextBatch.header.StandardEntryClassCode,
-					CompanyEntryDescription:  nextBatch.header.CompanyEntryDescription,
-					CompanyDescriptiveDate:   nextBatch.header.CompanyDescriptiveDate,
-					EffectiveEntryDate:       nextBatch.header.EffectiveEntryDate,
-					SettlementDate:           nextBatch.header.SettlementDate,
-					OriginatorStatusCode:     nextBatch.header.OriginatorStatusCode,
-					ODFIIdentification:       nextBatch.header.ODFIIdentification,
-					BatchNumber:              batchNumber,
-				})
-				if err != nil {
-					return nil, fmt.Errorf("problem creating overflow batch: %w", err)
-				}
-				batch.SetValidation(nextBatch.validateOpts)
+// batchFull returns true when a batch with count entries has reached MaxEntriesPerBatch
+func (m *mergedFiles) batchFull(count int) bool {
+	return m.conditions.MaxEntriesPerBatch > 0 && count >= m.conditions.MaxEntriesPerBatch
+}
+
+func (m *mergedFiles) addBatch(source *batch) error {
+	batch, err := m.newBatch(source)
+	if err != nil {
+		return fmt.Errorf("creating batch from sorted.batches failed: %w", err)
+	}
 
-			merge:
-				// Add the entry to the current batch
-				batch.AddEntry(nextEntry)
+	// add each entry detail
+	for it := source.entries.Iterator(); it.Valid(); it.Next() {
+		nextEntry := it.Value()
 
-				currentFileLineCount += 1 + nextEntry.addendaCount()
-				currentFileDollarAmount += nextEntry.Amount
+		lines := 1 + nextEntry.addendaCount()
+		creditOrDebit := nextEntry.CreditOrDebit()
+
+		// Check if we're going to exceed the merge conditions before adding the entry
+		switch {
+		case m.exceeds(lines, nextEntry.Amount, creditOrDebit):
+			// Close out the current batch and file since we exceeded some limit
+			if err := m.closeBatch(batch); err != nil {
+				return fmt.Errorf("problem creating batch for new file/batch: %w", err)
+			}
+			if err := m.closeFile(); err != nil {
+				return fmt.Errorf("problem creating file for new file/batch: %w", err)
 			}
+			m.newFile()
 
-			if len(batch.GetEntries()) > 0 {
-				err = batch.Create()
-				if err != nil {
-					return nil, fmt.Errorf("problem creating batch for outfile: %w", err)
-				}
-				file.AddBatch(batch)
+			batch, err = m.newBatch(source)
+			if err != nil {
+				return fmt.Errorf("problem creating overflow batch: %w", err)
+			}
+
+		case m.batchFull(len(batch.GetEntries())):
+			if err := m.closeBatch(batch); err != nil {
+				return fmt.Errorf("problem creating batch for new batch: %w", err)
+			}
+
+			batch, err = m.newBatch(source)
+			if err != nil {
+				return fmt.Errorf("problem creating overflow batch: %w", err)
 			}
 		}
 
-		for i := range sorted.iatBatches {
-			nextBatch := sorted.iatBatches[i]
-
-			batchNumber += 1
-			iatBh := &IATBatchHeader{
-				ServiceClassCode:                  nextBatch.header.ServiceClassCode,
-				IATIndicator:                      nextBatch.header.IATIndicator,
-				ForeignExchangeIndicator:          nextBatch.header.ForeignExchangeIndicator,
-				ForeignExchangeReferenceIndicator: nextBatch.header.ForeignExchangeReferenceIndicator,
-				ForeignExchangeReference:          nextBatch.header.ForeignExchangeReference,
-				ISODestinationCountryCode:         nextBatch.header.ISODestinationCountryCode,
-				OriginatorIdentification:          nextBatch.header.OriginatorIdentification,
-				StandardEntryClassCode:            nextBatch.header.StandardEntryClassCode,
-				CompanyEntryDescription:           nextBatch.header.CompanyEntryDescription,
-				ISOOriginatingCurrencyCode:        nextBatch.header.ISOOriginatingCurrencyCode,
-				ISODestinationCurrencyCode:        nextBatch.header.ISODestinationCurrencyCode,
-				EffectiveEntryDate:                nextBatch.header.EffectiveEntryDate,
-				SettlementDate:                    nextBatch.header.SettlementDate,
-				OriginatorStatusCode:              nextBatch.header.OriginatorStatusCode,
-				ODFIIdentification:                nextBatch.header.ODFIIdentification,
-				BatchNumber:                       batchNumber,
-			}
-			iatBatch := NewIATBatch(iatBh)
-			iatBatch.SetValidation(nextBatch.validateOpts)
-
-			currentFileLineCount += 2 // IATBatchHeader, BatchControl
-
-			// add each IAT entry detail
-			for it := nextBatch.entries.Iterator(); it.Valid(); it.Next() {
-				nextEntry := it.Value()
-
-				// Check if we're going to exceed the merge conditions before adding the entry
-				entryLineCount := 1 + nextEntry.addendaCount()
-				if conditions.MaxLines > 0 {
-					// File will be too large, so make a new file and batch
-					if currentFileLineCount+entryLineCount > conditions.MaxLines {
-						goto iatOverflow
-					}
-				}
+		// Add the entry to the current batch
+		batch.AddEntry(nextEntry)
+		m.track(lines, nextEntry.Amount, creditOrDebit)
+		m.trackEntry(source.sources, it.Key())
+	}
 
-				// File would exceed the dollar amount we're limited to
-				if conditions.MaxDollarAmount > 0 {
-					if int64(currentFileDollarAmount)+int64(nextEntry.Amount) > conditions.MaxDollarAmount {
-						goto iatOverflow
-					}
-				}
+	if err := m.closeBatch(batch); err != nil {
+		return fmt.Errorf("problem creating batch for outfile: %w", err)
+	}
+	return nil
+}
 
-				// Without a condition being exceeded jump into adding the entry in the current batch
-				goto iatMerge
-
-			iatOverflow:
-				// Close out the current batch and file since we exceeded some limit
-				if len(iatBatch.Entries) > 0 {
-					err := iatBatch.Create()
-					if err != nil {
-						return nil, fmt.Errorf("problem creating IAT batch for new file/batch: %w", err)
-					}
-					file.AddIATBatch(iatBatch)
-				}
-				if len(file.Batches) > 0 || len(file.IATBatches) > 0 {
-					err := file.Create()
-					if err != nil {
-						return nil, fmt.Errorf("problem creating file for new file/batch: %w", err)
-					}
-					out = append(out, file)
-				}
+func (m *mergedFiles) newBatch(source *batch) (Batcher, error) {
+	batchNumber, err := m.nextBatchNumber()
+	if err != nil {
+		return nil, err
+	}
+	batch, err := NewBatch(&BatchHeader{ // don't let BatchHeader escape and mutate
+		ServiceClassCode:         source.header.ServiceClassCode,
+		CompanyName:              source.header.CompanyName,
+		CompanyDiscretionaryData: source.header.CompanyDiscretionaryData,
+		CompanyIdentification:    source.header.CompanyIdentification,
+		StandardEntryClassCode:   source.header.StandardEntryClassCode,
+		CompanyEntryDescription:  source.header.CompanyEntryDescription,
+		CompanyDescriptiveDate:   source.header.CompanyDescriptiveDate,
+		EffectiveEntryDate:       source.header.EffectiveEntryDate,
+		SettlementDate:           source.header.SettlementDate,
+		OriginatorStatusCode:     source.header.OriginatorStatusCode,
+		ODFIIdentification:       source.header.ODFIIdentification,
+		BatchNumber:              batchNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+	batch.SetValidation(source.validateOpts)
+	return batch, nil
+}
 
-				// Reset counters
-				currentFileLineCount = 4 // FileHeader, FileControl, IATBatchHeader, BatchControl
-				currentFileDollarAmount = 0
-
-				// Create the new file and batch
-				file = NewFile()
-				file.Header = sorted.header
-
-				batchNumber += 1
-				iatBh = &IATBatchHeader{
-					ServiceClassCode:                  nextBatch.header.ServiceClassCode,
-					IATIndicator:                      nextBatch.header.IATIndicator,
-					ForeignExchangeIndicator:          nextBatch.header.ForeignExchangeIndicator,
-					ForeignExchangeReferenceIndicator: nextBatch.header.ForeignExchangeReferenceIndicator,
-					ForeignExchangeReference:          nextBatch.header.ForeignExchangeReference,
-					ISODestinationCountryCode:         nextBatch.header.ISODestinationCountryCode,
-					OriginatorIdentification:          nextBatch.header.OriginatorIdentification,
-					StandardEntryClassCode:            nextBatch.header.StandardEntryClassCode,
-					CompanyEntryDescription:           nextBatch.header.CompanyEntryDescription,
-					ISOOriginatingCurrencyCode:        nextBatch.header.ISOOriginatingCurrencyCode,
-					ISODestinationCurrencyCode:        nextBatch.header.ISODestinationCurrencyCode,
-					EffectiveEntryDate:                nextBatch.header.EffectiveEntryDate,
-					SettlementDate:                    nextBatch.header.SettlementDate,
-					OriginatorStatusCode:              nextBatch.header.OriginatorStatusCode,
-					ODFIIdentification:                nextBatch.header.ODFIIdentification,
-					BatchNumber:                       batchNumber,
-				}
-				iatBatch = NewIATBatch(iatBh)
-				iatBatch.SetValidation(nextBatch.validateOpts)
+// closeBatch tabulates batch and adds it to the current File if it has any entries
+func (m *mergedFiles) closeBatch(batch Batcher) error {
+	if len(batch.GetEntries()) == 0 {
+		return nil
+	}
+	if err := batch.Create(); err != nil {
+		return err
+	}
+	m.file.AddBatch(batch)
 
-			iatMerge:
-				// Add the entry to the current batch
-				iatBatch.AddEntry(nextEntry)
+	if m.trackSources {
+		entries := batch.GetEntries()
+		traceNumbers := make([]string, len(entries))
+		for i := range entries {
+			traceNumbers[i] = entries[i].TraceNumber
+		}
+		m.trackBatch(batch.GetHeader().BatchNumber, traceNumbers)
+	}
+	return nil
+}
 
-				currentFileLineCount += 1 + nextEntry.addendaCount()
-				currentFileDollarAmount += nextEntry.Amount
+func (m *mergedFiles) addIATBatch(source *iatBatch) error {
+	iatBatch, err := m.newIATBatch(source)
+	if err != nil {
+		return err
+	}
+
+	// add each IAT entry detail
+	for it := source.entries.Iterator(); it.Valid(); it.Next() {
+		nextEntry := it.Value()
+
+		lines := 1 + nextEntry.addendaCount()
+		creditOrDebit := nextEntry.CreditOrDebit()
+
+		// Check if we're going to exceed the merge conditions before adding the entry
+		switch {
+		case m.exceeds(lines, nextEntry.Amount, creditOrDebit):
+			// Close out the current batch and file since we exceeded some limit
+			if err := m.closeIATBatch(iatBatch); err != nil {
+				return fmt.Errorf("problem creating IAT batch for new file/batch: %w", err)
 			}
+			if err := m.closeFile(); err != nil {
+				return fmt.Errorf("problem creating file for new file/batch: %w", err)
+			}
+			m.newFile()
 
-			if len(iatBatch.Entries) > 0 {
-				err := iatBatch.Create()
-				if err != nil {
-					return nil, fmt.Errorf("problem creating IAT batch for outfile: %w", err)
-				}
-				file.AddIATBatch(iatBatch)
+			iatBatch, err = m.newIATBatch(source)
+			if err != nil {
+				return err
+			}
+
+		case m.batchFull(len(iatBatch.Entries)):
+			if err := m.closeIATBatch(iatBatch); err != nil {
+				return fmt.Errorf("problem creating IAT batch for new batch: %w", err)
 			}
-		}
 
-		if len(file.Batches) > 0 || len(file.IATBatches) > 0 {
-			err := file.Create()
+			iatBatch, err = m.newIATBatch(source)
 			if err != nil {
-				return nil, fmt.Errorf("problem creating outfile: %w", err)
+				return err
 			}
-			out = append(out, file)
 		}
 
-		sorted = sorted.next
+		// Add the entry to the current batch
+		iatBatch.AddEntry(nextEntry)
+		m.track(lines, nextEntry.Amount, creditOrDebit)
+		m.trackEntry(source.sources, it.Key())
+	}
+
+	if err := m.closeIATBatch(iatBatch); err != nil {
+		return fmt.Errorf("problem creating IAT batch for outfile: %w", err)
+	}
+	return nil
+}
+
+func (m *mergedFiles) newIATBatch(source *iatBatch) (IATBatch, error) {
+	batchNumber, err := m.nextBatchNumber()
+	if err != nil {
+		return IATBatch{}, err
 	}
-	return out, nil
+	iatBatch := NewIATBatch(&IATBatchHeader{
+		ServiceClassCode:                  source.header.ServiceClassCode,
+		IATIndicator:                      source.header.IATIndicator,
+		ForeignExchangeIndicator:          source.header.ForeignExchangeIndicator,
+		ForeignExchangeReferenceIndicator: source.header.ForeignExchangeReferenceIndicator,
+		ForeignExchangeReference:          source.header.ForeignExchangeReference,
+		ISODestinationCountryCode:         source.header.ISODestinationCountryCode,
+		OriginatorIdentification:          source.header.OriginatorIdentification,
+		StandardEntryClassCode:            source.header.StandardEntryClassCode,
+		CompanyEntryDescription:           source.header.CompanyEntryDescription,
+		ISOOriginatingCurrencyCode:        source.header.ISOOriginatingCurrencyCode,
+		ISODestinationCurrencyCode:        source.header.ISODestinationCurrencyCode,
+		EffectiveEntryDate:                source.header.EffectiveEntryDate,
+		SettlementDate:                    source.header.SettlementDate,
+		OriginatorStatusCode:              source.header.OriginatorStatusCode,
+		ODFIIdentification:                source.header.ODFIIdentification,
+		BatchNumber:                       batchNumber,
+	})
+	iatBatch.SetValidation(source.validateOpts)
+	return iatBatch, nil
+}
+
+// closeIATBatch tabulates iatBatch and adds it to the current File if it has any entries
+func (m *mergedFiles) closeIATBatch(iatBatch IATBatch) error {
+	if len(iatBatch.Entries) == 0 {
+		return nil
+	}
+	if err := iatBatch.Create(); err != nil {
+		return err
+	}
+	m.file.AddIATBatch(iatBatch)
+
+	if m.trackSources {
+		traceNumbers := make([]string, len(iatBatch.Entries))
+		for i := range iatBatch.Entries {
+			traceNumbers[i] = iatBatch.Entries[i].TraceNumber
+		}
+		m.trackBatch(iatBatch.Header.BatchNumber, traceNumbers)
+	}
+	return nil
 }
 
 // batch contains a BatcHeader and tree of entries sorted by TraceNumber, which allows for
@@ -815,6 +1124,33 @@ type batch struct {
 	header       BatchHeader
 	entries      *treemap.TreeMap[string, *EntryDetail]
 	validateOpts *ValidateOpts
+
+	// sources is keyed by TraceNumber and only populated when building a MergeResult Manifest
+	sources map[string]mergedEntrySource
+}
+
+func (b *batch) setSource(traceNumber string, source *mergeSource, batchNumber int) {
+	if b.sources == nil {
+		b.sources = make(map[string]mergedEntrySource)
+	}
+	b.sources[traceNumber] = mergedEntrySource{source: source, batchNumber: batchNumber}
+}
+
+func (b *batch) sortKey() string {
+	var firstTraceNumber string
+	if it := b.entries.Iterator(); it.Valid() {
+		firstTraceNumber = it.Key()
+	}
+	return strings.Join([]string{
+		b.header.StandardEntryClassCode,
+		b.header.CompanyIdentification,
+		b.header.EffectiveEntryDate,
+		b.header.ODFIIdentification,
+		fmt.Sprintf("%03d", b.header.ServiceClassCode),
+		b.header.CompanyName,
+		b.header.CompanyEntryDescription,
+		firstTraceNumber,
+	}, "|")
 }
 
 // iatBatch contains an IATBatchHeader and tree of IAT entries sorted by TraceNumber,
@@ -823,27 +1159,101 @@ type iatBatch struct {
 	header       IATBatchHeader
 	entries      *treemap.TreeMap[string, *IATEntryDetail]
 	validateOpts *ValidateOpts
+
+	// sources is keyed by TraceNumber and only populated when building a MergeResult Manifest
+	sources map[string]mergedEntrySource
+}
+
+func (b *iatBatch) setSource(traceNumber string, source *mergeSource, batchNumber int) {
+	if b.sources == nil {
+		b.sources = make(map[string]mergedEntrySource)
+	}
+	b.sources[traceNumber] = mergedEntrySource{source: source, batchNumber: batchNumber}
+}
+
+func (b *iatBatch) sortKey() string {
+	var firstTraceNumber string
+	if it := b.entries.Iterator(); it.Valid() {
+		firstTraceNumber = it.Key()
+	}
+	return strings.Join([]string{
+		b.header.OriginatorIdentification,
+		b.header.EffectiveEntryDate,
+		b.header.ODFIIdentification,
+		fmt.Sprintf("%03d", b.header.ServiceClassCode),
+		b.header.ISODestinationCountryCode,
+		b.header.CompanyEntryDescription,
+		firstTraceNumber,
+	}, "|")
 }
 
 // pickOutFile will search for an existing outFile matching the FileHeader Origin and Destination.
 // If no such file can be found it will create one. A nil file will never be returned.
 func pickOutFile(fh FileHeader, file *outFile) *outFile {
+	return pickGroupedOutFile(fh, "", file)
+}
+
+// pickGroupedOutFile will search for an existing outFile matching the FileHeader Origin and Destination
+// along with the Conditions group key. If no such file can be found it will create one. A nil file will never be returned.
+func pickGroupedOutFile(fh FileHeader, key string, file *outFile) *outFile {
 	if file == nil {
 		return &outFile{
 			header: fh,
+			key:    key,
 		}
 	}
-	if fh.ImmediateOrigin == file.header.ImmediateOrigin &&
-		fh.ImmediateDestination == file.header.ImmediateDestination {
-		return file
+	for {
+		if fh.ImmediateOrigin == file.header.ImmediateOrigin &&
+			fh.ImmediateDestination == file.header.ImmediateDestination &&
+			key == file.key {
+			return file
+		}
+		if file.next == nil {
+			file.next = &outFile{
+				header: fh,
+				key:    key,
+			}
+			return file.next
+		}
+		file = file.next
 	}
-	if file.next == nil {
-		file.next = &outFile{
-			header: fh,
+}
+
+// sortOutFiles orders the linked list of outFiles by ImmediateDestination, ImmediateOrigin and
+// group key. Batches within each outFile are ordered by their header and first TraceNumber.
+func sortOutFiles(file *outFile) *outFile {
+	var files []*outFile
+	for ; file != nil; file = file.next {
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		hi, hj := files[i].header, files[j].header
+		if hi.ImmediateDestination != hj.ImmediateDestination {
+			return hi.ImmediateDestination < hj.ImmediateDestination
+		}
+		if hi.ImmediateOrigin != hj.ImmediateOrigin {
+			return hi.ImmediateOrigin < hj.ImmediateOrigin
+		}
+		return files[i].key < files[j].key
+	})
+	for i := range files {
+		sort.SliceStable(files[i].batches, func(a, b int) bool {
+			return files[i].batches[a].sortKey() < files[i].batches[b].sortKey()
+		})
+		sort.SliceStable(files[i].iatBatches, func(a, b int) bool {
+			return files[i].iatBatches[a].sortKey() < files[i].iatBatches[b].sortKey()
+		})
+
+		files[i].next = nil
+		if i > 0 {
+			files[i-1].next = files[i]
 		}
-		return file.next
 	}
-	return pickOutFile(fh, file.next)
+	return files[0]
 }
 
 // findOutBatch searches an array of batches for one whose BatcHeader matches bh
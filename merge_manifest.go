@@ -0,0 +1,94 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+// MergeResult contains the merged Files along with a Manifest describing the source of every merged entry.
+type MergeResult struct {
+	Files []*File `json:"files"`
+
+	// Manifest has one MergedFile for each of Files, in the same order.
+	Manifest []MergedFile `json:"manifest"`
+}
+
+// MergedFile describes the batches of a merged File
+type MergedFile struct {
+	// FileID is the ID of the merged File, which is often empty until the File is stored.
+	FileID string `json:"fileID"`
+
+	Batches []MergedBatch `json:"batches"`
+}
+
+// MergedBatch describes the entries of a batch in a merged File
+type MergedBatch struct {
+	BatchNumber int `json:"batchNumber"`
+
+	Entries []MergedEntry `json:"entries"`
+}
+
+// MergedEntry describes where an entry of a merged File came from
+type MergedEntry struct {
+	// TraceNumber is the entry's TraceNumber in the merged File
+	TraceNumber string `json:"traceNumber"`
+
+	// SourceIndex is the position of the source File in the slice passed to MergeFilesWithManifest.
+	// It is zero for MergeDirWithManifest.
+	SourceIndex int `json:"sourceIndex"`
+
+	// SourceFileID is the ID of the source File
+	SourceFileID string `json:"sourceFileID,omitempty"`
+
+	// SourcePath is the filepath the source File was read from by MergeDirWithManifest
+	SourcePath string `json:"sourcePath,omitempty"`
+
+	// SourceBatchNumber is the BatchNumber of the entry's batch in the source File
+	SourceBatchNumber int `json:"sourceBatchNumber"`
+
+	// OriginalTraceNumber is the entry's TraceNumber in the source File
+	OriginalTraceNumber string `json:"originalTraceNumber"`
+}
+
+// TraceNumberReassigned returns true when the entry's TraceNumber was changed while merging.
+func (e MergedEntry) TraceNumberReassigned() bool {
+	return e.TraceNumber != e.OriginalTraceNumber
+}
+
+// mergeSource identifies a File being merged
+type mergeSource struct {
+	index  int
+	fileID string
+	path   string
+}
+
+// mergedEntrySource records where an entry was merged from
+type mergedEntrySource struct {
+	source      *mergeSource
+	batchNumber int
+}
+
+func (s mergedEntrySource) entry(originalTraceNumber string) MergedEntry {
+	out := MergedEntry{
+		SourceBatchNumber:   s.batchNumber,
+		OriginalTraceNumber: originalTraceNumber,
+	}
+	if s.source != nil {
+		out.SourceIndex = s.source.index
+		out.SourceFileID = s.source.fileID
+		out.SourcePath = s.source.path
+	}
+	return out
+}
@@ -1064,3 +1064,258 @@ func TestMergeDir_DeadlockPrevention(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "merging ADV batches is not supported")
 }
+
+func TestMergeFiles__GroupBy(t *testing.T) {
+	f1, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	f2, err := readACHFilepath(filepath.Join("test", "testdata", "web-debit.ach"))
+	require.NoError(t, err)
+	f2.Header = f1.Header // replace Header so they're merged into one file
+
+	out, err := MergeFilesWith([]*File{f1, f2}, Conditions{
+		GroupBy: []MergeGroupKey{GroupBySECCode},
+	})
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+
+	require.Len(t, out[0].Batches, 2)
+	for _, b := range out[0].Batches {
+		require.Equal(t, PPD, b.GetHeader().StandardEntryClassCode)
+	}
+	require.Len(t, out[1].Batches, 2)
+	for _, b := range out[1].Batches {
+		require.Equal(t, WEB, b.GetHeader().StandardEntryClassCode)
+	}
+
+	// batch numbers continue across files
+	require.Equal(t, 3, out[1].Batches[0].GetHeader().BatchNumber)
+
+	for _, f := range out {
+		require.NoError(t, f.Validate())
+	}
+}
+
+func TestMergeFiles__Separate(t *testing.T) {
+	f1, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	f2, err := readACHFilepath(filepath.Join("test", "testdata", "web-debit.ach"))
+	require.NoError(t, err)
+	f2.Header = f1.Header
+
+	t.Run("WEB credits", func(t *testing.T) {
+		out, err := MergeFilesWith([]*File{f1, f2}, Conditions{
+			Separate: []MergeSeparation{
+				{SECCodes: []string{WEB}, Credits: true},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, out, 2)
+		require.Len(t, out[0].Batches, 2)
+		require.Len(t, out[1].Batches, 2)
+		require.Equal(t, WEB, out[1].Batches[0].GetHeader().StandardEntryClassCode)
+	})
+
+	t.Run("WEB debits", func(t *testing.T) {
+		out, err := MergeFilesWith([]*File{f1, f2}, Conditions{
+			Separate: []MergeSeparation{
+				{SECCodes: []string{WEB}, Debits: true},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, out, 1)
+		require.Len(t, out[0].Batches, 4)
+	})
+
+	t.Run("CompanyIdentification", func(t *testing.T) {
+		companyID := f2.Batches[0].GetHeader().CompanyIdentification
+		out, err := MergeFilesWith([]*File{f1, f2}, Conditions{
+			Separate: []MergeSeparation{
+				{CompanyIdentifications: []string{companyID}},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, out, 2)
+		for _, b := range out[1].Batches {
+			require.Equal(t, companyID, b.GetHeader().CompanyIdentification)
+		}
+	})
+}
+
+func TestMergeFiles__MaxBatches(t *testing.T) {
+	f1, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	f2, err := readACHFilepath(filepath.Join("test", "testdata", "web-debit.ach"))
+	require.NoError(t, err)
+	f2.Header = f1.Header
+
+	out, err := MergeFilesWith([]*File{f1, f2}, Conditions{
+		MaxBatches: 3,
+	})
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+	require.Len(t, out[0].Batches, 3)
+	require.Len(t, out[1].Batches, 1)
+
+	for _, f := range out {
+		require.NoError(t, f.Validate())
+	}
+}
+
+func TestMergeFiles__MaxEntriesPerBatch(t *testing.T) {
+	f1, err := readACHFilepath(filepath.Join("test", "testdata", "web-debit.ach"))
+	require.NoError(t, err)
+
+	out, err := MergeFilesWith([]*File{f1}, Conditions{
+		MaxEntriesPerBatch: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	require.Len(t, out[0].Batches, 4)
+
+	for _, b := range out[0].Batches {
+		require.LessOrEqual(t, len(b.GetEntries()), 2)
+	}
+	require.Equal(t, f1.Control.TotalCreditEntryDollarAmountInFile, out[0].Control.TotalCreditEntryDollarAmountInFile)
+	require.NoError(t, out[0].Validate())
+
+	t.Run("with MaxBatches", func(t *testing.T) {
+		out, err := MergeFilesWith([]*File{f1}, Conditions{
+			MaxBatches:         1,
+			MaxEntriesPerBatch: 2,
+		})
+		require.NoError(t, err)
+		require.Len(t, out, 4)
+		for _, f := range out {
+			require.Len(t, f.Batches, 1)
+			require.NoError(t, f.Validate())
+		}
+	})
+}
+
+func TestMergeFiles__MaxCreditAndDebitAmount(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	out, err := MergeFilesWith([]*File{file}, Conditions{
+		MaxCreditAmount: 100000000,
+	})
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+	for _, f := range out {
+		require.LessOrEqual(t, f.Control.TotalCreditEntryDollarAmountInFile, 100000000)
+		require.NoError(t, f.Validate())
+	}
+
+	// debits are capped separately
+	out, err = MergeFilesWith([]*File{file}, Conditions{
+		MaxCreditAmount: 200000000,
+		MaxDebitAmount:  200000000,
+	})
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+}
+
+func TestMergeFiles__SortOutput(t *testing.T) {
+	f1, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	f2, err := readACHFilepath(filepath.Join("test", "testdata", "web-debit.ach"))
+	require.NoError(t, err)
+
+	conditions := Conditions{
+		GroupBy:    []MergeGroupKey{GroupBySECCode},
+		SortOutput: true,
+	}
+	first, err := MergeFilesWith([]*File{f1, f2}, conditions)
+	require.NoError(t, err)
+
+	second, err := MergeFilesWith([]*File{f2, f1}, conditions)
+	require.NoError(t, err)
+
+	require.Len(t, first, len(second))
+	for i := range first {
+		require.Equal(t, first[i].Header.ImmediateDestination, second[i].Header.ImmediateDestination)
+		require.Len(t, second[i].Batches, len(first[i].Batches))
+		for j := range first[i].Batches {
+			require.Equal(t, first[i].Batches[j].GetHeader().StandardEntryClassCode, second[i].Batches[j].GetHeader().StandardEntryClassCode)
+			require.Equal(t, first[i].Batches[j].GetHeader().BatchNumber, second[i].Batches[j].GetHeader().BatchNumber)
+		}
+	}
+}
+
+func TestMergeFilesWithManifest(t *testing.T) {
+	f1, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	f1.ID = "first"
+
+	f2, err := readACHFilepath(filepath.Join("test", "testdata", "web-debit.ach"))
+	require.NoError(t, err)
+	f2.ID = "second"
+	f2.Header = f1.Header
+
+	result, err := MergeFilesWithManifest([]*File{f1, f2}, Conditions{
+		MaxBatches: 3,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Files, 2)
+	require.Len(t, result.Manifest, 2)
+
+	var found int
+	for i := range result.Files {
+		batches := result.Files[i].Batches
+		require.Len(t, result.Manifest[i].Batches, len(batches))
+
+		for j := range batches {
+			mb := result.Manifest[i].Batches[j]
+			require.Equal(t, batches[j].GetHeader().BatchNumber, mb.BatchNumber)
+
+			entries := batches[j].GetEntries()
+			require.Len(t, mb.Entries, len(entries))
+			for k := range entries {
+				require.Equal(t, entries[k].TraceNumber, mb.Entries[k].TraceNumber)
+				require.False(t, mb.Entries[k].TraceNumberReassigned())
+
+				if mb.Entries[k].SourceFileID == "second" {
+					require.Equal(t, 1, mb.Entries[k].SourceIndex)
+					require.Contains(t, []int{1, 2, 3}, mb.Entries[k].SourceBatchNumber)
+				}
+				found++
+			}
+		}
+	}
+	require.Equal(t, 7, found)
+
+	// MergeFilesWith doesn't return a manifest
+	files, err := MergeFilesWith([]*File{f1, f2}, Conditions{})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
+
+func TestMergeDirWithManifest(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"ppd-debit.ach", "web-debit.ach"} {
+		bs, err := os.ReadFile(filepath.Join("test", "testdata", name))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), bs, 0600))
+	}
+
+	result, err := MergeDirWithManifest(dir, Conditions{SortOutput: true}, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Files, 2)
+	require.Len(t, result.Manifest, 2)
+
+	paths := make(map[string]int)
+	for _, mf := range result.Manifest {
+		for _, mb := range mf.Batches {
+			for _, entry := range mb.Entries {
+				paths[filepath.Base(entry.SourcePath)]++
+			}
+		}
+	}
+	require.Equal(t, 1, paths["ppd-debit.ach"])
+	require.Equal(t, 6, paths["web-debit.ach"])
+}
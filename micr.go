@@ -0,0 +1,247 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// E-13B MICR symbols. Scanners which can't output these characters commonly substitute the
+// E-13B font mapping (A transit, B amount, C on-us, D dash) or T, $, U and - which ParseMICR also reads.
+const (
+	micrTransit = '⑆'
+	micrAmount  = '⑇'
+	micrOnUs    = '⑈'
+	micrDash    = '⑉'
+)
+
+var (
+	// ErrInvalidMICR is returned when a MICR line is missing a field or has unreadable characters.
+	ErrInvalidMICR = errors.New("invalid MICR line")
+)
+
+// MICRLine holds the fields read from the E-13B MICR line printed along the bottom of a check.
+type MICRLine struct {
+	// RoutingNumber is the nine digit ABA routing number from the transit field.
+	RoutingNumber string
+
+	// AccountNumber is the on-us field left of its last on-us symbol without spaces or dashes.
+	AccountNumber string
+
+	// ProcessControl is the on-us field right of its last on-us symbol. It holds the serial number of personal checks.
+	ProcessControl string
+
+	// AuxiliaryOnUs is the field left of the transit field. It holds the serial number of business checks.
+	AuxiliaryOnUs string
+
+	// Amount is the encoded amount of the check in cents, or zero when the check has not been encoded.
+	Amount int
+}
+
+// CheckSerialNumber returns the auxiliary on-us field of business checks and the process control field otherwise.
+func (m *MICRLine) CheckSerialNumber() string {
+	return cmp.Or(m.AuxiliaryOnUs, m.ProcessControl)
+}
+
+// ParseMICR reads the auxiliary on-us, transit, on-us and amount fields of an E-13B MICR line
+// and validates the routing number's check digit.
+//
+// Example: ⑆231380104⑆ 744567899⑈ 1234
+func ParseMICR(line string) (*MICRLine, error) {
+	symbols := make([]rune, 0, len(line))
+	for _, r := range strings.ToUpper(line) {
+		switch {
+		case r == micrTransit || r == 'A' || r == 'T':
+			symbols = append(symbols, micrTransit)
+		case r == micrAmount || r == 'B' || r == '$':
+			symbols = append(symbols, micrAmount)
+		case r == micrOnUs || r == 'C' || r == 'U':
+			symbols = append(symbols, micrOnUs)
+		case r == micrDash || r == 'D' || r == '-':
+			symbols = append(symbols, micrDash)
+		case r == ' ' || (r >= '0' && r <= '9'):
+			symbols = append(symbols, r)
+		default:
+			return nil, fmt.Errorf("%w: unreadable character %q", ErrInvalidMICR, r)
+		}
+	}
+
+	transit := micrSymbols(symbols, micrTransit)
+	if len(transit) != 2 {
+		return nil, fmt.Errorf("%w: found %d transit symbols, expected 2", ErrInvalidMICR, len(transit))
+	}
+
+	m := &MICRLine{
+		RoutingNumber: micrDigits(symbols[transit[0]+1 : transit[1]]),
+	}
+	if err := validateDigits(m.RoutingNumber, 9); err != nil {
+		return nil, fieldError("RoutingNumber", err, m.RoutingNumber)
+	}
+	if check := CalculateCheckDigit(m.RoutingNumber); m.RoutingNumber[8:] != strconv.Itoa(check) {
+		return nil, fieldError("RoutingNumber", NewErrValidCheckDigit(check), m.RoutingNumber)
+	}
+
+	// Business checks print their serial number between on-us symbols left of the transit field
+	if aux := micrSymbols(symbols[:transit[0]], micrOnUs); len(aux) >= 2 {
+		m.AuxiliaryOnUs = micrDigits(symbols[aux[0]+1 : aux[len(aux)-1]])
+	}
+
+	onUs := symbols[transit[1]+1:]
+	if amount := micrSymbols(onUs, micrAmount); len(amount) > 0 {
+		if len(amount) != 2 {
+			return nil, fmt.Errorf("%w: found %d amount symbols, expected 2", ErrInvalidMICR, len(amount))
+		}
+		digits := micrDigits(onUs[amount[0]+1 : amount[1]])
+		if err := validateDigits(digits, 10); err != nil {
+			return nil, fieldError("Amount", err, digits)
+		}
+		m.Amount, _ = strconv.Atoi(digits)
+		onUs = onUs[:amount[0]]
+	}
+
+	separators := micrSymbols(onUs, micrOnUs)
+	if len(separators) == 0 {
+		return nil, fmt.Errorf("%w: missing on-us symbol", ErrInvalidMICR)
+	}
+	last := separators[len(separators)-1]
+	var start int
+	if len(separators) > 1 {
+		start = separators[len(separators)-2] + 1
+	}
+	m.AccountNumber = micrDigits(onUs[start:last])
+	m.ProcessControl = micrDigits(onUs[last+1:])
+	if m.AccountNumber == "" {
+		return nil, fieldError("AccountNumber", ErrFieldRequired)
+	}
+
+	return m, nil
+}
+
+// micrSymbols returns the index of each symbol in symbols
+func micrSymbols(symbols []rune, symbol rune) []int {
+	var out []int
+	for i := range symbols {
+		if symbols[i] == symbol {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// micrDigits returns the digits of a MICR field, dropping spaces, dashes and other symbols
+func micrDigits(symbols []rune) string {
+	var sb strings.Builder
+	for _, r := range symbols {
+		if r >= '0' && r <= '9' {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// MICREntryOptions holds the fields of a check conversion entry which are not printed on the MICR line.
+type MICREntryOptions struct {
+	// TransactionCode defaults to CheckingDebit
+	TransactionCode int
+
+	// Amount in cents overrides the MICR line's amount field, and is required for checks which aren't encoded.
+	Amount int
+
+	// IndividualName is the Receiver's name. TRX entries use it as the Receiving Company.
+	IndividualName string
+
+	// TerminalCity and TerminalState are where a POP check was presented.
+	TerminalCity  string
+	TerminalState string
+
+	// ItemResearchNumber is the ODFI's reference to the truncated or destroyed check of a TRC or XCK entry.
+	ItemResearchNumber string
+
+	// ItemTypeIndicator is used in TRC and TRX entries.
+	ItemTypeIndicator string
+}
+
+// micrAmountLimits are the largest checks in cents each SEC code can convert
+var micrAmountLimits = map[string]int{
+	ARC: 2500000,
+	BOC: 2500000,
+	POP: 2500000,
+	RCK: 250000,
+	XCK: 250000,
+}
+
+// EntryDetail returns a debit converting the check for a batch of secCode (ARC, BOC, POP, RCK, TRC, TRX or XCK).
+// The check serial number is placed where the SEC code expects it and checks the SEC code can't convert,
+// such as ARC and BOC checks over $25,000, are rejected.
+//
+// The returned entry has no trace number, which is set when it's added to a batch.
+func (m *MICRLine) EntryDetail(secCode string, opts MICREntryOptions) (*EntryDetail, error) {
+	amount := cmp.Or(opts.Amount, m.Amount)
+	if amount <= 0 {
+		return nil, fieldError("Amount", ErrFieldRequired)
+	}
+	if limit, exists := micrAmountLimits[secCode]; exists && amount > limit {
+		return nil, fieldError("Amount", NewErrBatchAmount(amount, limit), amount)
+	}
+
+	serial := m.CheckSerialNumber()
+	if serial == "" && secCode != TRX {
+		return nil, fieldError("CheckSerialNumber", ErrBatchCheckSerialNumber)
+	}
+
+	ed := NewEntryDetail()
+	ed.TransactionCode = cmp.Or(opts.TransactionCode, CheckingDebit)
+	ed.SetRDFI(m.RoutingNumber)
+	ed.DFIAccountNumber = m.AccountNumber
+	ed.Amount = amount
+
+	switch secCode {
+	case ARC, BOC, RCK:
+		ed.SetCheckSerialNumber(serial)
+		ed.IndividualName = opts.IndividualName
+
+	case POP:
+		ed.SetPOPCheckSerialNumber(serial)
+		ed.SetPOPTerminalCity(opts.TerminalCity)
+		ed.SetPOPTerminalState(opts.TerminalState)
+		ed.IndividualName = opts.IndividualName
+
+	case TRC, XCK:
+		ed.SetCheckSerialNumber(serial)
+		ed.SetProcessControlField(cmp.Or(m.ProcessControl, serial))
+		ed.SetItemResearchNumber(opts.ItemResearchNumber)
+		if secCode == TRC {
+			ed.SetItemTypeIndicator(opts.ItemTypeIndicator)
+		}
+
+	case TRX:
+		ed.SetCheckSerialNumber(serial)
+		ed.SetCATXAddendaRecords(0)
+		ed.SetCATXReceivingCompany(opts.IndividualName)
+		ed.SetItemTypeIndicator(opts.ItemTypeIndicator)
+
+	default:
+		return nil, fieldError("StandardEntryClassCode", ErrSECCode, secCode)
+	}
+
+	return ed, nil
+}
@@ -0,0 +1,168 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMICR(t *testing.T) {
+	t.Run("personal", func(t *testing.T) {
+		m, err := ParseMICR("⑆231380104⑆ 744⑉5678⑉99⑈ 1234")
+		require.NoError(t, err)
+		require.Equal(t, &MICRLine{
+			RoutingNumber:  "231380104",
+			AccountNumber:  "744567899",
+			ProcessControl: "1234",
+		}, m)
+		require.Equal(t, "1234", m.CheckSerialNumber())
+	})
+
+	t.Run("business", func(t *testing.T) {
+		m, err := ParseMICR("C001234C A231380104A 744567899C")
+		require.NoError(t, err)
+		require.Equal(t, "001234", m.AuxiliaryOnUs)
+		require.Equal(t, "744567899", m.AccountNumber)
+		require.Equal(t, "", m.ProcessControl)
+		require.Equal(t, "001234", m.CheckSerialNumber())
+	})
+
+	t.Run("amount", func(t *testing.T) {
+		m, err := ParseMICR("t231380104t 744-5678-99u 1234 $0000012345$")
+		require.NoError(t, err)
+		require.Equal(t, 12345, m.Amount)
+		require.Equal(t, "1234", m.ProcessControl)
+
+		_, err = ParseMICR("T231380104T 744567899U 1234 $12345$")
+		require.ErrorContains(t, err, "is not length 10")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseMICR("⑆231380105⑆ 744567899⑈ 1234")
+		require.ErrorContains(t, err, "does not match calculated check digit 4")
+
+		_, err = ParseMICR("⑆2313801⑆ 744567899⑈ 1234")
+		require.ErrorContains(t, err, "is not length 9")
+
+		_, err = ParseMICR("⑆231380104⑆ 744?67899⑈ 1234")
+		require.ErrorIs(t, err, ErrInvalidMICR)
+		require.ErrorContains(t, err, `unreadable character '?'`)
+
+		_, err = ParseMICR("231380104⑆ 744567899⑈ 1234")
+		require.ErrorIs(t, err, ErrInvalidMICR)
+
+		_, err = ParseMICR("⑆231380104⑆ 744567899 1234")
+		require.ErrorContains(t, err, "missing on-us symbol")
+
+		_, err = ParseMICR("⑆231380104⑆ ⑈ 1234")
+		require.ErrorIs(t, err, ErrFieldRequired)
+	})
+}
+
+func TestMICRLine_EntryDetail(t *testing.T) {
+	personal, err := ParseMICR("⑆231380104⑆ 744567899⑈ 1234")
+	require.NoError(t, err)
+
+	opts := MICREntryOptions{
+		Amount:             25000,
+		IndividualName:     "Jane Doe",
+		TerminalCity:       "PHIL",
+		TerminalState:      "PA",
+		ItemResearchNumber: "182726",
+		ItemTypeIndicator:  "01",
+	}
+
+	cases := []struct {
+		header *BatchHeader
+		check  func(t *testing.T, ed *EntryDetail)
+	}{
+		{mockBatchARCHeader(), func(t *testing.T, ed *EntryDetail) {
+			require.Equal(t, "1234", ed.IdentificationNumber)
+		}},
+		{mockBatchBOCHeader(), func(t *testing.T, ed *EntryDetail) {
+			require.Equal(t, "1234", ed.IdentificationNumber)
+		}},
+		{mockBatchRCKHeader(), func(t *testing.T, ed *EntryDetail) {
+			require.Equal(t, "1234", ed.IdentificationNumber)
+		}},
+		{mockBatchPOPHeader(), func(t *testing.T, ed *EntryDetail) {
+			require.Equal(t, "1234", ed.POPCheckSerialNumberField())
+			require.Equal(t, "PHIL", ed.POPTerminalCityField())
+			require.Equal(t, "PA", ed.POPTerminalStateField())
+		}},
+		{mockBatchTRCHeader(), func(t *testing.T, ed *EntryDetail) {
+			require.Equal(t, "1234", ed.ProcessControlField())
+			require.Equal(t, "01", ed.ItemTypeIndicator())
+		}},
+		{mockBatchTRXHeader(), func(t *testing.T, ed *EntryDetail) {
+			require.Equal(t, "Jane Doe", ed.CATXReceivingCompanyField()[:8])
+		}},
+		{mockBatchXCKHeader(), func(t *testing.T, ed *EntryDetail) {
+			require.Equal(t, "1234", ed.ProcessControlField())
+		}},
+	}
+	for _, tc := range cases {
+		secCode := tc.header.StandardEntryClassCode
+		t.Run(secCode, func(t *testing.T) {
+			ed, err := personal.EntryDetail(secCode, opts)
+			require.NoError(t, err)
+			require.Equal(t, CheckingDebit, ed.TransactionCode)
+			require.Equal(t, "23138010", ed.RDFIIdentification)
+			require.Equal(t, "4", ed.CheckDigit)
+			require.Equal(t, "744567899", ed.DFIAccountNumber)
+			require.Equal(t, 25000, ed.Amount)
+			tc.check(t, ed)
+
+			batch, err := NewBatch(tc.header)
+			require.NoError(t, err)
+			ed.SetTraceNumber(tc.header.ODFIIdentification, 1)
+			batch.AddEntry(ed)
+			require.NoError(t, batch.Create())
+		})
+	}
+
+	t.Run("ineligible", func(t *testing.T) {
+		large := opts
+		large.Amount = 2500001
+		_, err := personal.EntryDetail(ARC, large)
+		require.ErrorContains(t, err, "limited to 2500000")
+		_, err = personal.EntryDetail(BOC, large)
+		require.ErrorContains(t, err, "limited to 2500000")
+
+		large.Amount = 250001
+		_, err = personal.EntryDetail(RCK, large)
+		require.ErrorContains(t, err, "limited to 250000")
+
+		// TRC entries have no limit
+		_, err = personal.EntryDetail(TRC, large)
+		require.NoError(t, err)
+
+		_, err = personal.EntryDetail(ARC, MICREntryOptions{})
+		require.ErrorIs(t, err, ErrFieldRequired)
+
+		_, err = personal.EntryDetail(PPD, opts)
+		require.ErrorIs(t, err, ErrSECCode)
+
+		business, err := ParseMICR("⑆231380104⑆ 744567899⑈")
+		require.NoError(t, err)
+		_, err = business.EntryDetail(BOC, opts)
+		require.ErrorIs(t, err, ErrBatchCheckSerialNumber)
+	})
+}
@@ -0,0 +1,266 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ofac
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Entity is a sanctioned individual, organization, vessel or aircraft.
+type Entity struct {
+	// ID is the unique number OFAC assigns to each entry (ent_num or uid)
+	ID string `json:"id"`
+
+	// Name is the primary name of the entity. Individuals are written as "LAST, First".
+	Name string `json:"name"`
+
+	// Type is one of individual, entity, vessel or aircraft
+	Type string `json:"type"`
+
+	// Programs are the sanctions programs the entity is listed under
+	Programs []string `json:"programs,omitempty"`
+
+	// AltNames are the a.k.a., f.k.a. and n.k.a. names of the entity
+	AltNames []string `json:"altNames,omitempty"`
+
+	Remarks string `json:"remarks,omitempty"`
+}
+
+// List is a set of sanctioned entities loaded from OFAC's SDN or consolidated (non-SDN) list files.
+type List struct {
+	Entities []*Entity
+
+	byID map[string]*Entity
+}
+
+func newList() *List {
+	return &List{
+		byID: make(map[string]*Entity),
+	}
+}
+
+func (l *List) add(entity *Entity) {
+	if existing, ok := l.byID[entity.ID]; ok {
+		*existing = *entity
+		return
+	}
+	l.Entities = append(l.Entities, entity)
+	l.byID[entity.ID] = entity
+}
+
+// Get returns the entity with id, or nil if it's not in the list.
+func (l *List) Get(id string) *Entity {
+	if l == nil {
+		return nil
+	}
+	return l.byID[id]
+}
+
+// ReadFiles loads the OFAC list files at paths into one List.
+//
+// Files ending in .xml are read as sdn.xml or consolidated.xml. CSV files whose name contains "alt",
+// such as alt.csv and cons_alt.csv, add alternate names to entities read from the other files. Every
+// other CSV file is read like sdn.csv or cons_prim.csv.
+func ReadFiles(paths ...string) (*List, error) {
+	list := newList()
+
+	var alternates []string
+	for _, path := range paths {
+		name := strings.ToLower(filepath.Base(path))
+		if strings.HasSuffix(name, ".csv") && strings.Contains(name, "alt") {
+			alternates = append(alternates, path) // read after the primary names
+			continue
+		}
+		err := readFile(path, func(r io.Reader) error {
+			if strings.HasSuffix(name, ".xml") {
+				return list.readXML(r)
+			}
+			return list.readCSV(r)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, path := range alternates {
+		if err := readFile(path, list.ReadAltCSV); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+func readFile(path string, read func(io.Reader) error) error {
+	fd, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	if err := read(fd); err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadCSV reads entities in the format of OFAC's sdn.csv and cons_prim.csv files. Rows have the columns
+// ent_num, SDN_Name, SDN_Type, Program, Title, Call_Sign, Vess_type, Tonnage, GRT, Vess_flag, Vess_owner
+// and Remarks.
+func ReadCSV(r io.Reader) (*List, error) {
+	list := newList()
+	if err := list.readCSV(r); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (l *List) readCSV(r io.Reader) error {
+	return readRecords(r, 4, func(record []string) {
+		entity := &Entity{
+			ID:   record[0],
+			Name: record[1],
+			Type: strings.ToLower(record[2]),
+		}
+		if entity.Type == "" {
+			entity.Type = "entity"
+		}
+		if record[3] != "" {
+			entity.Programs = strings.Fields(strings.NewReplacer("[", "", "]", "").Replace(record[3]))
+		}
+		if len(record) > 11 {
+			entity.Remarks = record[11]
+		}
+		l.add(entity)
+	})
+}
+
+// ReadAltCSV adds alternate names in the format of OFAC's alt.csv and cons_alt.csv files to entities
+// already in the list. Rows have the columns ent_num, alt_num, alt_type, alt_name and alt_remarks.
+func (l *List) ReadAltCSV(r io.Reader) error {
+	return readRecords(r, 4, func(record []string) {
+		if entity := l.byID[record[0]]; entity != nil && record[3] != "" {
+			entity.AltNames = append(entity.AltNames, record[3])
+		}
+	})
+}
+
+// readRecords calls fn with each row of an OFAC CSV file, skipping header and malformed rows.
+// OFAC's "-0-" null markers are replaced with empty strings.
+func readRecords(r io.Reader, minFields int, fn func([]string)) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(record) < minFields || !isNumber(record[0]) {
+			continue // header, blank or the trailing end-of-file marker
+		}
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+			if record[i] == "-0-" {
+				record[i] = ""
+			}
+		}
+		fn(record)
+	}
+}
+
+func isNumber(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+type xmlList struct {
+	Entries []xmlEntry `xml:"sdnEntry"`
+}
+
+type xmlEntry struct {
+	UID       string   `xml:"uid"`
+	FirstName string   `xml:"firstName"`
+	LastName  string   `xml:"lastName"`
+	Type      string   `xml:"sdnType"`
+	Programs  []string `xml:"programList>program"`
+	Remarks   string   `xml:"remarks"`
+	AKAs      []struct {
+		FirstName string `xml:"firstName"`
+		LastName  string `xml:"lastName"`
+	} `xml:"akaList>aka"`
+}
+
+// ReadXML reads entities in the format of OFAC's sdn.xml and consolidated.xml files, including their
+// alternate names.
+func ReadXML(r io.Reader) (*List, error) {
+	list := newList()
+	if err := list.readXML(r); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (l *List) readXML(r io.Reader) error {
+	var doc xmlList
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return err
+	}
+	for _, entry := range doc.Entries {
+		entity := &Entity{
+			ID:       strings.TrimSpace(entry.UID),
+			Name:     xmlName(entry.LastName, entry.FirstName),
+			Type:     strings.ToLower(strings.TrimSpace(entry.Type)),
+			Programs: entry.Programs,
+			Remarks:  strings.TrimSpace(entry.Remarks),
+		}
+		for _, aka := range entry.AKAs {
+			if name := xmlName(aka.LastName, aka.FirstName); name != "" {
+				entity.AltNames = append(entity.AltNames, name)
+			}
+		}
+		l.add(entity)
+	}
+	return nil
+}
+
+// xmlName joins names the way the CSV files list them, "LAST, First"
+func xmlName(last, first string) string {
+	last, first = strings.TrimSpace(last), strings.TrimSpace(first)
+	if first == "" {
+		return last
+	}
+	return last + ", " + first
+}
@@ -0,0 +1,83 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ofac
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testdata(names ...string) []string {
+	var out []string
+	for _, name := range names {
+		out = append(out, filepath.Join("..", "test", "testdata", "ofac", name))
+	}
+	return out
+}
+
+func TestReadFiles__CSV(t *testing.T) {
+	// alt.csv is listed first, but read after sdn.csv
+	list, err := ReadFiles(testdata("alt.csv", "sdn.csv")...)
+	require.NoError(t, err)
+	require.Len(t, list.Entities, 4)
+
+	cuba := list.Get("306")
+	require.Equal(t, "BANCO NACIONAL DE CUBA", cuba.Name)
+	require.Equal(t, "entity", cuba.Type)
+	require.Equal(t, []string{"CUBA"}, cuba.Programs)
+	require.Equal(t, []string{"NATIONAL BANK OF CUBA"}, cuba.AltNames)
+	require.Equal(t, "a.k.a. 'BNC'.", cuba.Remarks)
+
+	individual := list.Get("2674")
+	require.Equal(t, "HAWATMA, Nayif", individual.Name)
+	require.Equal(t, "individual", individual.Type)
+	require.Equal(t, []string{"HAWATMEH, Nayef"}, individual.AltNames)
+
+	require.Nil(t, list.Get("99999"))
+}
+
+func TestReadFiles__XML(t *testing.T) {
+	list, err := ReadFiles(testdata("sdn.xml")...)
+	require.NoError(t, err)
+	require.Len(t, list.Entities, 2)
+
+	individual := list.Get("2674")
+	require.Equal(t, "HAWATMA, Nayif", individual.Name)
+	require.Equal(t, "individual", individual.Type)
+	require.Equal(t, []string{"SDGT"}, individual.Programs)
+	require.Equal(t, []string{"HAWATMEH, Nayef"}, individual.AltNames)
+	require.Equal(t, []string{"AERO-CARIBBEAN"}, list.Get("36").AltNames)
+}
+
+func TestReadFiles__Errors(t *testing.T) {
+	_, err := ReadFiles(testdata("missing.csv")...)
+	require.Error(t, err)
+
+	_, err = ReadXML(strings.NewReader("<sdnList>"))
+	require.Error(t, err)
+}
+
+func TestReadCSV__Header(t *testing.T) {
+	list, err := ReadCSV(strings.NewReader("ent_num,SDN_Name,SDN_Type,Program\n10,\"EXAMPLE\",\"-0- \",\"[SDGT] [IRGC]\"\n"))
+	require.NoError(t, err)
+	require.Len(t, list.Entities, 1)
+	require.Equal(t, []string{"SDGT", "IRGC"}, list.Entities[0].Programs)
+}
@@ -0,0 +1,154 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ofac
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ignoredWords are dropped from names before they're compared as they rarely distinguish parties
+var ignoredWords = map[string]bool{
+	"THE": true, "CO": true, "COMPANY": true, "CORP": true, "CORPORATION": true, "INC": true,
+	"LLC": true, "LTD": true, "LIMITED": true, "PLC": true, "SA": true, "GMBH": true,
+}
+
+// Normalize prepares a name for matching by removing accents and punctuation, upper casing it and
+// dropping common company suffixes such as INC and LLC.
+func Normalize(name string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	if out, _, err := transform.String(t, name); err == nil {
+		name = out
+	}
+	words := strings.FieldsFunc(strings.ToUpper(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	kept := make([]string, 0, len(words))
+	for _, w := range words {
+		if !ignoredWords[w] {
+			kept = append(kept, w)
+		}
+	}
+	if len(kept) == 0 {
+		kept = words
+	}
+	return strings.Join(kept, " ")
+}
+
+// JaroWinkler returns the Jaro-Winkler similarity of a and b, from 0 for no similarity to 1 for an
+// exact match. Strings sharing a prefix of up to four characters score higher.
+func JaroWinkler(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(len(ra), len(rb))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+
+	matches := 0
+	for i := range ra {
+		lo, hi := max(0, i-window), min(len(rb), i+window+1)
+		for j := lo; j < hi; j++ {
+			if matchedB[j] || ra[i] != rb[j] {
+				continue
+			}
+			matchedA[i], matchedB[j] = true, true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, k := 0, 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[k] {
+			k++
+		}
+		if ra[i] != rb[k] {
+			transpositions++
+		}
+		k++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for i := 0; i < min(4, len(ra), len(rb)) && ra[i] == rb[i]; i++ {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// candidate is a normalized name of an entity
+type candidate struct {
+	name   string // as written in the list
+	value  string
+	sorted string // words of value in alphabetical order
+}
+
+func newCandidate(name, value string) candidate {
+	words := strings.Fields(value)
+	slices.Sort(words)
+	return candidate{
+		name:   name,
+		value:  value,
+		sorted: strings.Join(words, " "),
+	}
+}
+
+// candidates returns the normalized primary and alternate names of entity. Names written as
+// "LAST, First" are also compared as "First LAST".
+func candidates(entity *Entity) []candidate {
+	var out []candidate
+	for _, name := range append([]string{entity.Name}, entity.AltNames...) {
+		if v := Normalize(name); v != "" {
+			out = append(out, newCandidate(name, v))
+		}
+		if last, first, ok := strings.Cut(name, ","); ok {
+			if v := Normalize(first + " " + last); v != "" {
+				out = append(out, newCandidate(name, v))
+			}
+		}
+	}
+	return out
+}
+
+// score compares a normalized name against c in its written and word sorted order
+func (c candidate) score(value, sorted string) float64 {
+	return max(JaroWinkler(value, c.value), JaroWinkler(sorted, c.sorted))
+}
@@ -0,0 +1,237 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package ofac screens the parties of ACH entries against OFAC sanctions lists.
+//
+// Lists are read from the SDN or consolidated (non-SDN) CSV and XML files published by OFAC.
+// Names are normalized and compared with Jaro-Winkler similarity, so small spelling differences
+// and reordered names are still found. Every party of an IATEntryDetail (the receiver, originator,
+// ODFI, RDFI and foreign correspondent banks) and the IndividualName of each EntryDetail is screened.
+package ofac
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/moov-io/ach"
+)
+
+// DefaultMinScore is the lowest similarity reported as a hit when Options.MinScore is zero.
+const DefaultMinScore = 0.92
+
+// Indicator chooses which OFAC screening indicator of IATEntryDetail records a Screener sets.
+type Indicator int
+
+const (
+	// IndicatorNone leaves IATEntryDetail records unchanged.
+	IndicatorNone Indicator = iota
+
+	// IndicatorGatewayOperator sets OFACScreeningIndicator (position 77), which is set by Gateway Operators.
+	IndicatorGatewayOperator
+
+	// IndicatorSecondary sets SecondaryOFACScreeningIndicator (position 78), which may be set by the ODFI.
+	IndicatorSecondary
+)
+
+// Options controls how a Screener matches names.
+type Options struct {
+	// MinScore is the lowest Jaro-Winkler similarity, between 0 and 1, reported as a hit.
+	// Zero uses DefaultMinScore.
+	MinScore float64 `json:"minScore"`
+
+	// Indicator is set to "1" on screened IATEntryDetail records with hits and "0" on those without.
+	Indicator Indicator `json:"indicator"`
+}
+
+// Match is a sanctioned entity similar to a screened name.
+type Match struct {
+	Entity *Entity `json:"entity"`
+
+	// MatchedName is the primary or alternate name of Entity which was most similar
+	MatchedName string `json:"matchedName"`
+
+	// Score is the similarity of the names, from 0 to 1
+	Score float64 `json:"score"`
+}
+
+// Party names which are screened
+const (
+	PartyReceiver                 = "Receiver"
+	PartyOriginator               = "Originator"
+	PartyODFI                     = "ODFI"
+	PartyRDFI                     = "RDFI"
+	PartyForeignCorrespondentBank = "Foreign Correspondent Bank"
+)
+
+// Hit is a party of an entry whose name matches a sanctioned entity.
+type Hit struct {
+	BatchNumber int    `json:"batchNumber"`
+	TraceNumber string `json:"traceNumber"`
+
+	// Party is which party of the entry was matched, such as Receiver or Originator
+	Party string `json:"party"`
+	Name  string `json:"name"`
+
+	Match
+}
+
+// Screener finds the entries of files whose parties match entities of a List.
+type Screener struct {
+	opts Options
+
+	entities   []*Entity
+	candidates [][]candidate
+}
+
+// NewScreener returns a Screener which matches names against list.
+func NewScreener(list *List, opts Options) *Screener {
+	if opts.MinScore <= 0 {
+		opts.MinScore = DefaultMinScore
+	}
+	s := &Screener{
+		opts: opts,
+	}
+	if list != nil {
+		for _, entity := range list.Entities {
+			s.entities = append(s.entities, entity)
+			s.candidates = append(s.candidates, candidates(entity))
+		}
+	}
+	return s
+}
+
+// Search returns the entities whose names are similar to name, with the most similar first.
+func (s *Screener) Search(name string) []Match {
+	value := Normalize(name)
+	if value == "" {
+		return nil
+	}
+	words := strings.Fields(value)
+	slices.Sort(words)
+	sorted := strings.Join(words, " ")
+
+	var out []Match
+	for i, entity := range s.entities {
+		best := Match{Entity: entity}
+		for _, c := range s.candidates[i] {
+			if score := c.score(value, sorted); score > best.Score {
+				best.MatchedName, best.Score = c.name, score
+			}
+		}
+		if best.Score >= s.opts.MinScore {
+			out = append(out, best)
+		}
+	}
+	slices.SortStableFunc(out, func(a, b Match) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return out
+}
+
+// ScreenFile returns the hits for every entry in file. When Options.Indicator is set the screening
+// indicator of each IATEntryDetail is updated.
+func (s *Screener) ScreenFile(file *ach.File) []Hit {
+	if file == nil {
+		return nil
+	}
+
+	var out []Hit
+	for _, batch := range file.Batches {
+		bh := batch.GetHeader()
+		for _, entry := range batch.GetEntries() {
+			hits := s.ScreenEntry(entry)
+			for i := range hits {
+				hits[i].BatchNumber = bh.BatchNumber
+			}
+			out = append(out, hits...)
+		}
+	}
+	for _, batch := range file.IATBatches {
+		bh := batch.GetHeader()
+		for _, entry := range batch.GetEntries() {
+			hits := s.ScreenIATEntry(entry)
+			for i := range hits {
+				hits[i].BatchNumber = bh.BatchNumber
+			}
+			out = append(out, hits...)
+		}
+	}
+	return out
+}
+
+// ScreenEntry returns the hits for the IndividualName of entry.
+func (s *Screener) ScreenEntry(entry *ach.EntryDetail) []Hit {
+	if entry == nil {
+		return nil
+	}
+	return s.screen(entry.TraceNumber, PartyReceiver, entry.IndividualName)
+}
+
+// ScreenIATEntry returns the hits for the parties named in the addenda records of entry. When
+// Options.Indicator is set the matching screening indicator of entry is updated.
+func (s *Screener) ScreenIATEntry(entry *ach.IATEntryDetail) []Hit {
+	if entry == nil {
+		return nil
+	}
+
+	var out []Hit
+	if entry.Addenda10 != nil {
+		out = append(out, s.screen(entry.TraceNumber, PartyReceiver, entry.Addenda10.Name)...)
+	}
+	if entry.Addenda11 != nil {
+		out = append(out, s.screen(entry.TraceNumber, PartyOriginator, entry.Addenda11.OriginatorName)...)
+	}
+	if entry.Addenda13 != nil {
+		out = append(out, s.screen(entry.TraceNumber, PartyODFI, entry.Addenda13.ODFIName)...)
+	}
+	if entry.Addenda14 != nil {
+		out = append(out, s.screen(entry.TraceNumber, PartyRDFI, entry.Addenda14.RDFIName)...)
+	}
+	for _, addenda18 := range entry.Addenda18 {
+		if addenda18 != nil {
+			out = append(out, s.screen(entry.TraceNumber, PartyForeignCorrespondentBank, addenda18.ForeignCorrespondentBankName)...)
+		}
+	}
+
+	indicator := "0"
+	if len(out) > 0 {
+		indicator = "1"
+	}
+	switch s.opts.Indicator {
+	case IndicatorGatewayOperator:
+		entry.OFACScreeningIndicator = indicator
+	case IndicatorSecondary:
+		entry.SecondaryOFACScreeningIndicator = indicator
+	}
+	return out
+}
+
+func (s *Screener) screen(traceNumber, party, name string) []Hit {
+	name = strings.TrimSpace(name)
+
+	var out []Hit
+	for _, match := range s.Search(name) {
+		out = append(out, Hit{
+			TraceNumber: traceNumber,
+			Party:       party,
+			Name:        name,
+			Match:       match,
+		})
+	}
+	return out
+}
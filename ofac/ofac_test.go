@@ -0,0 +1,137 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ofac
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readACHFile(t *testing.T, name string) *ach.File {
+	t.Helper()
+
+	file, err := ach.ReadFile(filepath.Join("..", "test", "testdata", name))
+	require.NoError(t, err)
+	return file
+}
+
+func testScreener(t *testing.T, opts Options) *Screener {
+	t.Helper()
+
+	list, err := ReadFiles(testdata("sdn.csv", "alt.csv")...)
+	require.NoError(t, err)
+	return NewScreener(list, opts)
+}
+
+func TestJaroWinkler(t *testing.T) {
+	require.InDelta(t, 0.961, JaroWinkler("MARTHA", "MARHTA"), 0.001)
+	require.InDelta(t, 0.840, JaroWinkler("DWAYNE", "DUANE"), 0.001)
+	require.InDelta(t, 0.813, JaroWinkler("DIXON", "DICKSONX"), 0.001)
+	require.Equal(t, 1.0, JaroWinkler("", ""))
+	require.Equal(t, 0.0, JaroWinkler("ABC", ""))
+	require.Equal(t, 0.0, JaroWinkler("ABC", "XYZ"))
+}
+
+func TestNormalize(t *testing.T) {
+	require.Equal(t, "ANGLO CARIBBEAN", Normalize("Anglo-Caribbean Co., Ltd."))
+	require.Equal(t, "JOSE MUNOZ", Normalize("  José  Muñoz "))
+	require.Equal(t, "THE COMPANY", Normalize("The Company")) // every word is ignored
+	require.Equal(t, "", Normalize(" - "))
+}
+
+func TestScreener__Search(t *testing.T) {
+	s := testScreener(t, Options{})
+
+	matches := s.Search("Nayif Hawatma")
+	require.Len(t, matches, 1)
+	require.Equal(t, "2674", matches[0].Entity.ID)
+	require.Equal(t, 1.0, matches[0].Score)
+
+	// alternate names and misspellings
+	matches = s.Search("National Bank of Cuba")
+	require.Len(t, matches, 1)
+	require.Equal(t, "NATIONAL BANK OF CUBA", matches[0].MatchedName)
+
+	matches = s.Search("Aerocaribean Airline")
+	require.Len(t, matches, 1)
+	require.Equal(t, "36", matches[0].Entity.ID)
+	require.Less(t, matches[0].Score, 1.0)
+
+	require.Empty(t, s.Search("Receiver Account Name"))
+	require.Empty(t, s.Search(""))
+
+	// a lower MinScore reports less similar names, ordered by score
+	matches = testScreener(t, Options{MinScore: 0.5}).Search("Anglo Caribbean Airlines")
+	require.Greater(t, len(matches), 1)
+	require.Equal(t, "173", matches[0].Entity.ID)
+	require.GreaterOrEqual(t, matches[0].Score, matches[1].Score)
+}
+
+func TestScreener__ScreenFile(t *testing.T) {
+	s := testScreener(t, Options{})
+
+	file := readACHFile(t, "ppd-debit.ach")
+	require.Empty(t, s.ScreenFile(file))
+
+	file.Batches[0].GetEntries()[0].IndividualName = "Hawatma Nayif"
+	hits := s.ScreenFile(file)
+	require.Len(t, hits, 1)
+	require.Equal(t, 1, hits[0].BatchNumber)
+	require.Equal(t, "121042880000001", hits[0].TraceNumber)
+	require.Equal(t, PartyReceiver, hits[0].Party)
+	require.Equal(t, "Hawatma Nayif", hits[0].Name)
+	require.Equal(t, "2674", hits[0].Entity.ID)
+
+	require.Empty(t, s.ScreenFile(nil))
+}
+
+func TestScreener__ScreenIATEntry(t *testing.T) {
+	file := readACHFile(t, "iat-debit.ach")
+	entry := file.IATBatches[0].Entries[0]
+
+	s := testScreener(t, Options{Indicator: IndicatorSecondary})
+	require.Empty(t, s.ScreenIATEntry(entry))
+	require.Equal(t, "0", entry.SecondaryOFACScreeningIndicator)
+
+	entry.Addenda11.OriginatorName = "Anglo Caribbean Company"
+	entry.Addenda18[0].ForeignCorrespondentBankName = "Banco Nacional de Cuba"
+	hits := s.ScreenFile(file)
+	require.Len(t, hits, 2)
+	require.Equal(t, PartyOriginator, hits[0].Party)
+	require.Equal(t, "173", hits[0].Entity.ID)
+	require.Equal(t, PartyForeignCorrespondentBank, hits[1].Party)
+	require.Equal(t, "306", hits[1].Entity.ID)
+	require.Equal(t, " ", entry.OFACScreeningIndicatorField())
+	require.Equal(t, "1", entry.SecondaryOFACScreeningIndicator)
+
+	// the indicator is kept when the file is written and read again
+	var buf bytes.Buffer
+	require.NoError(t, ach.NewWriter(&buf).Write(file))
+	read, err := ach.NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.Equal(t, "1", read.IATBatches[0].Entries[0].SecondaryOFACScreeningIndicator)
+
+	s = testScreener(t, Options{Indicator: IndicatorGatewayOperator})
+	s.ScreenIATEntry(entry)
+	require.Equal(t, "1", entry.OFACScreeningIndicator)
+}
@@ -73,6 +73,9 @@ type Reader struct {
 
 	// skipBatchAccumulation is a flag to skip .AddBatch
 	skipBatchAccumulation bool
+
+	// parsedRecords is set once the first ACH record is parsed, ending the file's preamble
+	parsedRecords bool
 }
 
 // error returns a new ParseError based on err
@@ -335,6 +338,15 @@ func blankLine(line string) bool {
 }
 
 func (r *Reader) readLine(line string) error {
+	if r.preambleLine(line) {
+		r.File.Preamble = append(r.File.Preamble, line)
+		return nil
+	}
+	if r.trailerLine(line) {
+		r.File.Trailer = append(r.File.Trailer, line)
+		return nil
+	}
+
 	lineLength := utf8.RuneCountInString(line)
 	switch {
 	case r.lineNum == 1 && lineLength > RecordLength:
@@ -375,6 +387,36 @@ func (r *Reader) readLine(line string) error {
 	return nil
 }
 
+// preambleLine returns true for bank-specific lines before the first ACH record when they're allowed
+func (r *Reader) preambleLine(line string) bool {
+	opts := r.File.validateOpts
+	if opts == nil || !opts.AllowPreambleLines || r.parsedRecords {
+		return false
+	}
+	if strings.HasPrefix(line, fileHeaderPos) {
+		return false
+	}
+	return !opts.AllowMissingFileHeader || !strings.HasPrefix(line, batchHeaderPos)
+}
+
+// trailerLine returns true for bank-specific lines after the FileControl when they're allowed
+func (r *Reader) trailerLine(line string) bool {
+	opts := r.File.validateOpts
+	if opts == nil || !opts.AllowTrailerLines {
+		return false
+	}
+	if r.File.Control.LineNumber == 0 && r.File.ADVControl.LineNumber == 0 {
+		return false
+	}
+	return !blockPadding(line)
+}
+
+// blockPadding returns true for block padding lines, which are made entirely of '9'
+func blockPadding(line string) bool {
+	line = strings.TrimRight(line, " \r")
+	return line != "" && strings.Trim(line, "9") == ""
+}
+
 func trimSpacesFromLongLine(s string) string {
 	return strings.TrimSuffix(s[:lineLength], " ")
 }
@@ -411,6 +453,7 @@ func (r *Reader) parseLine() error {
 	if r.File.Control.LineNumber > 0 {
 		return r.parseError(ErrExtraRecordsAfterFileControl)
 	}
+	r.parsedRecords = true
 
 	// Parse the line
 	switch r.line[:1] {
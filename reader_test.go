@@ -2394,3 +2394,72 @@ func TestReadRunes(t *testing.T) {
 		})
 	}
 }
+
+func TestReader__PreambleAndTrailer(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	preamble := []string{"$$ADD ID=USER1 BID='NWFACH1234'", "0" + strings.Repeat("BANK HEADER ", 7) + "BANK HEAD"}
+	trailer := []string{"$$END"}
+	input := strings.Join(preamble, "\n") + "\n" + string(bs) + "\n" + strings.Join(trailer, "\n") + "\n"
+
+	// Bank-specific lines are rejected by default
+	_, err = NewReader(strings.NewReader(input)).Read()
+	require.Error(t, err)
+
+	r := NewReader(strings.NewReader(input))
+	r.SetValidation(&ValidateOpts{AllowPreambleLines: true, AllowTrailerLines: true})
+	file, err := r.Read()
+	require.NoError(t, err)
+	require.NoError(t, file.Validate())
+	require.Equal(t, preamble, file.Preamble)
+	require.Equal(t, trailer, file.Trailer)
+	require.Equal(t, 3, file.Header.LineNumber)
+
+	// The lines are written back out around the file's records and block padding
+	var buf, records bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(&file))
+
+	withoutLines := file
+	withoutLines.Preamble, withoutLines.Trailer = nil, nil
+	require.NoError(t, NewWriter(&records).Write(&withoutLines))
+	require.Equal(t, strings.Join(preamble, "\n")+"\n"+records.String()+"$$END\n", buf.String())
+
+	// And kept in JSON
+	out, err := json.Marshal(&file)
+	require.NoError(t, err)
+	decoded, err := FileFromJSON(out)
+	require.NoError(t, err)
+	require.Equal(t, preamble, decoded.Preamble)
+	require.Equal(t, trailer, decoded.Trailer)
+}
+
+func TestReader__TrailerStartingWith99(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	// Only lines made entirely of '9' are block padding
+	trailer := []string{"99 BANK TRAILER 0000001"}
+	input := string(bs) + "\n" + strings.Repeat("9", RecordLength) + "\n" + strings.Join(trailer, "\n") + "\n"
+
+	r := NewReader(strings.NewReader(input))
+	r.SetValidation(&ValidateOpts{AllowTrailerLines: true})
+	file, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, trailer, file.Trailer)
+}
+
+func TestReader__PreambleMissingFileHeader(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	lines := strings.Split(string(bs), "\n")
+
+	// Without a FileHeader the preamble ends at the first BatchHeader
+	input := "$$ADD ID=USER1\n" + strings.Join(lines[1:], "\n")
+	r := NewReader(strings.NewReader(input))
+	r.SetValidation(&ValidateOpts{AllowPreambleLines: true, AllowMissingFileHeader: true})
+	file, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, []string{"$$ADD ID=USER1"}, file.Preamble)
+	require.Len(t, file.Batches, 1)
+}
@@ -18,8 +18,20 @@
 package ach
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/moov-io/base"
+)
+
+var (
+	// ErrReversalWindow is returned when a reversal would settle more than five banking days after the original entries.
+	ErrReversalWindow = errors.New("reversal is outside of the five banking day window")
+	// ErrTraceNumberNotFound is returned when an entry to reverse isn't in the File.
+	ErrTraceNumberNotFound = errors.New("trace number not found")
 )
 
 // Reversal will transform a File into a Nacha compliant reversal which can be transmitted to undo fund movement.
@@ -47,38 +59,12 @@ func (f *File) Reversal(effectiveEntryDate time.Time) error {
 		// Adjust Effective Entry Date for same-day vs standard
 		bh.EffectiveEntryDate = effectiveEntryDate.Format("060102")
 
-		hasCredits, hasDebits := false, false
-
 		// In EntryDetail records we need to update the TransactionCode fields to undo fund movement.
+		var codes []int
 		entries := f.Batches[i].GetEntries()
 		for j := range entries {
-			switch entries[j].TransactionCode {
-			case
-				CheckingCredit, CheckingReturnNOCCredit, CheckingPrenoteCredit, CheckingZeroDollarRemittanceCredit,
-				GLCredit, GLPrenoteCredit, GLReturnNOCCredit, GLZeroDollarRemittanceCredit,
-				LoanPrenoteCredit, LoanReturnNOCCredit, LoanZeroDollarRemittanceCredit,
-				SavingsCredit, SavingsPrenoteCredit, SavingsReturnNOCCredit, SavingsZeroDollarRemittanceCredit:
-				// Credit -> Debit
-				hasDebits = true
-				entries[j].TransactionCode += 5
-
-			case LoanCredit:
-				hasDebits = true
-				entries[j].TransactionCode += 3
-
-			case
-				CheckingDebit, CheckingPrenoteDebit, CheckingReturnNOCDebit, CheckingZeroDollarRemittanceDebit,
-				GLDebit, GLPrenoteDebit, GLReturnNOCDebit, GLZeroDollarRemittanceDebit,
-				LoanReturnNOCDebit,
-				SavingsDebit, SavingsPrenoteDebit, SavingsReturnNOCDebit, SavingsZeroDollarRemittanceDebit:
-				// Debit -> Credit
-				hasCredits = true
-				entries[j].TransactionCode -= 5
-
-			case LoanDebit:
-				hasDebits = true
-				entries[j].TransactionCode -= 3
-			}
+			entries[j].TransactionCode = reverseTransactionCode(entries[j].TransactionCode)
+			codes = append(codes, entries[j].TransactionCode)
 		}
 
 		// Re-calculate control record
@@ -89,17 +75,9 @@ func (f *File) Reversal(effectiveEntryDate time.Time) error {
 		bc.TotalCreditEntryDollarAmount = prevDebits
 
 		// Fixup ServiceClassCode
-		if hasCredits {
-			bh.ServiceClassCode = CreditsOnly
-			bc.ServiceClassCode = CreditsOnly
-		}
-		if hasDebits {
-			bh.ServiceClassCode = DebitsOnly
-			bc.ServiceClassCode = DebitsOnly
-		}
-		if hasCredits && hasDebits {
-			bh.ServiceClassCode = MixedDebitsAndCredits
-			bc.ServiceClassCode = MixedDebitsAndCredits
+		if scc := reversalServiceClassCode(codes); scc > 0 {
+			bh.ServiceClassCode = scc
+			bc.ServiceClassCode = scc
 		}
 
 		// Update header and control
@@ -112,5 +90,284 @@ func (f *File) Reversal(effectiveEntryDate time.Time) error {
 			}
 		}
 	}
+
+	for i := range f.IATBatches {
+		bh := f.IATBatches[i].GetHeader()
+		bh.CompanyEntryDescription = "REVERSAL"
+		bh.EffectiveEntryDate = effectiveEntryDate.Format("060102")
+
+		var codes []int
+		for _, entry := range f.IATBatches[i].GetEntries() {
+			entry.TransactionCode = reverseTransactionCode(entry.TransactionCode)
+			codes = append(codes, entry.TransactionCode)
+		}
+		if scc := reversalServiceClassCode(codes); scc > 0 {
+			bh.ServiceClassCode = scc
+		}
+		if err := f.IATBatches[i].build(); err != nil {
+			return fmt.Errorf("rebuilding IAT batch index %d failed: %v", i, err)
+		}
+	}
 	return f.Create()
 }
+
+// ReverseEntries returns a new File which reverses only the entries with the given trace numbers.
+//
+// Each batch with a chosen entry is copied with its Company Entry Description set to "REVERSAL" and
+// the effectiveEntryDate, which must be within five banking days of the original Effective Entry Date.
+// Reversed entries are given new trace numbers, numbered across every batch of the reversal. Batches with
+// an Offset, or with offset entries when read from a file, have their offset entries rebuilt.
+// Offset entries can't be chosen on their own. The File is not modified.
+func (f *File) ReverseEntries(traceNumbers []string, effectiveEntryDate time.Time) (*File, error) {
+	if len(traceNumbers) == 0 {
+		return nil, errors.New("no trace numbers to reverse")
+	}
+	wanted := make(map[string]bool, len(traceNumbers))
+	for _, tn := range traceNumbers {
+		wanted[strings.TrimSpace(tn)] = false
+	}
+
+	out := NewFile()
+	out.SetValidation(f.validateOpts)
+	out.Header = f.Header
+	out.Header.FileCreationDate = effectiveEntryDate.Format("060102")
+	out.Header.FileCreationTime = effectiveEntryDate.Format("1504")
+	out.Preamble, out.Trailer = f.Preamble, f.Trailer
+
+	// traceNumber is the sequence of the last trace number given in the reversal
+	traceNumber := 0
+
+	for _, batch := range f.Batches {
+		bh := batch.GetHeader()
+
+		var entries []*EntryDetail
+		for _, entry := range batch.GetEntries() {
+			if _, exists := wanted[entry.TraceNumber]; !exists {
+				continue
+			}
+			wanted[entry.TraceNumber] = true
+			if strings.EqualFold(strings.TrimSpace(entry.IndividualName), offsetIndividualName) {
+				continue // rebuilt below
+			}
+			entries = append(entries, entry)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		if err := checkReversalWindow(bh.EffectiveEntryDate, effectiveEntryDate); err != nil {
+			return nil, fmt.Errorf("batch %d: %w", bh.BatchNumber, err)
+		}
+
+		header := *bh
+		header.CompanyEntryDescription = "REVERSAL"
+		header.EffectiveEntryDate = effectiveEntryDate.Format("060102")
+
+		var codes []int
+		reversed := make([]*EntryDetail, len(entries))
+		for i := range entries {
+			entry, err := copyRecord(entries[i])
+			if err != nil {
+				return nil, err
+			}
+			entry.SetValidation(f.validateOpts)
+			entry.TransactionCode = reverseTransactionCode(entry.TransactionCode)
+			traceNumber++
+			entry.SetTraceNumber(header.ODFIIdentification, traceNumber)
+			codes = append(codes, entry.TransactionCode)
+			reversed[i] = entry
+		}
+
+		var offset *Offset
+		if b, ok := batch.(interface{ getOffset() *Offset }); ok {
+			offset = b.getOffset()
+		}
+		if offset == nil {
+			offset = offsetFromEntries(batch.GetEntries())
+		}
+		if scc := reversalServiceClassCode(codes); scc > 0 && offset == nil {
+			header.ServiceClassCode = scc
+		}
+
+		nb, err := NewBatch(&header)
+		if err != nil {
+			return nil, err
+		}
+		nb.SetValidation(f.validateOpts)
+		for _, entry := range reversed {
+			nb.AddEntry(entry)
+		}
+		if offset != nil {
+			nb.WithOffset(offset)
+		}
+		if err := nb.Create(); err != nil {
+			return nil, fmt.Errorf("building reversal of batch %d: %w", bh.BatchNumber, err)
+		}
+		// Offset entries follow the reversed entries
+		traceNumber += len(nb.GetEntries()) - len(reversed)
+		out.AddBatch(nb)
+	}
+
+	for _, batch := range f.IATBatches {
+		bh := batch.GetHeader()
+
+		var entries []*IATEntryDetail
+		for _, entry := range batch.GetEntries() {
+			if _, exists := wanted[entry.TraceNumber]; exists {
+				wanted[entry.TraceNumber] = true
+				entries = append(entries, entry)
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		if err := checkReversalWindow(bh.EffectiveEntryDate, effectiveEntryDate); err != nil {
+			return nil, fmt.Errorf("IAT batch %d: %w", bh.BatchNumber, err)
+		}
+
+		header := *bh
+		header.CompanyEntryDescription = "REVERSAL"
+		header.EffectiveEntryDate = effectiveEntryDate.Format("060102")
+
+		nb := NewIATBatch(&header)
+		nb.SetValidation(f.validateOpts)
+
+		var codes []int
+		for i := range entries {
+			entry, err := copyRecord(entries[i])
+			if err != nil {
+				return nil, err
+			}
+			entry.SetValidation(f.validateOpts)
+			entry.TransactionCode = reverseTransactionCode(entry.TransactionCode)
+			traceNumber++
+			entry.SetTraceNumber(header.ODFIIdentification, traceNumber)
+			codes = append(codes, entry.TransactionCode)
+			nb.AddEntry(entry)
+		}
+		if scc := reversalServiceClassCode(codes); scc > 0 {
+			header.ServiceClassCode = scc
+		}
+		if err := nb.Create(); err != nil {
+			return nil, fmt.Errorf("building reversal of IAT batch %d: %w", bh.BatchNumber, err)
+		}
+		out.AddIATBatch(nb)
+	}
+
+	for tn, found := range wanted {
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrTraceNumberNotFound, tn)
+		}
+	}
+	if len(out.Batches) == 0 && len(out.IATBatches) == 0 {
+		return nil, errors.New("no entries to reverse")
+	}
+	if err := out.Create(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// offsetFromEntries returns the Offset of a batch read from a file, which only has its offset entries,
+// or nil when the batch has no offset entries.
+func offsetFromEntries(entries []*EntryDetail) *Offset {
+	for _, entry := range entries {
+		if !strings.EqualFold(strings.TrimSpace(entry.IndividualName), offsetIndividualName) {
+			continue
+		}
+		offset := &Offset{
+			RoutingNumber: entry.RDFIIdentification + entry.CheckDigit,
+			AccountNumber: strings.TrimSpace(entry.DFIAccountNumber),
+			Description:   strings.TrimSpace(entry.DiscretionaryData),
+		}
+		switch entry.TransactionCode {
+		case CheckingCredit, CheckingDebit:
+			offset.AccountType = OffsetChecking
+		case SavingsCredit, SavingsDebit:
+			offset.AccountType = OffsetSavings
+		default:
+			continue
+		}
+		return offset
+	}
+	return nil
+}
+
+// reverseTransactionCode returns the TransactionCode which undoes the fund movement of code
+func reverseTransactionCode(code int) int {
+	switch code {
+	case
+		CheckingCredit, CheckingReturnNOCCredit, CheckingPrenoteCredit, CheckingZeroDollarRemittanceCredit,
+		GLCredit, GLPrenoteCredit, GLReturnNOCCredit, GLZeroDollarRemittanceCredit,
+		LoanPrenoteCredit, LoanReturnNOCCredit, LoanZeroDollarRemittanceCredit,
+		SavingsCredit, SavingsPrenoteCredit, SavingsReturnNOCCredit, SavingsZeroDollarRemittanceCredit:
+		// Credit -> Debit
+		return code + 5
+
+	case LoanCredit:
+		return code + 3
+
+	case
+		CheckingDebit, CheckingPrenoteDebit, CheckingReturnNOCDebit, CheckingZeroDollarRemittanceDebit,
+		GLDebit, GLPrenoteDebit, GLReturnNOCDebit, GLZeroDollarRemittanceDebit,
+		LoanReturnNOCDebit,
+		SavingsDebit, SavingsPrenoteDebit, SavingsReturnNOCDebit, SavingsZeroDollarRemittanceDebit:
+		// Debit -> Credit
+		return code - 5
+
+	case LoanDebit:
+		return code - 3
+	}
+	return code
+}
+
+// reversalServiceClassCode returns the ServiceClassCode for a batch of reversed TransactionCodes,
+// or zero when none are credits or debits.
+func reversalServiceClassCode(codes []int) int {
+	hasCredits, hasDebits := false, false
+	for _, code := range codes {
+		ed := EntryDetail{TransactionCode: code}
+		switch ed.CreditOrDebit() {
+		case "C":
+			hasCredits = true
+		case "D":
+			hasDebits = true
+		}
+	}
+	switch {
+	case hasCredits && hasDebits:
+		return MixedDebitsAndCredits
+	case hasCredits:
+		return CreditsOnly
+	case hasDebits:
+		return DebitsOnly
+	}
+	return 0
+}
+
+// checkReversalWindow verifies a reversal settles within five banking days of the original YYMMDD effective entry date.
+// The original date is used as the settlement date of the erroneous entries.
+func checkReversalWindow(original string, effectiveEntryDate time.Time) error {
+	originalDate, err := time.Parse("060102", original)
+	if err != nil {
+		return fmt.Errorf("parsing original effective entry date %q: %w", original, err)
+	}
+	deadline := base.NewTime(originalDate).AddBankingDay(5)
+	if y, m, d := effectiveEntryDate.Date(); time.Date(y, m, d, 0, 0, 0, 0, time.UTC).After(deadline.Time) {
+		return fmt.Errorf("%w: %s is after %s for entries effective %s", ErrReversalWindow,
+			effectiveEntryDate.Format("2006-01-02"), deadline.Format("2006-01-02"), originalDate.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// copyRecord returns a deep copy of an entry and its addenda records
+func copyRecord[T any](in *T) (*T, error) {
+	bs, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("copying %T: %w", in, err)
+	}
+	out := new(T)
+	if err := json.Unmarshal(bs, out); err != nil {
+		return nil, fmt.Errorf("copying %T: %w", in, err)
+	}
+	return out, nil
+}
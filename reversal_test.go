@@ -18,6 +18,7 @@
 package ach
 
 import (
+	"bytes"
 	"path/filepath"
 	"testing"
 	"time"
@@ -124,3 +125,159 @@ func TestReversal_LoanCredit(t *testing.T) {
 	require.Len(t, entries, 1)
 	require.Equal(t, LoanCredit, entries[0].TransactionCode)
 }
+
+func TestReversal_IAT(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "20180713-IAT.ach"))
+	require.NoError(t, err)
+	require.Len(t, file.IATBatches, 2)
+	require.Equal(t, CheckingCredit, file.IATBatches[0].Entries[0].TransactionCode)
+	require.Equal(t, CheckingDebit, file.IATBatches[1].Entries[0].TransactionCode)
+
+	err = file.Reversal(time.Now().In(time.UTC))
+	require.NoError(t, err)
+
+	bh := file.IATBatches[0].GetHeader()
+	require.Equal(t, "REVERSAL", bh.CompanyEntryDescription)
+	require.Equal(t, DebitsOnly, bh.ServiceClassCode)
+	require.Equal(t, CheckingDebit, file.IATBatches[0].Entries[0].TransactionCode)
+	require.Equal(t, file.IATBatches[0].Entries[0].Amount, file.IATBatches[0].Control.TotalDebitEntryDollarAmount)
+	require.Equal(t, CreditsOnly, file.IATBatches[1].GetHeader().ServiceClassCode)
+	require.Equal(t, CheckingCredit, file.IATBatches[1].Entries[0].TransactionCode)
+	require.NoError(t, file.Validate())
+}
+
+func TestFile_ReverseEntries(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	original := file.Batches[0].GetEntries()
+	require.Len(t, original, 3)
+	traceNumber, code := original[1].TraceNumber, original[1].TransactionCode
+
+	effectiveEntryDate := time.Date(2019, time.July, 22, 0, 0, 0, 0, time.UTC) // 190719 + 1 banking day
+	reversal, err := file.ReverseEntries([]string{traceNumber}, effectiveEntryDate)
+	require.NoError(t, err)
+	require.NoError(t, reversal.Validate())
+
+	require.Len(t, reversal.Batches, 1)
+	bh := reversal.Batches[0].GetHeader()
+	require.Equal(t, "REVERSAL", bh.CompanyEntryDescription)
+	require.Equal(t, "190722", bh.EffectiveEntryDate)
+
+	entries := reversal.Batches[0].GetEntries()
+	require.Len(t, entries, 1)
+	require.Equal(t, reverseTransactionCode(code), entries[0].TransactionCode)
+	require.Equal(t, original[1].Amount, entries[0].Amount)
+	require.Equal(t, "121042880000001", entries[0].TraceNumber)
+
+	// The original file is unchanged
+	require.Equal(t, traceNumber, original[1].TraceNumber)
+	require.Equal(t, code, original[1].TransactionCode)
+	require.Equal(t, "REG.SALARY", file.Batches[0].GetHeader().CompanyEntryDescription)
+
+	t.Run("unknown trace number", func(t *testing.T) {
+		_, err := file.ReverseEntries([]string{traceNumber, "999999999999999"}, effectiveEntryDate)
+		require.ErrorIs(t, err, ErrTraceNumberNotFound)
+		require.ErrorContains(t, err, "999999999999999")
+	})
+
+	t.Run("outside window", func(t *testing.T) {
+		// Five banking days after Friday July 19th is Friday July 26th
+		_, err := file.ReverseEntries([]string{traceNumber}, time.Date(2019, time.July, 26, 12, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		_, err = file.ReverseEntries([]string{traceNumber}, time.Date(2019, time.July, 29, 0, 0, 0, 0, time.UTC))
+		require.ErrorIs(t, err, ErrReversalWindow)
+	})
+}
+
+func TestFile_ReverseEntriesIAT(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "iat-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	entry := file.IATBatches[0].Entries[0]
+	effectiveEntryDate, err := time.Parse("060102", file.IATBatches[0].Header.EffectiveEntryDate)
+	require.NoError(t, err)
+
+	reversal, err := file.ReverseEntries([]string{entry.TraceNumber}, effectiveEntryDate)
+	require.NoError(t, err)
+	require.NoError(t, reversal.Validate())
+
+	require.Len(t, reversal.IATBatches, 1)
+	require.Equal(t, "REVERSAL", reversal.IATBatches[0].Header.CompanyEntryDescription)
+	require.Len(t, reversal.IATBatches[0].Entries, 1)
+	require.Equal(t, reverseTransactionCode(entry.TransactionCode), reversal.IATBatches[0].Entries[0].TransactionCode)
+	require.Equal(t, entry.Addenda10.Name, reversal.IATBatches[0].Entries[0].Addenda10.Name)
+}
+
+func TestFile_ReverseEntriesOffset(t *testing.T) {
+	file := mockFilePPD(t)
+	b := file.Batches[0].(*BatchPPD)
+	b.Header.ServiceClassCode = MixedDebitsAndCredits
+	b.Entries[0].TransactionCode = CheckingDebit
+	b.WithOffset(&Offset{
+		RoutingNumber: "121042882",
+		AccountNumber: "123456789",
+		AccountType:   OffsetChecking,
+		Description:   "test offset",
+	})
+	require.NoError(t, b.Create())
+	require.NoError(t, file.Create())
+	require.Len(t, b.Entries, 2)
+
+	effectiveEntryDate, err := time.Parse("060102", b.Header.EffectiveEntryDate)
+	require.NoError(t, err)
+
+	// Choosing the offset entry with its debit rebuilds a balanced offset
+	reversal, err := file.ReverseEntries([]string{b.Entries[0].TraceNumber, b.Entries[1].TraceNumber}, effectiveEntryDate)
+	require.NoError(t, err)
+
+	entries := reversal.Batches[0].GetEntries()
+	require.Len(t, entries, 2)
+	require.Equal(t, CheckingCredit, entries[0].TransactionCode)
+	require.Equal(t, "OFFSET", entries[1].IndividualName)
+	require.Equal(t, CheckingDebit, entries[1].TransactionCode)
+	require.Equal(t, entries[0].Amount, entries[1].Amount)
+
+	// Offsets can't be reversed alone
+	_, err = file.ReverseEntries([]string{b.Entries[1].TraceNumber}, effectiveEntryDate)
+	require.ErrorContains(t, err, "no entries to reverse")
+
+	t.Run("read from a file", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewWriter(&buf).Write(file))
+		parsed, err := NewReader(&buf).Read()
+		require.NoError(t, err)
+
+		reversal, err := parsed.ReverseEntries([]string{b.Entries[0].TraceNumber}, effectiveEntryDate)
+		require.NoError(t, err)
+		require.NoError(t, reversal.Validate())
+
+		entries := reversal.Batches[0].GetEntries()
+		require.Len(t, entries, 2)
+		require.Equal(t, CheckingCredit, entries[0].TransactionCode)
+		require.Equal(t, "OFFSET", entries[1].IndividualName)
+		require.Equal(t, CheckingDebit, entries[1].TransactionCode)
+		require.Equal(t, "121042882", entries[1].RDFIIdentification+entries[1].CheckDigit)
+		require.Equal(t, entries[0].Amount, entries[1].Amount)
+	})
+}
+
+func TestFile_ReverseEntriesBatches(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "two-micro-deposits.ach"))
+	require.NoError(t, err)
+	require.Len(t, file.Batches, 2)
+
+	first := file.Batches[0].GetEntries()[0]
+	second := file.Batches[1].GetEntries()[0]
+
+	effectiveEntryDate := time.Date(2020, time.March, 26, 0, 0, 0, 0, time.UTC)
+	reversal, err := file.ReverseEntries([]string{first.TraceNumber, second.TraceNumber}, effectiveEntryDate)
+	require.NoError(t, err)
+	require.NoError(t, reversal.Validate())
+
+	// Trace numbers are unique across every batch
+	require.Len(t, reversal.Batches, 2)
+	require.Equal(t, "121042880000001", reversal.Batches[0].GetEntries()[0].TraceNumber)
+	require.Equal(t, "121042880000002", reversal.Batches[1].GetEntries()[0].TraceNumber)
+}
@@ -0,0 +1,223 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moov-io/base"
+)
+
+// SameDayEntryLimit is the largest Amount (in cents) an individual entry can have and remain
+// eligible for same-day settlement. Entries over $1,000,000.00 must settle on a future banking day.
+const SameDayEntryLimit = 100000000
+
+// sameDayIndicatorPrefix is the "SD" prefix of the SDHHMM convention for CompanyDescriptiveDate
+const sameDayIndicatorPrefix = "SD"
+
+// SplitSameDayOptions controls how File.SplitSameDay partitions entries.
+type SplitSameDayOptions struct {
+	// Now is the time the split is evaluated at. When zero time.Now() in the cutoff's Location is used.
+	Now time.Time `json:"now"`
+
+	// SameDayIndicator is written into each same-day BatchHeader's CompanyDescriptiveDate.
+	// When empty the Nacha "SDHHMM" convention is used with the cutoff's hour and minute (e.g. SD1300).
+	SameDayIndicator string `json:"sameDayIndicator"`
+
+	// NextDayEffectiveEntryDate overrides the EffectiveEntryDate of next-day batches.
+	// When zero the next banking day after Now is used.
+	NextDayEffectiveEntryDate time.Time `json:"nextDayEffectiveEntryDate"`
+
+	// MaxSameDayAmount overrides SameDayEntryLimit as the largest Amount eligible for same-day settlement.
+	MaxSameDayAmount int `json:"maxSameDayAmount"`
+
+	// SingleFile will return every same-day and next-day batch in one File (the first return value)
+	// rather than as two separate Files.
+	SingleFile bool `json:"singleFile"`
+}
+
+var (
+	// ErrSplitSameDayADV is returned when SplitSameDay is called on a File with ADV batches
+	ErrSplitSameDayADV = errors.New("ADV files cannot be split for same-day settlement")
+)
+
+// SplitSameDay partitions the entries of a File into same-day and next-day batches. The return is
+// 2 Files, a same-day File and next-day File, or an error. Either File is nil when it would contain no batches.
+//
+// An entry remains eligible for same-day settlement unless:
+//   - its Amount exceeds SameDayEntryLimit (or opts.MaxSameDayAmount)
+//   - it is an IAT entry or part of an ENR batch
+//   - the split happens after cutoff or on a non-banking day
+//
+// Same-day batches have their EffectiveEntryDate set to the current banking day and CompanyDescriptiveDate
+// set to the same-day indicator (e.g. SD1300). Next-day batches are dated for the next banking day and have
+// any same-day indicator removed. Batch controls and the file controls are recalculated.
+//
+// Callers should always check for a nil-error before using the returned files. The Files returned may not
+// be valid and callers should confirm with Validate.
+func (f *File) SplitSameDay(cutoff time.Time, opts *SplitSameDayOptions) (*File, *File, error) {
+	if opts == nil {
+		opts = &SplitSameDayOptions{}
+	}
+	if f.IsADV() {
+		return nil, nil, ErrSplitSameDayADV
+	}
+	if err := f.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now().In(cutoff.Location())
+	}
+	today := base.NewTime(now)
+	windowOpen := today.IsBankingDay() && !now.After(cutoff)
+
+	nextDay := opts.NextDayEffectiveEntryDate
+	if nextDay.IsZero() {
+		nextDay = today.AddBankingDay(1).Time
+	}
+
+	indicator := opts.SameDayIndicator
+	if indicator == "" {
+		indicator = sameDayIndicatorPrefix + cutoff.Format("1504")
+	}
+	limit := opts.MaxSameDayAmount
+	if limit <= 0 {
+		limit = SameDayEntryLimit
+	}
+
+	sameDayFile := NewFile()
+	nextDayFile := NewFile()
+	if opts.SingleFile {
+		nextDayFile = sameDayFile
+	}
+	if f.validateOpts != nil {
+		sameDayFile.SetValidation(f.validateOpts)
+		nextDayFile.SetValidation(f.validateOpts)
+	}
+
+	for _, batch := range f.Batches {
+		bh := batch.GetHeader()
+
+		var sameDayEntries, nextDayEntries []*EntryDetail
+		for _, entry := range batch.GetEntries() {
+			if windowOpen && bh.StandardEntryClassCode != ENR && entry.Amount <= limit {
+				sameDayEntries = append(sameDayEntries, entry)
+			} else {
+				nextDayEntries = append(nextDayEntries, entry)
+			}
+		}
+
+		if len(sameDayEntries) > 0 {
+			sbh := createSegmentFileBatchHeader(bh.ServiceClassCode, bh)
+			sbh.EffectiveEntryDate = now.Format("060102")
+			sbh.CompanyDescriptiveDate = indicator
+			sbh.BatchNumber = nextBatchNumber(sameDayFile)
+
+			b, err := splitSameDayBatch(sbh, sameDayEntries, f.validateOpts)
+			if err != nil {
+				return nil, nil, err
+			}
+			sameDayFile.AddBatch(b)
+		}
+		if len(nextDayEntries) > 0 {
+			nbh := createSegmentFileBatchHeader(bh.ServiceClassCode, bh)
+			nbh.EffectiveEntryDate = nextDay.Format("060102")
+			if IsSameDayIndicator(nbh.CompanyDescriptiveDate) {
+				nbh.CompanyDescriptiveDate = ""
+			}
+			nbh.BatchNumber = nextBatchNumber(nextDayFile)
+
+			b, err := splitSameDayBatch(nbh, nextDayEntries, f.validateOpts)
+			if err != nil {
+				return nil, nil, err
+			}
+			nextDayFile.AddBatch(b)
+		}
+	}
+
+	// IAT entries are not eligible for same-day settlement
+	for _, iatBatch := range f.IATBatches {
+		nbh := *iatBatch.GetHeader()
+		nbh.ID = base.ID()
+		nbh.EffectiveEntryDate = nextDay.Format("060102")
+		nbh.BatchNumber = nextBatchNumber(nextDayFile)
+
+		b := NewIATBatch(&nbh)
+		b.SetValidation(f.validateOpts)
+		for _, entry := range iatBatch.GetEntries() {
+			b.AddEntry(entry)
+		}
+		if err := b.Create(); err != nil {
+			return nil, nil, fmt.Errorf("creating next-day IAT batch %d: %w", nbh.BatchNumber, err)
+		}
+		nextDayFile.AddIATBatch(b)
+	}
+
+	if len(sameDayFile.Batches) == 0 && len(sameDayFile.IATBatches) == 0 {
+		sameDayFile = nil
+	}
+	if opts.SingleFile || (len(nextDayFile.Batches) == 0 && len(nextDayFile.IATBatches) == 0) {
+		nextDayFile = nil
+	}
+
+	for _, file := range []*File{sameDayFile, nextDayFile} {
+		if file == nil {
+			continue
+		}
+		f.addFileHeaderData(file)
+		if err := file.Create(); err != nil {
+			return nil, nil, err
+		}
+		if err := file.Validate(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return sameDayFile, nextDayFile, nil
+}
+
+// splitSameDayBatch creates a batch for the SEC code in bh containing entries.
+func splitSameDayBatch(bh *BatchHeader, entries []*EntryDetail, opts *ValidateOpts) (Batcher, error) {
+	b, err := NewBatch(bh)
+	if err != nil {
+		return nil, err
+	}
+	b.SetValidation(opts)
+	for _, entry := range entries {
+		b.AddEntry(entry)
+	}
+	if err := b.Create(); err != nil {
+		return nil, fmt.Errorf("creating batch %d: %w", bh.BatchNumber, err)
+	}
+	return b, nil
+}
+
+// nextBatchNumber returns the ascending batch number for the next batch added to file.
+func nextBatchNumber(file *File) int {
+	return len(file.Batches) + len(file.IATBatches) + 1
+}
+
+// IsSameDayIndicator returns true if a BatchHeader's CompanyDescriptiveDate follows the SDHHMM
+// same-day convention (e.g. SD1300).
+func IsSameDayIndicator(descriptiveDate string) bool {
+	return strings.HasPrefix(strings.ToUpper(strings.TrimSpace(descriptiveDate)), sameDayIndicatorPrefix)
+}
@@ -0,0 +1,137 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// Wednesday October 14th, 2026
+	splitSameDayNow    = time.Date(2026, time.October, 14, 10, 0, 0, 0, time.UTC)
+	splitSameDayCutoff = time.Date(2026, time.October, 14, 13, 0, 0, 0, time.UTC)
+)
+
+func TestFile__SplitSameDay(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	sameDay, nextDay, err := file.SplitSameDay(splitSameDayCutoff, &SplitSameDayOptions{
+		Now: splitSameDayNow,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, sameDay)
+	require.NotNil(t, nextDay)
+
+	// $1,000,000.00 credits are eligible
+	require.Len(t, sameDay.Batches, 1)
+	bh := sameDay.Batches[0].GetHeader()
+	require.Equal(t, "SD1300", bh.CompanyDescriptiveDate)
+	require.Equal(t, "261014", bh.EffectiveEntryDate)
+	require.Len(t, sameDay.Batches[0].GetEntries(), 2)
+	require.Equal(t, 200000000, sameDay.Control.TotalCreditEntryDollarAmountInFile)
+	require.Equal(t, 0, sameDay.Control.TotalDebitEntryDollarAmountInFile)
+
+	// $2,000,000.00 debit is over the limit
+	require.Len(t, nextDay.Batches, 1)
+	bh = nextDay.Batches[0].GetHeader()
+	require.Equal(t, "261015", bh.EffectiveEntryDate)
+	require.Equal(t, 1, bh.BatchNumber)
+	require.Len(t, nextDay.Batches[0].GetEntries(), 1)
+	require.Equal(t, 200000000, nextDay.Control.TotalDebitEntryDollarAmountInFile)
+}
+
+func TestFile__SplitSameDayAfterCutoff(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	sameDay, nextDay, err := file.SplitSameDay(splitSameDayCutoff, &SplitSameDayOptions{
+		Now: splitSameDayCutoff.Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Nil(t, sameDay)
+	require.NotNil(t, nextDay)
+	require.Len(t, nextDay.Batches[0].GetEntries(), 3)
+	require.Equal(t, "261015", nextDay.Batches[0].GetHeader().EffectiveEntryDate)
+}
+
+func TestFile__SplitSameDayWeekend(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	saturday := time.Date(2026, time.October, 17, 10, 0, 0, 0, time.UTC)
+	sameDay, nextDay, err := file.SplitSameDay(saturday.Add(3*time.Hour), &SplitSameDayOptions{
+		Now: saturday,
+	})
+	require.NoError(t, err)
+	require.Nil(t, sameDay)
+	require.Equal(t, "261019", nextDay.Batches[0].GetHeader().EffectiveEntryDate)
+}
+
+func TestFile__SplitSameDaySingleFile(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	out, nextDay, err := file.SplitSameDay(splitSameDayCutoff, &SplitSameDayOptions{
+		Now:              splitSameDayNow,
+		SameDayIndicator: "SD1030",
+		SingleFile:       true,
+	})
+	require.NoError(t, err)
+	require.Nil(t, nextDay)
+	require.Len(t, out.Batches, 2)
+	require.Equal(t, "SD1030", out.Batches[0].GetHeader().CompanyDescriptiveDate)
+	require.Equal(t, 1, out.Batches[0].GetHeader().BatchNumber)
+	require.Equal(t, "", out.Batches[1].GetHeader().CompanyDescriptiveDate)
+	require.Equal(t, 2, out.Batches[1].GetHeader().BatchNumber)
+	require.NoError(t, out.Validate())
+}
+
+func TestFile__SplitSameDayIAT(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "iat-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	sameDay, nextDay, err := file.SplitSameDay(splitSameDayCutoff, &SplitSameDayOptions{
+		Now: splitSameDayNow,
+	})
+	require.NoError(t, err)
+	require.Nil(t, sameDay)
+	require.Len(t, nextDay.IATBatches, len(file.IATBatches))
+	require.Equal(t, "261015", nextDay.IATBatches[0].GetHeader().EffectiveEntryDate)
+}
+
+func TestFile__SplitSameDayADV(t *testing.T) {
+	file, err := ReadJSONFile(filepath.Join("test", "testdata", "adv-valid.json"))
+	require.NoError(t, err)
+
+	_, _, err = file.SplitSameDay(splitSameDayCutoff, nil)
+	require.ErrorIs(t, err, ErrSplitSameDayADV)
+}
+
+func TestIsSameDayIndicator(t *testing.T) {
+	require.True(t, IsSameDayIndicator("SD1300"))
+	require.True(t, IsSameDayIndicator("sd0900"))
+	require.True(t, IsSameDayIndicator(" SD1700"))
+
+	require.False(t, IsSameDayIndicator(""))
+	require.False(t, IsSameDayIndicator("191102"))
+}
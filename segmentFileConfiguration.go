@@ -17,14 +17,77 @@
 
 package ach
 
-// SegmentFileConfiguration contains configuration setting for sorting during Segment File Creation.
+import (
+	"strings"
+)
+
+// SegmentFileConfiguration contains configuration settings for how File.Segment and File.SegmentFile
+// split a File into multiple Files.
 //
-// It is currently not defined, but can/will be expanded later and File.SegmentFile enhanced to use the
-// configuration settings
-type SegmentFileConfiguration struct{}
+// The zero value segments a File into one credit File and one debit File.
+type SegmentFileConfiguration struct {
+	// BySECCode places entries of each StandardEntryClassCode into separate Files.
+	BySECCode bool `json:"bySECCode"`
+
+	// ByCompanyIdentification places entries of each CompanyIdentification (or IAT OriginatorIdentification)
+	// into separate Files.
+	ByCompanyIdentification bool `json:"byCompanyIdentification"`
+
+	// ByEffectiveEntryDate places entries of each EffectiveEntryDate into separate Files.
+	ByEffectiveEntryDate bool `json:"byEffectiveEntryDate"`
+
+	// ByODFI places entries of each ODFIIdentification into separate Files.
+	ByODFI bool `json:"byODFI"`
+
+	// MixedCreditsAndDebits keeps credit and debit entries together rather than segmenting them into
+	// separate credit and debit Files.
+	MixedCreditsAndDebits bool `json:"mixedCreditsAndDebits"`
+
+	// MaxEntries limits the number of EntryDetail records in each segmented File.
+	MaxEntries int `json:"maxEntries"`
+
+	// MaxAmount limits the sum of entry amounts (credits and debits) in each segmented File.
+	MaxAmount int `json:"maxAmount"`
 
-// SegmentFileConfiguration returns a new SegmentFileConfiguration with default values for non exported fields
+	// PreserveBatchNumbers keeps the BatchNumber of each original batch rather than renumbering
+	// batches from 1 in each segmented File.
+	PreserveBatchNumbers bool `json:"preserveBatchNumbers"`
+
+	// RenumberTraceNumbers assigns new ascending TraceNumbers to the entries of each segmented batch
+	// rather than keeping their original TraceNumbers.
+	RenumberTraceNumbers bool `json:"renumberTraceNumbers"`
+}
+
+// NewSegmentFileConfiguration returns a new SegmentFileConfiguration with default values for non exported fields
 func NewSegmentFileConfiguration() *SegmentFileConfiguration {
 	sfc := &SegmentFileConfiguration{}
 	return sfc
 }
+
+// CreditDebitOnly returns true when the configuration only segments credits from debits, which
+// produces at most two Files and is supported by File.SegmentFile.
+func (sfc *SegmentFileConfiguration) CreditDebitOnly() bool {
+	if sfc == nil {
+		return true
+	}
+	return !sfc.BySECCode && !sfc.ByCompanyIdentification && !sfc.ByEffectiveEntryDate && !sfc.ByODFI &&
+		!sfc.MixedCreditsAndDebits && sfc.MaxEntries <= 0 && sfc.MaxAmount <= 0
+}
+
+// segmentKey returns the grouping key of a batch based on which fields are configured to split Files
+func (sfc *SegmentFileConfiguration) segmentKey(secCode, companyID, effectiveEntryDate, odfi string) string {
+	var parts []string
+	if sfc.BySECCode {
+		parts = append(parts, secCode)
+	}
+	if sfc.ByCompanyIdentification {
+		parts = append(parts, companyID)
+	}
+	if sfc.ByEffectiveEntryDate {
+		parts = append(parts, effectiveEntryDate)
+	}
+	if sfc.ByODFI {
+		parts = append(parts, odfi)
+	}
+	return strings.Join(parts, "|")
+}
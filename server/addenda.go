@@ -0,0 +1,89 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/moov-io/ach"
+	"github.com/moov-io/base/log"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+type addenda05Response struct {
+	Addenda05 *ach.Addenda05 `json:"addenda05"`
+	Err       error          `json:"error"`
+}
+
+func (r addenda05Response) error() error { return r.Err }
+
+// createDNEAddendaEndpoint formats the date of death, SSN and amount of a DNE into an Addenda05 record
+func createDNEAddendaEndpoint(logger log.Logger) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		info, ok := request.(ach.DNEPaymentInformation)
+		if !ok {
+			return addenda05Response{Err: ErrFoundABug}, ErrFoundABug
+		}
+		addenda05, err := info.Addenda05()
+		if err != nil {
+			if logger != nil {
+				logger.Error().LogError(fmt.Errorf("building DNE addenda: %w", err))
+			}
+			return addenda05Response{Err: err}, err
+		}
+		return addenda05Response{Addenda05: addenda05}, nil
+	}
+}
+
+func decodeCreateDNEAddendaRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var info ach.DNEPaymentInformation
+	if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("decoding DNE payment information json: %w", err)
+	}
+	return info, nil
+}
+
+// createENRAddendaEndpoint formats the enrollee's account and classification of an ENR into an Addenda05 record
+func createENRAddendaEndpoint(logger log.Logger) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		info, ok := request.(ach.ENRPaymentInformation)
+		if !ok {
+			return addenda05Response{Err: ErrFoundABug}, ErrFoundABug
+		}
+		addenda05, err := info.Addenda05()
+		if err != nil {
+			if logger != nil {
+				logger.Error().LogError(fmt.Errorf("building ENR addenda: %w", err))
+			}
+			return addenda05Response{Err: err}, err
+		}
+		return addenda05Response{Addenda05: addenda05}, nil
+	}
+}
+
+func decodeCreateENRAddendaRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var info ach.ENRPaymentInformation
+	if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("decoding ENR payment information json: %w", err)
+	}
+	return info, nil
+}
@@ -0,0 +1,76 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base/log"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddenda05__DNE(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, log.NewNopLogger())
+	handler := MakeHTTPHandler(NewService(repo), repo, kitlog.NewNopLogger())
+
+	body := `{"dateOfDeath": "2018-01-02T00:00:00Z", "customerSSN": "333224444", "amount": "1234.56"}`
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/addenda05/dne", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp addenda05Response
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, "05", resp.Addenda05.TypeCode)
+	require.Equal(t, `DATE OF DEATH*010218*CUSTOMERSSN*333224444*AMOUNT*1234.56\`, resp.Addenda05.PaymentRelatedInformation)
+
+	t.Run("invalid", func(t *testing.T) {
+		body := `{"dateOfDeath": "2018-01-02T00:00:00Z", "customerSSN": "#########"}`
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("POST", "/addenda05/dne", strings.NewReader(body)))
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), "CustomerSSN")
+	})
+}
+
+func TestAddenda05__ENR(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, log.NewNopLogger())
+	handler := MakeHTTPHandler(NewService(repo), repo, kitlog.NewNopLogger())
+
+	body := `{"transactionCode": 22, "RDFIIdentification": "12200004", "checkDigit": "3", "DFIAccountNumber": "123987654321",
+"individualIdentification": "777777777", "individualName": "JOHN DOE", "enrolleeClassificationCode": "0"}`
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/addenda05/enr", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp addenda05Response
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, `22*12200004*3*123987654321*777777777*DOE*JOHN*0\`, resp.Addenda05.PaymentRelatedInformation)
+
+	t.Run("invalid", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("POST", "/addenda05/enr", strings.NewReader(`{"transactionCode": 23}`)))
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), "TransactionCode")
+	})
+}
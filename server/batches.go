@@ -19,6 +19,7 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -34,7 +35,7 @@ import (
 
 type createBatchRequest struct {
 	FileID string
-	Batch  ach.Batcher
+	Batch  ach.GenericBatch
 
 	requestID string
 }
@@ -56,7 +57,18 @@ func createBatchEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 			}, err
 		}
 
-		id, err := s.CreateBatch(req.FileID, req.Batch)
+		var id string
+		var err error
+		switch batch := req.Batch.(type) {
+		case *ach.IATBatch:
+			if gs, ok := s.(GenericBatchService); ok {
+				id, err = gs.CreateIATBatch(req.FileID, batch)
+			} else {
+				err = ErrIATBatchesUnsupported
+			}
+		case ach.Batcher:
+			id, err = s.CreateBatch(req.FileID, batch)
+		}
 
 		if logger != nil {
 			logger := logger.With(log.Fields{
@@ -105,14 +117,26 @@ func decodeCreateBatchRequest(_ context.Context, r *http.Request) (interface{},
   "immediateOrigin": "123456780",
   "immediateDestination": "987654320",
   "id": ""
-}, "batches":[%v] }`
-	file, err := ach.FileFromJSON([]byte(fmt.Sprintf(fileContentsShim, string(body))))
+}, "%s":[%v] }`
+
+	// IAT batches are read from the iatBatches array of the shim.
+	var peek struct {
+		IATBatchHeader json.RawMessage `json:"IATBatchHeader"`
+	}
+	batches := "batches"
+	if err := json.Unmarshal(body, &peek); err == nil && len(peek.IATBatchHeader) > 0 {
+		batches = "iatBatches"
+	}
+	file, err := ach.FileFromJSON([]byte(fmt.Sprintf(fileContentsShim, batches, string(body))))
 	if err != nil {
 		return nil, err
 	}
 	if len(file.Batches) == 1 {
 		req.Batch = file.Batches[0]
 	}
+	if len(file.IATBatches) == 1 {
+		req.Batch = &file.IATBatches[0]
+	}
 	if req.Batch == nil {
 		return nil, errors.New("no Batch provided")
 	}
@@ -122,6 +146,17 @@ func decodeCreateBatchRequest(_ context.Context, r *http.Request) (interface{},
 	return req, nil
 }
 
+// getBatchID returns the resource ID of a domestic or IAT batch
+func getBatchID(batch ach.GenericBatch) string {
+	switch b := batch.(type) {
+	case ach.Batcher:
+		return b.ID()
+	case *ach.IATBatch:
+		return b.ID
+	}
+	return ""
+}
+
 type getBatchesRequest struct {
 	fileID string
 
@@ -131,11 +166,12 @@ type getBatchesRequest struct {
 type getBatchesResponse struct {
 	// TODO(adam): change this to JSON encode without wrapper {"batches": [..]}
 	// We don't wrap json objects in other responses, so why here?
-	Batches []ach.Batcher `json:"batches"`
-	Err     error         `json:"error"`
+	Batches    []ach.Batcher   `json:"batches"`
+	IATBatches []*ach.IATBatch `json:"iatBatches,omitempty"`
+	Err        error           `json:"error"`
 }
 
-func (r getBatchesResponse) count() int { return len(r.Batches) }
+func (r getBatchesResponse) count() int { return len(r.Batches) + len(r.IATBatches) }
 
 func (r getBatchesResponse) error() error { return r.Err }
 
@@ -170,10 +206,21 @@ func getBatchesEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 			}).Log("get batches")
 		}
 
-		return getBatchesResponse{
-			Batches: s.GetBatches(req.fileID),
-			Err:     nil,
-		}, nil
+		var resp getBatchesResponse
+		gs, ok := s.(GenericBatchService)
+		if !ok {
+			resp.Batches = s.GetBatches(req.fileID)
+			return resp, nil
+		}
+		for _, batch := range gs.GetGenericBatches(req.fileID) {
+			switch b := batch.(type) {
+			case *ach.IATBatch:
+				resp.IATBatches = append(resp.IATBatches, b)
+			case ach.Batcher:
+				resp.Batches = append(resp.Batches, b)
+			}
+		}
+		return resp, nil
 	}
 }
 
@@ -185,8 +232,8 @@ type getBatchRequest struct {
 }
 
 type getBatchResponse struct {
-	Batch ach.Batcher `json:"batch"`
-	Err   error       `json:"error"`
+	Batch ach.GenericBatch `json:"batch"`
+	Err   error            `json:"error"`
 }
 
 func (r getBatchResponse) error() error { return r.Err }
@@ -220,7 +267,13 @@ func getBatchEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 			}, err
 		}
 
-		batch, err := s.GetBatch(req.fileID, req.batchID)
+		var batch ach.GenericBatch
+		var err error
+		if gs, ok := s.(GenericBatchService); ok {
+			batch, err = gs.GetGenericBatch(req.fileID, req.batchID)
+		} else {
+			batch, err = s.GetBatch(req.fileID, req.batchID)
+		}
 
 		if logger != nil {
 			logger := logger.With(log.Fields{
@@ -23,6 +23,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -30,6 +32,7 @@ import (
 	"github.com/moov-io/base/log"
 
 	kitlog "github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFiles__decodeCreateBatchRequest(t *testing.T) {
@@ -80,6 +83,72 @@ func TestFiles__decodeCreateBatchRequest(t *testing.T) {
 	}
 }
 
+func TestFiles__CreateIATBatch(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, log.NewNopLogger())
+	svc := NewService(repo)
+	handler := MakeHTTPHandler(svc, repo, kitlog.NewNopLogger())
+
+	f := ach.NewFile()
+	f.ID = "iat"
+	require.NoError(t, repo.StoreFile(f))
+
+	bs, err := os.ReadFile(filepath.Join("..", "test", "testdata", "iat-debit.json"))
+	require.NoError(t, err)
+	iatFile, err := ach.FileFromJSON(bs)
+	require.NoError(t, err)
+	require.Len(t, iatFile.IATBatches, 1)
+
+	var body bytes.Buffer
+	require.NoError(t, json.NewEncoder(&body).Encode(iatFile.IATBatches[0]))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/files/iat/batches", &body))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var created createBatchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	require.NotEmpty(t, created.ID)
+
+	stored, err := svc.GetFile(f.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Batches, 0)
+	require.Len(t, stored.IATBatches, 1)
+	require.Equal(t, created.ID, stored.IATBatches[0].ID)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/files/iat/batches/"+created.ID, nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Contains(t, w.Body.String(), `"IATBatchHeader"`)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/files/iat/batches", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, "1", w.Header().Get("X-Total-Count"))
+	var listed struct {
+		IATBatches []*ach.IATBatch `json:"iatBatches"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&listed))
+	require.Len(t, listed.IATBatches, 1)
+	require.Equal(t, created.ID, listed.IATBatches[0].ID)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("DELETE", "/files/iat/batches/"+created.ID, nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Empty(t, svc.(GenericBatchService).GetGenericBatches(f.ID))
+
+	t.Run("service without IAT batches", func(t *testing.T) {
+		// Embedding only the Service interface hides the GenericBatchService methods
+		svc := struct{ Service }{svc}
+
+		resp, err := createBatchEndpoint(svc, log.NewNopLogger())(context.TODO(), createBatchRequest{
+			FileID: f.ID,
+			Batch:  &iatFile.IATBatches[0],
+		})
+		require.NoError(t, err)
+		require.ErrorIs(t, resp.(createBatchResponse).Err, ErrIATBatchesUnsupported)
+	})
+}
+
 func TestFiles__createBatchEndpoint(t *testing.T) {
 	repo := NewRepositoryInMemory(testTTLDuration, log.NewNopLogger())
 	svc := NewService(repo)
@@ -26,9 +26,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/describe"
+	"github.com/moov-io/ach/describe/mask"
+	"github.com/moov-io/ach/duplicates"
 	"github.com/moov-io/base"
 	moovhttp "github.com/moov-io/base/http"
 	"github.com/moov-io/base/log"
@@ -208,6 +213,9 @@ func decodeGetFilesRequest(_ context.Context, r *http.Request) (interface{}, err
 type getFileRequest struct {
 	ID string
 
+	// Enrich annotates returns and NOCs, see ach.File.Enrich
+	Enrich bool
+
 	requestID string
 }
 
@@ -226,6 +234,21 @@ func getFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 		}
 
 		f, err := s.GetFile(req.ID)
+		if err == nil && req.Enrich {
+			// Enrich a clone so the stored file is unchanged
+			cloned, err := cloneFile(f)
+			if err != nil {
+				if logger != nil {
+					logger.With(log.Fields{
+						"files":     log.String("getFile"),
+						"requestID": log.String(req.requestID),
+					}).Error().LogError(err)
+				}
+				return getFileResponse{Err: err}, nil
+			}
+			cloned.Enrich()
+			f = cloned
+		}
 
 		if logger != nil {
 			logger := logger.With(log.Fields{
@@ -252,10 +275,18 @@ func decodeGetFileRequest(_ context.Context, r *http.Request) (interface{}, erro
 	if !ok {
 		return nil, ErrBadRouting
 	}
-	return getFileRequest{
+	req := getFileRequest{
 		ID:        id,
 		requestID: moovhttp.GetRequestID(r),
-	}, nil
+	}
+	if v := r.URL.Query().Get("enrich"); v != "" {
+		enrich, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("parsing enrich: %w", err)
+		}
+		req.Enrich = enrich
+	}
+	return req, nil
 }
 
 type deleteFileRequest struct {
@@ -430,6 +461,159 @@ func GetLineEnding(r *http.Request) string {
 	return "\n"
 }
 
+type getFileReportRequest struct {
+	ID        string
+	requestID string
+
+	format string
+}
+
+type getFileReportResponse struct {
+	Err error `json:"error"`
+}
+
+func (v getFileReportResponse) error() error { return v.Err }
+
+// fileReport is a rendered report returned by getFileReportEndpoint on success
+type fileReport struct {
+	contentType string
+	body        io.Reader
+}
+
+func getFileReportEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(getFileReportRequest)
+		if !ok {
+			return getFileReportResponse{Err: ErrFoundABug}, ErrFoundABug
+		}
+
+		report := fileReport{contentType: "text/html; charset=utf-8"}
+		var buf bytes.Buffer
+		f, err := s.GetFile(req.ID)
+		if err == nil {
+			opts := &describe.Opts{Options: mask.Options{MaskAccountNumbers: true, MaskCorrectedData: true}}
+			if req.format == "pdf" {
+				report.contentType = "application/pdf"
+				err = describe.PDF(&buf, f, opts)
+			} else {
+				err = describe.HTML(&buf, f, opts)
+			}
+			report.body = &buf
+		}
+
+		if logger != nil {
+			logger := logger.With(log.Fields{
+				"files":     log.String("getFileReport"),
+				"requestID": log.String(req.requestID),
+				"format":    log.String(req.format),
+			})
+			if err != nil {
+				logger.Error().LogError(err)
+			} else {
+				logger.Info().Log("get file report")
+			}
+		}
+		if err != nil {
+			return getFileReportResponse{Err: err}, nil
+		}
+		return report, nil
+	}
+}
+
+func decodeGetFileReportRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+
+	req := getFileReportRequest{
+		ID:        id,
+		requestID: moovhttp.GetRequestID(r),
+		format:    strings.ToLower(r.URL.Query().Get("format")),
+	}
+	switch req.format {
+	case "":
+		req.format = "html"
+	case "html", "pdf":
+	default:
+		return nil, fmt.Errorf("unknown report format %q", req.format)
+	}
+	return req, nil
+}
+
+// encodeReportResponse writes a rendered report with its Content-Type or the JSON error
+func encodeReportResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if r, ok := response.(fileReport); ok {
+		w.Header().Set("Content-Type", r.contentType)
+		w.WriteHeader(http.StatusOK)
+		_, err := io.Copy(w, r.body)
+		return err
+	}
+	return encodeResponse(ctx, w, response)
+}
+
+type checkDuplicatesRequest struct {
+	ID        string
+	requestID string
+
+	opts duplicates.Options
+}
+
+type checkDuplicatesResponse struct {
+	Report *duplicates.Report `json:"report"`
+	Err    error              `json:"error"`
+}
+
+func (v checkDuplicatesResponse) error() error { return v.Err }
+
+func checkDuplicatesEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(checkDuplicatesRequest)
+		if !ok {
+			return checkDuplicatesResponse{Err: ErrFoundABug}, ErrFoundABug
+		}
+
+		report, err := s.CheckDuplicates(req.ID, req.opts)
+		if logger != nil {
+			logger := logger.With(log.Fields{
+				"files":     log.String("checkDuplicates"),
+				"requestID": log.String(req.requestID),
+			})
+			if err != nil {
+				logger.Error().LogError(err)
+			} else {
+				logger.Info().Logf("checked file for duplicates, found=%v", report.Found())
+			}
+		}
+		return checkDuplicatesResponse{
+			Report: report,
+			Err:    err,
+		}, nil
+	}
+}
+
+func decodeCheckDuplicatesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+
+	req := checkDuplicatesRequest{
+		ID:        id,
+		requestID: moovhttp.GetRequestID(r),
+	}
+	if v := r.URL.Query().Get("window"); v != "" {
+		window, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("parsing window: %w", err)
+		}
+		req.opts.Window = window
+	}
+	return req, nil
+}
+
 type validateFileRequest struct {
 	ID        string
 	requestID string
@@ -575,9 +759,52 @@ type segmentedFilesResponse struct {
 	DebitFileID string    `json:"debitFileID"`
 	DebitFile   *ach.File `json:"debitFile"`
 
+	// FileIDs and Files are returned when the SegmentFileConfiguration splits
+	// a file by more than credits and debits.
+	FileIDs []string    `json:"fileIDs,omitempty"`
+	Files   []*ach.File `json:"files,omitempty"`
+
 	Err error `json:"error"`
 }
 
+// segmentFiles stores each file segmented by opts
+func segmentFiles(r Repository, logger log.Logger, requestID string, segment func() ([]*ach.File, error)) (segmentedFilesResponse, error) {
+	files, err := segment()
+	if logger != nil {
+		logger := logger.With(log.Fields{
+			"files":     log.String("segment"),
+			"requestID": log.String(requestID),
+		})
+		if err != nil {
+			logger.Error().LogError(err)
+		} else {
+			logger.Info().Logf("segmented into %d files", len(files))
+		}
+	}
+	if err != nil {
+		return segmentedFilesResponse{Err: err}, err
+	}
+
+	var resp segmentedFilesResponse
+	for _, file := range files {
+		err = r.StoreFile(file)
+		if err != nil {
+			if logger != nil {
+				logger.With(log.Fields{
+					"files":     log.String("storeSegmentedFile"),
+					"requestID": log.String(requestID),
+				}).LogError(err)
+			}
+			resp.Err = err
+			return resp, nil
+		}
+		resp.FileIDs = append(resp.FileIDs, file.ID)
+		resp.Files = append(resp.Files, file)
+	}
+
+	return resp, nil
+}
+
 func segmentFileIDEndpoint(s Service, r Repository, logger log.Logger) endpoint.Endpoint {
 	return func(_ context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(segmentFileIDRequest)
@@ -585,6 +812,12 @@ func segmentFileIDEndpoint(s Service, r Repository, logger log.Logger) endpoint.
 			return segmentedFilesResponse{Err: ErrFoundABug}, ErrFoundABug
 		}
 
+		if !req.opts.CreditDebitOnly() {
+			return segmentFiles(r, logger, req.requestID, func() ([]*ach.File, error) {
+				return s.SegmentID(req.fileID, req.opts)
+			})
+		}
+
 		creditFile, debitFile, err := s.SegmentFileID(req.fileID, req.opts)
 
 		if logger != nil {
@@ -605,31 +838,35 @@ func segmentFileIDEndpoint(s Service, r Repository, logger log.Logger) endpoint.
 		var resp segmentedFilesResponse
 
 		if creditFile.ID != "" {
-			err = r.StoreFile(creditFile)
-			if logger != nil && err != nil {
-				logger.With(log.Fields{
-					"files":     log.String("storeCreditFile"),
-					"requestID": log.String(req.requestID),
-				}).LogError(err)
+			if err := r.StoreFile(creditFile); err != nil {
+				if logger != nil {
+					logger.With(log.Fields{
+						"files":     log.String("storeCreditFile"),
+						"requestID": log.String(req.requestID),
+					}).LogError(err)
+				}
+				resp.Err = err
+				return resp, nil
 			}
 			resp.CreditFile = creditFile
 			resp.CreditFileID = creditFile.ID
 		}
 
 		if debitFile.ID != "" {
-			err = r.StoreFile(debitFile)
-			if logger != nil && err != nil {
-				logger.With(log.Fields{
-					"files":     log.String("storeDebitFile"),
-					"requestID": log.String(req.requestID),
-				}).LogError(err)
+			if err := r.StoreFile(debitFile); err != nil {
+				if logger != nil {
+					logger.With(log.Fields{
+						"files":     log.String("storeDebitFile"),
+						"requestID": log.String(req.requestID),
+					}).LogError(err)
+				}
+				resp.Err = err
+				return resp, nil
 			}
 			resp.DebitFile = debitFile
 			resp.DebitFileID = debitFile.ID
 		}
 
-		resp.Err = err
-
 		return resp, nil
 	}
 }
@@ -673,6 +910,12 @@ func segmentFileEndpoint(s Service, r Repository, logger log.Logger) endpoint.En
 			req.File.SetValidation(req.validateOpts)
 		}
 
+		if !req.opts.CreditDebitOnly() {
+			return segmentFiles(r, logger, req.requestID, func() ([]*ach.File, error) {
+				return s.Segment(req.File, req.opts)
+			})
+		}
+
 		creditFile, debitFile, err := s.SegmentFile(req.File, req.opts)
 		if logger != nil {
 			logger.With(log.Fields{
@@ -692,31 +935,35 @@ func segmentFileEndpoint(s Service, r Repository, logger log.Logger) endpoint.En
 		var resp segmentedFilesResponse
 
 		if creditFile.ID != "" {
-			err = r.StoreFile(creditFile)
-			if logger != nil && err != nil {
-				logger.With(log.Fields{
-					"files":     log.String("storeCreditFile"),
-					"requestID": log.String(req.requestID),
-				}).LogError(err)
+			if err := r.StoreFile(creditFile); err != nil {
+				if logger != nil {
+					logger.With(log.Fields{
+						"files":     log.String("storeCreditFile"),
+						"requestID": log.String(req.requestID),
+					}).LogError(err)
+				}
+				resp.Err = err
+				return resp, nil
 			}
 			resp.CreditFile = creditFile
 			resp.CreditFileID = creditFile.ID
 		}
 
 		if debitFile.ID != "" {
-			err = r.StoreFile(debitFile)
-			if logger != nil && err != nil {
-				logger.With(log.Fields{
-					"files":     log.String("storeDebitFile"),
-					"requestID": log.String(req.requestID),
-				}).LogError(err)
+			if err := r.StoreFile(debitFile); err != nil {
+				if logger != nil {
+					logger.With(log.Fields{
+						"files":     log.String("storeDebitFile"),
+						"requestID": log.String(req.requestID),
+					}).LogError(err)
+				}
+				resp.Err = err
+				return resp, nil
 			}
 			resp.DebitFile = debitFile
 			resp.DebitFileID = debitFile.ID
 		}
 
-		resp.Err = err
-
 		return resp, nil
 	}
 }
@@ -915,6 +1162,7 @@ func decodeMergeFilesRequest(_ context.Context, r *http.Request) (interface{}, e
 type reverseFileRequest struct {
 	fileID             string
 	effectiveEntryDate time.Time
+	traceNumbers       []string
 	requestID          string
 }
 
@@ -933,7 +1181,13 @@ func reverseFileEndpoint(s Service, r Repository, logger log.Logger) endpoint.En
 			return reverseFileResponse{Err: ErrFoundABug}, ErrFoundABug
 		}
 
-		reversedFile, err := s.ReverseFile(req.fileID, req.effectiveEntryDate)
+		var reversedFile *ach.File
+		var err error
+		if len(req.traceNumbers) > 0 {
+			reversedFile, err = s.ReverseEntries(req.fileID, req.traceNumbers, req.effectiveEntryDate)
+		} else {
+			reversedFile, err = s.ReverseFile(req.fileID, req.effectiveEntryDate)
+		}
 		if logger != nil {
 			logger := logger.With(log.Fields{
 				"files":     log.String("ReverseFile"),
@@ -981,10 +1235,12 @@ func decodeReverseFileRequest(_ context.Context, r *http.Request) (interface{},
 
 	var body struct {
 		EffectiveEntryDate base.Time `json:"effectiveEntryDate"`
+		TraceNumbers       []string  `json:"traceNumbers"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
 		return nil, fmt.Errorf("parsing reverse request: %w", err)
 	}
+	req.traceNumbers = body.TraceNumbers
 
 	if body.EffectiveEntryDate.IsZero() {
 		req.effectiveEntryDate = time.Now().In(time.UTC)
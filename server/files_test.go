@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -794,6 +795,78 @@ func TestFiles__segmentFileIDEndpoint(t *testing.T) {
 	require.NotNil(t, resp.DebitFile)
 }
 
+func TestFiles__segmentFileIDEndpointOptions(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, kitlog.NewNopLogger())
+
+	bs, err := os.ReadFile(filepath.Join("..", "test", "testdata", "ppd-mixedDebitCredit-valid.json"))
+	require.NoError(t, err)
+	file, err := ach.FileFromJSON(bs)
+	require.NoError(t, err)
+	require.NoError(t, repo.StoreFile(file))
+
+	body := strings.NewReader(`{"mixedCreditsAndDebits": true, "maxEntries": 1}`)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", fmt.Sprintf("/files/%s/segment", file.ID), body)
+	req.Header.Set("Origin", "https://moov.io")
+	req.Header.Set("X-Request-Id", "11111")
+
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp segmentedFilesResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Empty(t, resp.CreditFileID)
+	require.Empty(t, resp.DebitFileID)
+	require.Len(t, resp.FileIDs, 2)
+	require.Len(t, resp.Files, 2)
+
+	for i := range resp.FileIDs {
+		f, err := repo.FindFile(resp.FileIDs[i])
+		require.NoError(t, err)
+		require.Len(t, f.Batches[0].GetEntries(), 1)
+	}
+}
+
+type failingStoreRepository struct {
+	Repository
+
+	stores int
+	failAt int
+}
+
+func (r *failingStoreRepository) StoreFile(f *ach.File) error {
+	r.stores++
+	if r.stores == r.failAt {
+		return errors.New("store failed")
+	}
+	return r.Repository.StoreFile(f)
+}
+
+func TestFiles__segmentFilesStoreError(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := &failingStoreRepository{
+		Repository: NewRepositoryInMemory(testTTLDuration, logger),
+		failAt:     1,
+	}
+
+	file, err := ach.ReadFile(filepath.Join("..", "test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	resp, err := segmentFiles(repo, logger, "", func() ([]*ach.File, error) {
+		return file.Segment(&ach.SegmentFileConfiguration{MixedCreditsAndDebits: true, MaxEntries: 1})
+	})
+	require.NoError(t, err)
+	require.EqualError(t, resp.Err, "store failed")
+	require.Empty(t, resp.FileIDs)
+	require.Equal(t, 1, repo.stores)
+}
+
 func TestFiles__segmentFileEndpoint(t *testing.T) {
 	logger := log.NewNopLogger()
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
@@ -1039,6 +1112,54 @@ func TestFilesByID__getFileEndpoint(t *testing.T) {
 
 }
 
+func TestFilesByID__getFileEndpointEnrich(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, kitlog.NewNopLogger())
+
+	file, err := ach.ReadFile(filepath.Join("..", "test", "testdata", "return-WEB.ach"))
+	require.NoError(t, err)
+	file.ID = "returns"
+	require.NoError(t, repo.StoreFile(file))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/files/returns?enrich=true", nil)
+	req.Header.Set("X-Request-Id", "11111")
+
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"returnDetails":{"code":"R01","reason":"Insufficient Funds"`)
+
+	// The stored file isn't enriched
+	stored, err := repo.FindFile("returns")
+	require.NoError(t, err)
+	require.Nil(t, stored.Batches[0].GetEntries()[0].Addenda99.ReturnDetails)
+
+	t.Run("not enriched", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/files/returns", nil)
+
+		router.ServeHTTP(w, req)
+		w.Flush()
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NotContains(t, w.Body.String(), "returnDetails")
+	})
+
+	t.Run("bad enrich", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/files/returns?enrich=maybe", nil)
+
+		router.ServeHTTP(w, req)
+		w.Flush()
+
+		require.NotEqual(t, http.StatusOK, w.Code)
+	})
+}
+
 // TestFileContentsByID__getFileContentsEndpoint tests getFileContentsEndpoint by File ID
 func TestFileContentsByID__getFileContentsEndpoint(t *testing.T) {
 	logger := log.NewNopLogger()
@@ -1070,6 +1191,64 @@ func TestFileContentsByID__getFileContentsEndpoint(t *testing.T) {
 	}
 }
 
+func TestFiles__getFileReportEndpoint(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, kitlog.NewNopLogger())
+
+	file, err := ach.ReadFile(filepath.Join("..", "test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	file.ID = "report"
+	require.NoError(t, repo.StoreFile(file))
+
+	t.Run("html", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/files/report/report", nil)
+		req.Header.Set("X-Request-Id", "11111")
+
+		router.ServeHTTP(w, req)
+		w.Flush()
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Contains(t, w.Body.String(), "<td>****5678</td>")
+		require.NotContains(t, w.Body.String(), "12345678")
+	})
+
+	t.Run("pdf", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/files/report/report?format=pdf", nil)
+
+		router.ServeHTTP(w, req)
+		w.Flush()
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+		require.True(t, strings.HasPrefix(w.Body.String(), "%PDF-"))
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/files/report/report?format=docx", nil)
+
+		router.ServeHTTP(w, req)
+		w.Flush()
+
+		require.NotEqual(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/files/missing/report", nil)
+
+		router.ServeHTTP(w, req)
+		w.Flush()
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
 func TestFileContentsByID__getFileContentsEndpoint_WindowsLineEndings(t *testing.T) {
 	logger := log.NewNopLogger()
 	repo := NewRepositoryInMemory(testTTLDuration, logger)
@@ -1574,6 +1753,48 @@ func TestFiles__reverseFileEndpointWithDate(t *testing.T) {
 	require.Equal(t, "250615", bh.EffectiveEntryDate)
 }
 
+func TestFiles__reverseFileEndpointTraceNumbers(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, kitlog.NewNopLogger())
+
+	file, err := ach.ReadFile(filepath.Join("..", "test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+	file.ID = "mixed"
+	require.NoError(t, repo.StoreFile(file))
+
+	reverse := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/files/mixed/reverse", strings.NewReader(body))
+		router.ServeHTTP(w, req)
+		w.Flush()
+		return w
+	}
+
+	w := reverse(`{"effectiveEntryDate": "2019-07-22T10:00:00Z", "traceNumbers": ["121042880000002"]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp reverseFileResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.File.Batches, 1)
+	entries := resp.File.Batches[0].GetEntries()
+	require.Len(t, entries, 1)
+	require.Equal(t, ach.CheckingDebit, entries[0].TransactionCode)
+	require.Equal(t, "121042880000001", entries[0].TraceNumber)
+
+	// The reversal is stored and the original file is unchanged
+	stored, err := repo.FindFile(resp.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Batches[0].GetEntries(), 1)
+	require.Len(t, file.Batches[0].GetEntries(), 3)
+	require.Equal(t, "121042880000002", file.Batches[0].GetEntries()[1].TraceNumber)
+
+	w = reverse(`{"effectiveEntryDate": "2019-08-30T10:00:00Z", "traceNumbers": ["121042880000002"]}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "five banking day window")
+}
+
 func TestFilesError__reverseFileEndpoint(t *testing.T) {
 	repo := NewRepositoryInMemory(testTTLDuration, nil)
 	svc := NewService(repo)
@@ -1617,3 +1838,56 @@ func TestFiles__decodeReverseFileRequestWithFileID(t *testing.T) {
 	require.Equal(t, 1, int(r.effectiveEntryDate.Month()))
 	require.Equal(t, 15, r.effectiveEntryDate.Day())
 }
+
+func TestFiles__checkDuplicatesEndpoint(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+	router := MakeHTTPHandler(svc, repo, kitlog.NewNopLogger())
+
+	first, err := ach.ReadFile(filepath.Join("..", "test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	first.ID = "first"
+	require.NoError(t, repo.StoreFile(first))
+
+	second, err := ach.ReadFile(filepath.Join("..", "test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	second.ID = "second"
+	require.NoError(t, repo.StoreFile(second))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/files/second/duplicates?window=24h", nil)
+	req.Header.Set("X-Request-Id", "11111")
+
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp checkDuplicatesResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.NotNil(t, resp.Report)
+	require.True(t, resp.Report.DuplicateFile)
+	require.Equal(t, "first", resp.Report.DuplicateFileID)
+	require.Len(t, resp.Report.Entries, 1)
+
+	t.Run("bad window", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/files/second/duplicates?window=tomorrow", nil)
+
+		router.ServeHTTP(w, req)
+		w.Flush()
+
+		require.NotEqual(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/files/missing/duplicates", nil)
+
+		router.ServeHTTP(w, req)
+		w.Flush()
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/duplicates"
 	"github.com/moov-io/base/log"
 )
 
@@ -39,10 +40,21 @@ type Repository interface {
 	DeleteBatch(fileID string, batchID string) error
 }
 
+// GenericBatchRepository is implemented by a Repository which also stores IAT batches.
+// DeleteBatch is expected to delete IAT batches as well.
+type GenericBatchRepository interface {
+	StoreIATBatch(fileID string, batch *ach.IATBatch) error
+	FindGenericBatch(fileID string, batchID string) (ach.GenericBatch, error)
+	FindAllGenericBatches(fileID string) []ach.GenericBatch
+}
+
 type repositoryInMemory struct {
 	mtx   sync.RWMutex
 	files map[string]*ach.File
 
+	// history indexes stored files for duplicate checks
+	history duplicates.History
+
 	ttl time.Duration
 
 	logger log.Logger
@@ -51,9 +63,10 @@ type repositoryInMemory struct {
 // NewRepositoryInMemory is an in memory ach storage repository for files
 func NewRepositoryInMemory(ttl time.Duration, logger log.Logger) Repository {
 	repo := &repositoryInMemory{
-		files:  make(map[string]*ach.File),
-		ttl:    ttl,
-		logger: logger,
+		files:   make(map[string]*ach.File),
+		history: duplicates.NewMemoryHistory(),
+		ttl:     ttl,
+		logger:  logger,
 	}
 
 	if ttl <= 0*time.Second {
@@ -83,6 +96,7 @@ func (r *repositoryInMemory) StoreFile(f *ach.File) error {
 		return ErrAlreadyExists
 	}
 	r.files[f.ID] = f
+	r.indexFile(f)
 	return nil
 }
 
@@ -111,6 +125,7 @@ func (r *repositoryInMemory) DeleteFile(id string) error {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 	delete(r.files, id)
+	r.removeIndex(id)
 	return nil
 }
 
@@ -126,20 +141,46 @@ func (r *repositoryInMemory) StoreBatch(fileID string, batch ach.Batcher) error
 	}
 
 	// ensure the batch does not already exist
-	for _, val := range file.Batches {
-		if val.ID() == batch.ID() {
-			return ErrAlreadyExists
-		}
+	if hasBatch(file, batch.ID()) {
+		return ErrAlreadyExists
 	}
 
 	// Add the batch to the file
-	if f := r.files[fileID]; f != nil {
-		f.AddBatch(batch)
+	file.AddBatch(batch)
+	r.indexFile(file)
+
+	return nil
+}
+
+// StoreIATBatch adds an IAT batch to the file
+func (r *repositoryInMemory) StoreIATBatch(fileID string, batch *ach.IATBatch) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	file, ok := r.files[fileID]
+	if !ok || file == nil {
+		return ErrNotFound
+	}
+	if hasBatch(file, batch.ID) {
+		return ErrAlreadyExists
 	}
 
+	file.AddIATBatch(*batch)
+	r.indexFile(file)
+
 	return nil
 }
 
+// hasBatch returns true when file has a domestic or IAT batch with batchID
+func hasBatch(file *ach.File, batchID string) bool {
+	for _, val := range file.AllBatches() {
+		if getBatchID(val) == batchID {
+			return true
+		}
+	}
+	return false
+}
+
 // FindBatch retrieves a ach.Batcher based on the supplied ID
 func (r *repositoryInMemory) FindBatch(fileID string, batchID string) (ach.Batcher, error) {
 	r.mtx.RLock()
@@ -175,6 +216,38 @@ func (r *repositoryInMemory) FindAllBatches(fileID string) []ach.Batcher {
 	return batches
 }
 
+// FindGenericBatch retrieves a domestic or IAT batch based on the supplied ID
+func (r *repositoryInMemory) FindGenericBatch(fileID string, batchID string) (ach.GenericBatch, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	file, ok := r.files[fileID]
+	if !ok || file == nil {
+		return nil, ErrNotFound
+	}
+
+	for _, val := range file.AllBatches() {
+		if getBatchID(val) == batchID {
+			return val, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+// FindAllGenericBatches returns the domestic and IAT batches of a file
+func (r *repositoryInMemory) FindAllGenericBatches(fileID string) []ach.GenericBatch {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	file, ok := r.files[fileID]
+	if !ok || file == nil {
+		return nil
+	}
+
+	return file.AllBatches()
+}
+
 func (r *repositoryInMemory) DeleteBatch(fileID string, batchID string) error {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
@@ -187,6 +260,14 @@ func (r *repositoryInMemory) DeleteBatch(fileID string, batchID string) error {
 	for i := len(file.Batches) - 1; i >= 0; i-- {
 		if file.Batches[i].ID() == batchID {
 			file.Batches = append(file.Batches[:i], file.Batches[i+1:]...)
+			r.indexFile(file)
+			return nil
+		}
+	}
+	for i := len(file.IATBatches) - 1; i >= 0; i-- {
+		if file.IATBatches[i].ID == batchID {
+			file.IATBatches = append(file.IATBatches[:i], file.IATBatches[i+1:]...)
+			r.indexFile(file)
 			return nil
 		}
 	}
@@ -212,6 +293,7 @@ func (r *repositoryInMemory) cleanupOldFiles() {
 		if file.Header.FileCreationDate < tooOldStr {
 			removed++
 			delete(r.files, i)
+			r.removeIndex(i)
 		}
 	}
 
@@ -219,3 +301,22 @@ func (r *repositoryInMemory) cleanupOldFiles() {
 		r.logger.Info().Logf("removed %d ACH files older than %v", removed, tooOld.Format(time.RFC3339))
 	}
 }
+
+// DuplicatesHistory returns the index of stored files used to check for duplicates
+func (r *repositoryInMemory) DuplicatesHistory() duplicates.History {
+	return r.history
+}
+
+// indexFile records the hash and entries of a stored file, the caller must hold r.mtx
+func (r *repositoryInMemory) indexFile(f *ach.File) {
+	detector := duplicates.NewDetector(r.history, duplicates.Options{Hash: hash})
+	if err := detector.Record(f); err != nil && r.logger != nil {
+		r.logger.Warn().Logf("problem indexing file %s for duplicates: %v", f.ID, err)
+	}
+}
+
+func (r *repositoryInMemory) removeIndex(fileID string) {
+	if err := r.history.Remove(fileID); err != nil && r.logger != nil {
+		r.logger.Warn().Logf("problem removing file %s from duplicates index: %v", fileID, err)
+	}
+}
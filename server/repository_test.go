@@ -18,12 +18,17 @@
 package server
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/duplicates"
 	"github.com/moov-io/base"
 	"github.com/moov-io/base/log"
+
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -131,3 +136,55 @@ func TestRepository__cleanupOldFiles(t *testing.T) {
 		repo.cleanupOldFiles() // make sure we don't panic
 	}
 }
+
+func TestRepository__DuplicatesHistory(t *testing.T) {
+	repo := NewRepositoryInMemory(testTTLDuration, log.NewNopLogger())
+	history := repo.(duplicatesIndex).DuplicatesHistory()
+
+	file, err := ach.ReadFile(filepath.Join("..", "test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	file.ID = "first"
+	entry := file.Batches[0].GetEntries()[0]
+	key := duplicates.Key{
+		RDFIIdentification:   entry.RDFIIdentification,
+		DFIAccountNumber:     strings.TrimSpace(entry.DFIAccountNumber),
+		Amount:               entry.Amount,
+		IdentificationNumber: strings.TrimSpace(entry.IdentificationNumber),
+	}
+	require.NoError(t, repo.StoreFile(file))
+
+	report, err := duplicates.NewDetector(history, duplicates.Options{Hash: hash}).Check(file)
+	require.NoError(t, err)
+	fileIDs, err := history.FindFiles(report.FileHash)
+	require.NoError(t, err)
+	require.Equal(t, []string{"first"}, fileIDs)
+
+	found, err := history.FindEntries(key)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+
+	// Removing a batch indexes the file again
+	require.NoError(t, repo.DeleteBatch("first", file.Batches[0].ID()))
+	fileIDs, err = history.FindFiles(report.FileHash)
+	require.NoError(t, err)
+	require.Empty(t, fileIDs)
+
+	found, err = history.FindEntries(key)
+	require.NoError(t, err)
+	require.Empty(t, found)
+
+	// Deleted files are removed from the index
+	second, err := ach.ReadFile(filepath.Join("..", "test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	second.ID = "second"
+	require.NoError(t, repo.StoreFile(second))
+
+	found, err = history.FindEntries(key)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+
+	require.NoError(t, repo.DeleteFile("second"))
+	found, err = history.FindEntries(key)
+	require.NoError(t, err)
+	require.Empty(t, found)
+}
@@ -28,6 +28,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/moov-io/ach"
 	"github.com/moov-io/base"
 	moovhttp "github.com/moov-io/base/http"
 	"github.com/moov-io/base/log"
@@ -145,12 +146,24 @@ func MakeHTTPHandler(s Service, repo Repository, kitlog gokitlog.Logger) http.Ha
 		encodeResponse,
 		options...,
 	))
+	r.Methods("GET").Path("/files/{id}/duplicates").Handler(httptransport.NewServer(
+		checkDuplicatesEndpoint(s, logger),
+		decodeCheckDuplicatesRequest,
+		encodeResponse,
+		options...,
+	))
 	r.Methods("GET").Path("/files/{id}/contents").Handler(httptransport.NewServer(
 		getFileContentsEndpoint(s, logger),
 		decodeGetFileContentsRequest,
 		encodeTextResponse,
 		options...,
 	))
+	r.Methods("GET").Path("/files/{id}/report").Handler(httptransport.NewServer(
+		getFileReportEndpoint(s, logger),
+		decodeGetFileReportRequest,
+		encodeReportResponse,
+		options...,
+	))
 	r.Methods("GET").Path("/files/{id}/validate").Handler(httptransport.NewServer(
 		validateFileEndpoint(s, logger),
 		decodeValidateFileRequest,
@@ -223,6 +236,18 @@ func MakeHTTPHandler(s Service, repo Repository, kitlog gokitlog.Logger) http.Ha
 		encodeResponse,
 		options...,
 	))
+	r.Methods("POST").Path("/addenda05/dne").Handler(httptransport.NewServer(
+		createDNEAddendaEndpoint(logger),
+		decodeCreateDNEAddendaRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/addenda05/enr").Handler(httptransport.NewServer(
+		createENRAddendaEndpoint(logger),
+		decodeCreateENRAddendaRequest,
+		encodeResponse,
+		options...,
+	))
 	r.Methods("POST").Path("/merge").Handler(httptransport.NewServer(
 		mergeFilesEndpoint(s, repo, logger),
 		decodeMergeFilesRequest,
@@ -344,6 +369,8 @@ func codeFrom(err error) int {
 		strings.Contains(errString, "ach.RecordWrongLengthErr"),
 		strings.Contains(errString, "FieldName"): // FileFromJSON
 		return http.StatusBadRequest
+	case errors.Is(err, ach.ErrReversalWindow), errors.Is(err, ach.ErrTraceNumberNotFound):
+		return http.StatusBadRequest
 	}
 
 	switch err {
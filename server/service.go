@@ -28,12 +28,17 @@ import (
 	"time"
 
 	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/duplicates"
+	"github.com/moov-io/ach/fedach"
 	"github.com/moov-io/base"
 )
 
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
+
+	// ErrIATBatchesUnsupported is returned when the Service or Repository doesn't handle IAT batches
+	ErrIATBatchesUnsupported = errors.New("repository does not support IAT batches")
 )
 
 // Service is a REST interface for interacting with ACH file structures
@@ -53,12 +58,18 @@ type Service interface {
 	GetFileContents(id string, opts *ach.WriteOpts) (io.Reader, error)
 	// ValidateFile
 	ValidateFile(id string, opts *ach.ValidateOpts) error
+	// CheckDuplicates compares a file against every other stored file for duplicate entries or contents
+	CheckDuplicates(id string, opts duplicates.Options) (*duplicates.Report, error)
 	// BalanceFile will apply a given offset record to the file
 	BalanceFile(fileID string, off *ach.Offset) (*ach.File, error)
 	// SegmentFileID segments an ach file
 	SegmentFileID(id string, opts *ach.SegmentFileConfiguration) (*ach.File, *ach.File, error)
 	// SegmentFile segments an ach file
 	SegmentFile(file *ach.File, opts *ach.SegmentFileConfiguration) (*ach.File, *ach.File, error)
+	// SegmentID segments an ach file into any number of files
+	SegmentID(id string, opts *ach.SegmentFileConfiguration) ([]*ach.File, error)
+	// Segment segments an ach file into any number of files
+	Segment(file *ach.File, opts *ach.SegmentFileConfiguration) ([]*ach.File, error)
 	// FlattenBatches will minimize the ach.Batch objects in a file by consolidating EntryDetails under distinct batch headers
 	FlattenBatches(id string) (*ach.File, error)
 	// CreateBatch creates a new batch within and ach file and returns its resource ID
@@ -73,11 +84,28 @@ type Service interface {
 	MergeFiles(fileIDs []string, files []*ach.File, conditions *ach.Conditions) ([]*ach.File, error)
 	// ReverseFile creates a NACHA compliant reversal of the ACH file
 	ReverseFile(fileID string, effectiveEntryDate time.Time) (*ach.File, error)
+	// ReverseEntries creates a NACHA compliant reversal of the chosen entries in an ACH file
+	ReverseEntries(fileID string, traceNumbers []string, effectiveEntryDate time.Time) (*ach.File, error)
+}
+
+// GenericBatchService is implemented by a Service which also handles IAT batches. The batch
+// endpoints fall back to domestic batches for a Service without it.
+type GenericBatchService interface {
+	// CreateIATBatch creates a new IAT batch within an ach file and returns its resource ID
+	CreateIATBatch(fileID string, batch *ach.IATBatch) (string, error)
+	// GetGenericBatch retrieves a domestic or IAT batch based on the file id and batch id
+	GetGenericBatch(fileID string, batchID string) (ach.GenericBatch, error)
+	// GetGenericBatches retrieves all domestic and IAT batches associated with the file id.
+	GetGenericBatches(fileID string) []ach.GenericBatch
 }
 
+var _ GenericBatchService = (*service)(nil)
+
 // service a concrete implementation of the service.
 type service struct {
 	store Repository
+
+	directory *fedach.Directory
 }
 
 // NewService creates a new concrete service
@@ -87,6 +115,15 @@ func NewService(r Repository) Service {
 	}
 }
 
+// NewServiceWithDirectory creates a new concrete service which rejects entries whose RDFI
+// isn't a participant in the FedACH directory when validating files.
+func NewServiceWithDirectory(r Repository, dir *fedach.Directory) Service {
+	return &service{
+		store:     r,
+		directory: dir,
+	}
+}
+
 // CreateFile add a file to storage
 // TODO(adam): the HTTP endpoint accepts malformed bodies (and missing data)
 func (s *service) CreateFile(fh *ach.FileHeader) (string, error) {
@@ -181,9 +218,51 @@ func (s *service) ValidateFile(id string, opts *ach.ValidateOpts) error {
 	if err != nil {
 		return fmt.Errorf("problem reading file %s: %w", id, err)
 	}
+	if s.directory != nil {
+		// copy the options so the caller's aren't modified
+		var withDirectory ach.ValidateOpts
+		if opts != nil {
+			withDirectory = *opts
+		}
+		if withDirectory.CheckRDFI == nil {
+			withDirectory.CheckRDFI = s.directory.CheckRDFI
+		}
+		opts = &withDirectory
+	}
 	return f.ValidateWith(opts)
 }
 
+// duplicatesIndex is implemented by a Repository which indexes files for duplicate checks as they're stored
+type duplicatesIndex interface {
+	DuplicatesHistory() duplicates.History
+}
+
+func (s *service) CheckDuplicates(id string, opts duplicates.Options) (*duplicates.Report, error) {
+	f, err := s.GetFile(id)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Hash = hash
+
+	// Repositories which index stored files are checked against directly
+	if indexed, ok := s.store.(duplicatesIndex); ok {
+		return duplicates.NewDetector(indexed.DuplicatesHistory(), opts).Check(f)
+	}
+
+	// Every other stored file is history to compare against
+	detector := duplicates.NewDetector(duplicates.NewMemoryHistory(), opts)
+	for _, other := range s.store.FindAllFiles() {
+		if other == nil || other.ID == f.ID {
+			continue
+		}
+		if err := detector.Record(other); err != nil {
+			return nil, fmt.Errorf("problem recording file %s: %w", other.ID, err)
+		}
+	}
+	return detector.Check(f)
+}
+
 func (s *service) CreateBatch(fileID string, batch ach.Batcher) (string, error) {
 	if batch == nil {
 		return "", errors.New("no batch provided")
@@ -203,6 +282,27 @@ func (s *service) CreateBatch(fileID string, batch ach.Batcher) (string, error)
 	return batch.ID(), nil
 }
 
+func (s *service) CreateIATBatch(fileID string, batch *ach.IATBatch) (string, error) {
+	if batch == nil || batch.GetHeader() == nil {
+		return "", errors.New("no batch provided")
+	}
+	store, ok := s.store.(GenericBatchRepository)
+	if !ok {
+		return "", ErrIATBatchesUnsupported
+	}
+	if batch.GetHeader().ID == "" {
+		batch.GetHeader().ID = base.ID()
+	}
+	batch.ID = batch.GetHeader().ID
+	if batch.GetControl() != nil {
+		batch.GetControl().ID = batch.ID
+	}
+	if err := store.StoreIATBatch(fileID, batch); err != nil {
+		return "", err
+	}
+	return batch.ID, nil
+}
+
 func (s *service) GetBatch(fileID string, batchID string) (ach.Batcher, error) {
 	b, err := s.store.FindBatch(fileID, batchID)
 	if err != nil {
@@ -215,6 +315,30 @@ func (s *service) GetBatches(fileID string) []ach.Batcher {
 	return s.store.FindAllBatches(fileID)
 }
 
+func (s *service) GetGenericBatch(fileID string, batchID string) (ach.GenericBatch, error) {
+	store, ok := s.store.(GenericBatchRepository)
+	if !ok {
+		return s.GetBatch(fileID, batchID)
+	}
+	b, err := store.FindGenericBatch(fileID, batchID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return b, nil
+}
+
+func (s *service) GetGenericBatches(fileID string) []ach.GenericBatch {
+	if store, ok := s.store.(GenericBatchRepository); ok {
+		return store.FindAllGenericBatches(fileID)
+	}
+	batches := s.GetBatches(fileID)
+	out := make([]ach.GenericBatch, 0, len(batches))
+	for i := range batches {
+		out = append(out, batches[i])
+	}
+	return out
+}
+
 func (s *service) DeleteBatch(fileID string, batchID string) error {
 	return s.store.DeleteBatch(fileID, batchID)
 }
@@ -282,6 +406,31 @@ func (s *service) SegmentFile(file *ach.File, opts *ach.SegmentFileConfiguration
 	return creditFile, debitFile, nil
 }
 
+// SegmentID takes an ACH FileID and segments the file according to the SegmentFileConfiguration.
+func (s *service) SegmentID(fileID string, opts *ach.SegmentFileConfiguration) ([]*ach.File, error) {
+	original, err := s.GetFile(fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clone the file to avoid mutating the original in the repository
+	f, err := cloneFile(original)
+	if err != nil {
+		return nil, fmt.Errorf("cloning file: %w", err)
+	}
+
+	return s.Segment(f, opts)
+}
+
+// Segment takes an ACH File and segments the file according to the SegmentFileConfiguration.
+func (s *service) Segment(file *ach.File, opts *ach.SegmentFileConfiguration) ([]*ach.File, error) {
+	// Build/tabulate file in the case it is malformed.
+	if err := file.Create(); err != nil {
+		return nil, err
+	}
+	return file.Segment(opts)
+}
+
 // FlattenBatches consolidates batches that have the same BatchHeader
 func (s *service) FlattenBatches(fileID string) (*ach.File, error) {
 	original, err := s.GetFile(fileID)
@@ -370,6 +519,22 @@ func (s *service) ReverseFile(fileID string, effectiveEntryDate time.Time) (*ach
 	return &cloned, nil
 }
 
+// ReverseEntries creates a NACHA compliant reversal of the chosen entries in an ACH file
+func (s *service) ReverseEntries(fileID string, traceNumbers []string, effectiveEntryDate time.Time) (*ach.File, error) {
+	f, err := s.GetFile(fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// ReverseEntries builds a new file, so the original in the repository isn't modified
+	reversed, err := f.ReverseEntries(traceNumbers, effectiveEntryDate)
+	if err != nil {
+		return nil, err
+	}
+	reversed.ID = base.ID()
+	return reversed, nil
+}
+
 // cloneFile creates a deep copy of the file via JSON serialization.
 // This prevents mutations to the returned file from affecting the original in the repository.
 // JSON is used instead of ACH Writer/Reader because it can handle files that haven't been built yet.
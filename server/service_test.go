@@ -19,6 +19,7 @@ package server
 
 import (
 	"github.com/moov-io/ach"
+	"github.com/moov-io/ach/fedach"
 	"github.com/moov-io/base"
 	"io"
 	"log"
@@ -241,6 +242,34 @@ func TestValidateFileOpts(t *testing.T) {
 	}
 }
 
+func TestValidateFileDirectory(t *testing.T) {
+	dir, err := fedach.ReadFile(filepath.Join("..", "test", "testdata", "fedach", "FedACHdir.txt"))
+	require.NoError(t, err)
+
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	defer fd.Close()
+
+	file, err := ach.NewReader(fd).Read()
+	require.NoError(t, err)
+	file.ID = "ppd-debit"
+
+	repo := NewRepositoryInMemory(testTTLDuration, nil)
+	require.NoError(t, repo.StoreFile(&file))
+
+	s := NewServiceWithDirectory(repo, dir)
+	require.NoError(t, s.ValidateFile(file.ID, nil))
+
+	file.Batches[0].GetEntries()[0].SetRDFI("021000021")
+	require.NoError(t, file.Batches[0].Create())
+	require.NoError(t, file.Create())
+	require.NoError(t, NewService(repo).ValidateFile(file.ID, nil))
+
+	opts := &ach.ValidateOpts{}
+	require.ErrorIs(t, s.ValidateFile(file.ID, opts), fedach.ErrNotParticipant)
+	require.Nil(t, opts.CheckRDFI)
+}
+
 // Service.CreateBatch tests
 
 // TestCreateBatch tests creating a new batch when file.ID exists and batch.id does not exist
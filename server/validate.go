@@ -54,6 +54,9 @@ const (
 	bypassBatchValidation            = "bypassBatchValidation"
 	skipFileCreationValidation       = "skipFileCreationValidation"
 	skipBatchHeaderCompanyValidation = "skipBatchHeaderCompanyValidation"
+	allowPreambleLines               = "allowPreambleLines"
+	allowTrailerLines                = "allowTrailerLines"
+	requireDNEPaymentInformation     = "requireDNEPaymentInformation"
 )
 
 // readValidateOpts parses ValidateOpts from the URL query parameters and from the request body.
@@ -87,6 +90,9 @@ func readValidateOpts(request *http.Request) (io.ReadCloser, *ach.ValidateOpts,
 		bypassBatchValidation,
 		skipFileCreationValidation,
 		skipBatchHeaderCompanyValidation,
+		allowPreambleLines,
+		allowTrailerLines,
+		requireDNEPaymentInformation,
 	}
 
 	var buf bytes.Buffer
@@ -156,6 +162,12 @@ func readValidateOpts(request *http.Request) (io.ReadCloser, *ach.ValidateOpts,
 			opts.SkipFileCreationValidation = yes
 		case skipBatchHeaderCompanyValidation:
 			opts.SkipBatchHeaderCompanyValidation = yes
+		case allowPreambleLines:
+			opts.AllowPreambleLines = yes
+		case allowTrailerLines:
+			opts.AllowTrailerLines = yes
+		case requireDNEPaymentInformation:
+			opts.RequireDNEPaymentInformation = yes
 		}
 	}
 
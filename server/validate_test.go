@@ -31,7 +31,7 @@ func TestReadValidateOpts(t *testing.T) {
   "bypassDestinationValidation":true,
   "allowUnorderedBatchNumbers":true
 }`)
-	req, err := http.NewRequest("POST", "/files/f1/validate?bypassDestination=false&allowInvalidCheckDigit=true&skipBatchHeaderCompanyValidation=true", body)
+	req, err := http.NewRequest("POST", "/files/f1/validate?bypassDestination=false&allowInvalidCheckDigit=true&skipBatchHeaderCompanyValidation=true&allowPreambleLines=true", body)
 	require.NoError(t, err)
 
 	_, opts, err := readValidateOpts(req)
@@ -42,4 +42,6 @@ func TestReadValidateOpts(t *testing.T) {
 	require.True(t, opts.AllowUnorderedBatchNumbers)
 	require.True(t, opts.AllowInvalidCheckDigit)
 	require.True(t, opts.SkipBatchHeaderCompanyValidation)
+	require.True(t, opts.AllowPreambleLines)
+	require.False(t, opts.AllowTrailerLines)
 }
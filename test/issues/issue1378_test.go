@@ -27,7 +27,7 @@ import (
 	"time"
 
 	"github.com/moov-io/ach"
-	"github.com/moov-io/ach/cmd/achcli/describe"
+	"github.com/moov-io/ach/describe"
 	"github.com/moov-io/ach/server"
 	"github.com/moov-io/base/log"
 
@@ -23,7 +23,7 @@ import (
 	"testing"
 
 	"github.com/moov-io/ach"
-	"github.com/moov-io/ach/cmd/achcli/describe"
+	"github.com/moov-io/ach/describe"
 	"github.com/stretchr/testify/require"
 )
 
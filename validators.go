@@ -513,6 +513,19 @@ func CheckRoutingNumber(routingNumber string) error {
 	return nil
 }
 
+// validateDigits returns an error if s is not exactly length characters of 0-9
+func validateDigits(s string, length int) error {
+	if utf8.RuneCountInString(s) != length {
+		return NewErrValidFieldLength(length)
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return ErrNonNumeric
+		}
+	}
+	return nil
+}
+
 // roundUp10 round number up to the next ten spot.
 func roundUp10(n int) int {
 	return int(math.Ceil(float64(n)/10.0)) * 10
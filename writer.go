@@ -33,12 +33,28 @@ type Writer struct {
 	LineEnding string // configurable line ending to support different consumer requirements
 	// BypassValidation can be set to skip file validation and will allow non-compliant Nacha files to be written.
 	BypassValidation bool
+	// DisableBlockPadding skips the lines of 9's which fill the last block of 10 records.
+	DisableBlockPadding bool
+
+	// Preamble and Trailer lines are written instead of the File's Preamble and Trailer when set.
+	Preamble []string
+	Trailer  []string
 }
 
 // WriteOpts defines options for writing a file.
 type WriteOpts struct {
 	// LineEnding sets a custom line ending character.
 	LineEnding string `json:"lineEnding"`
+
+	// DisableBlockPadding skips the lines of 9's which fill the last block of 10 records.
+	DisableBlockPadding bool `json:"disableBlockPadding"`
+
+	// Preamble lines, such as a "$$ADD ID=" line, are written before the FileHeader
+	// instead of the File's Preamble.
+	Preamble []string `json:"preamble"`
+
+	// Trailer lines are written after the FileControl and block padding instead of the File's Trailer.
+	Trailer []string `json:"trailer"`
 }
 
 // NewWriter returns a new Writer that writes to w.
@@ -48,14 +64,19 @@ func NewWriter(w io.Writer) *Writer {
 
 // NewWriter returns a new Writer that writes to w.
 func NewWriterWithOpts(w io.Writer, opts *WriteOpts) *Writer {
-	lineEnding := "\n"
-	if opts != nil && opts.LineEnding != "" {
-		lineEnding = opts.LineEnding
-	}
-	return &Writer{
+	out := &Writer{
 		w:          bufio.NewWriter(w),
-		LineEnding: lineEnding,
+		LineEnding: "\n",
+	}
+	if opts != nil {
+		if opts.LineEnding != "" {
+			out.LineEnding = opts.LineEnding
+		}
+		out.DisableBlockPadding = opts.DisableBlockPadding
+		out.Preamble = opts.Preamble
+		out.Trailer = opts.Trailer
 	}
+	return out
 }
 
 var (
@@ -71,6 +92,15 @@ func (w *Writer) Write(file *File) error {
 	}
 
 	w.lineNum = 0
+
+	preamble := file.Preamble
+	if len(w.Preamble) > 0 {
+		preamble = w.Preamble
+	}
+	if err := w.writeLines(preamble); err != nil {
+		return err
+	}
+
 	// Iterate over all records in the file
 	if err := w.writeLine(&file.Header); err != nil {
 		return err
@@ -97,7 +127,7 @@ func (w *Writer) Write(file *File) error {
 	}
 
 	// pad the final block
-	for i := 0; i < (10-(w.lineNum%10)) && w.lineNum%10 != 0; i++ {
+	for i := 0; i < (10-(w.lineNum%10)) && w.lineNum%10 != 0 && !w.DisableBlockPadding; i++ {
 		_, err := w.w.WriteString(paddingLine)
 		if err != nil {
 			return err
@@ -108,9 +138,30 @@ func (w *Writer) Write(file *File) error {
 		}
 	}
 
+	trailer := file.Trailer
+	if len(w.Trailer) > 0 {
+		trailer = w.Trailer
+	}
+	if err := w.writeLines(trailer); err != nil {
+		return err
+	}
+
 	return w.w.Flush()
 }
 
+// writeLines writes bank-specific lines which aren't ACH records, so they aren't counted for block padding
+func (w *Writer) writeLines(lines []string) error {
+	for _, line := range lines {
+		if _, err := w.w.WriteString(line); err != nil {
+			return err
+		}
+		if _, err := w.w.WriteString(w.LineEnding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Flush writes any buffered data to the underlying io.Writer.
 func (w *Writer) Flush() error {
 	if w == nil || w.w == nil {
@@ -768,3 +768,23 @@ func TestWriteBypassValidation(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestWriter__BankSpecificLines(t *testing.T) {
+	file := mockFilePPD(t)
+	file.Preamble = []string{"$$ADD ID=FILE"}
+
+	var buf bytes.Buffer
+	w := NewWriterWithOpts(&buf, &WriteOpts{
+		Preamble:            []string{"$$ADD ID=USER1 BID='NWFACH1234'"},
+		Trailer:             []string{"$$END"},
+		DisableBlockPadding: true,
+	})
+	require.NoError(t, w.Write(file))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Equal(t, "$$ADD ID=USER1 BID='NWFACH1234'", lines[0])
+	require.Equal(t, "$$END", lines[len(lines)-1])
+	require.Equal(t, "9000001", lines[len(lines)-2][:7])
+	require.NotContains(t, buf.String(), paddingLine)
+	require.Len(t, lines, 2+5)
+}